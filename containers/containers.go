@@ -11,6 +11,8 @@
 // Enumerable provides Ruby inspired (each, select, map, find, any?, etc.) container functions.
 //
 // Serialization provides serializers (marshalers) and deserializers (unmarshalers).
+//
+// Equal compares elements of two containers.
 package containers
 
 import "github.com/emirpasic/gods/utils"
@@ -33,3 +35,89 @@ func GetSortedValues(container Container, comparator utils.Comparator) []interfa
 	utils.Sort(values, comparator)
 	return values
 }
+
+// Equal returns true if both containers hold the same elements, comparing values with the given equaler (== if nil).
+//
+// Maps and trees are equal if they have the same keys mapped to equal values,
+// sets are equal if they have the same elements (occurring the same number of times in multisets).
+// Keys and elements are looked up with the containers' own comparators or hashers, hence ordered
+// and hash based maps and sets are compared independently of their iteration order.
+// Other containers (lists, stacks, heaps) are equal if their values are equal in iteration order.
+func Equal(a Container, b Container, valueEqual utils.Equaler) bool {
+	kind := kindOf(a)
+	if kindOf(b) != kind || a.Size() != b.Size() {
+		return false
+	}
+	if valueEqual == nil {
+		valueEqual = func(a, b interface{}) bool { return a == b }
+	}
+	switch kind {
+	case mapKind:
+		for _, key := range a.(keyedContainer).Keys() {
+			valueA, _ := a.(keyedContainer).Get(key)
+			valueB, found := b.(keyedContainer).Get(key)
+			if !found || !valueEqual(valueA, valueB) {
+				return false
+			}
+		}
+	case multisetKind:
+		for _, element := range a.Values() {
+			if a.(countingContainer).Count(element) != b.(countingContainer).Count(element) {
+				return false
+			}
+		}
+	case setKind:
+		for _, element := range a.Values() {
+			if !b.(membershipContainer).Contains(element) {
+				return false
+			}
+		}
+	default:
+		valuesA, valuesB := a.Values(), b.Values()
+		for i := range valuesA {
+			if !valueEqual(valuesA[i], valuesB[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+const (
+	sequenceKind = iota
+	mapKind
+	multisetKind
+	setKind
+)
+
+type keyedContainer interface {
+	Keys() []interface{}
+	Get(key interface{}) (value interface{}, found bool)
+}
+
+type indexedContainer interface {
+	Get(index int) (interface{}, bool)
+}
+
+type countingContainer interface {
+	Count(element interface{}) int
+}
+
+type membershipContainer interface {
+	Contains(elements ...interface{}) bool
+}
+
+// kindOf tells how the container's elements are compared by Equal.
+func kindOf(container Container) int {
+	switch container.(type) {
+	case keyedContainer:
+		return mapKind
+	case indexedContainer:
+		return sequenceKind
+	case countingContainer:
+		return multisetKind
+	case membershipContainer:
+		return setKind
+	}
+	return sequenceKind
+}
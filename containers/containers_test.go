@@ -53,3 +53,81 @@ func TestGetSortedValuesStrings(t *testing.T) {
 		}
 	}
 }
+
+// For testing purposes
+type MapTest struct {
+	ContainerTest
+	m map[interface{}]interface{}
+}
+
+func (container MapTest) Keys() []interface{} {
+	keys := []interface{}{}
+	for key := range container.m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (container MapTest) Get(key interface{}) (interface{}, bool) {
+	value, found := container.m[key]
+	return value, found
+}
+
+// For testing purposes
+type SetTest struct {
+	ContainerTest
+}
+
+func (container SetTest) Contains(elements ...interface{}) bool {
+	for _, element := range elements {
+		found := false
+		for _, value := range container.values {
+			found = found || value == element
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEqual(t *testing.T) {
+	a := ContainerTest{values: []interface{}{1, 2, 3}}
+	if actualValue := Equal(a, ContainerTest{values: []interface{}{1, 2, 3}}, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := Equal(a, ContainerTest{values: []interface{}{3, 2, 1}}, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := Equal(a, ContainerTest{values: []interface{}{1, 2}}, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := Equal(a, ContainerTest{values: []interface{}{-1, -2, -3}}, func(a, b interface{}) bool { return a.(int) == -b.(int) }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	set := SetTest{ContainerTest{values: []interface{}{1, 2, 3}}}
+	if actualValue := Equal(set, SetTest{ContainerTest{values: []interface{}{3, 2, 1}}}, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := Equal(set, SetTest{ContainerTest{values: []interface{}{3, 2, 4}}}, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := Equal(set, a, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	m := MapTest{ContainerTest{values: []interface{}{"a", "b"}}, map[interface{}]interface{}{1: "a", 2: "b"}}
+	if actualValue := Equal(m, MapTest{ContainerTest{values: []interface{}{"b", "a"}}, map[interface{}]interface{}{2: "b", 1: "a"}}, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := Equal(m, MapTest{ContainerTest{values: []interface{}{"a", "a"}}, map[interface{}]interface{}{1: "a", 2: "a"}}, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := Equal(m, MapTest{ContainerTest{values: []interface{}{"a", "b"}}, map[interface{}]interface{}{1: "a", 3: "b"}}, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := Equal(m, set, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package containertest implements conformance tests for implementations of the container interfaces.
+//
+// Each test function takes the *testing.T of the calling test and a constructor of empty containers (or an iterator
+// with the elements it is expected to iterate over), runs table-driven tests of the interface's methods and
+// randomized tests that compare the container against a simple model after each operation.
+//
+// Elements (and keys) of the tests are ints, so containers relying on comparators must be created with utils.IntComparator.
+//
+// Usage:
+//
+//	func TestListConformance(t *testing.T) {
+//		containertest.TestList(t, func() lists.List { return mylist.New() })
+//	}
+package containertest
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// operations is the number of random operations performed by the randomized tests
+const operations = 1000
+
+// elements is the number of distinct elements (and keys) used by the randomized tests
+const elements = 50
+
+// newRand returns a source of random numbers with a fixed seed, so that failures are reproducible.
+func newRand() *rand.Rand {
+	return rand.New(rand.NewSource(1))
+}
+
+// checkContainer checks the methods of Container against the expected values, in order if ordered is true.
+func checkContainer(t *testing.T, context string, container containers.Container, expected []interface{}, ordered bool) {
+	if actualValue, expectedValue := container.Size(), len(expected); actualValue != expectedValue {
+		t.Errorf("%s: Size() got %v expected %v", context, actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := container.Empty(), len(expected) == 0; actualValue != expectedValue {
+		t.Errorf("%s: Empty() got %v expected %v", context, actualValue, expectedValue)
+	}
+	values := container.Values()
+	if !ordered {
+		values, expected = sorted(values), sorted(expected)
+	}
+	if actualValue, expectedValue := fmt.Sprint(values), fmt.Sprint(expected); actualValue != expectedValue {
+		t.Errorf("%s: Values() got %v expected %v", context, actualValue, expectedValue)
+	}
+}
+
+// sorted returns a sorted copy of the int values.
+func sorted(values []interface{}) []interface{} {
+	ints := make([]int, len(values))
+	for i, value := range values {
+		if intValue, ok := value.(int); ok {
+			ints[i] = intValue
+		} else {
+			// not an int, let the comparison fail on the unsorted values
+			return values
+		}
+	}
+	sort.Ints(ints)
+	result := make([]interface{}, len(ints))
+	for i, value := range ints {
+		result[i] = value
+	}
+	return result
+}
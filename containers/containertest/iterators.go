@@ -0,0 +1,170 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package containertest
+
+import (
+	"github.com/emirpasic/gods/containers"
+	"testing"
+)
+
+// TestIteratorWithIndex tests an iterator in its initial state (one-before-first) that is expected to iterate over values in order.
+// The container of the iterator must not be modified during the test.
+func TestIteratorWithIndex(t *testing.T, iterator containers.IteratorWithIndex, values []interface{}) {
+	checkForward(t, "TestIteratorWithIndex", iterator, func(index int) bool {
+		return checkIndex(t, "TestIteratorWithIndex", iterator.Index(), iterator.Value(), index, values)
+	}, len(values))
+}
+
+// TestReverseIteratorWithIndex tests a reverse iterator in its initial state (one-before-first) that is expected to iterate over values in order.
+// The container of the iterator must not be modified during the test.
+func TestReverseIteratorWithIndex(t *testing.T, iterator containers.ReverseIteratorWithIndex, values []interface{}) {
+	check := func(index int) bool {
+		return checkIndex(t, "TestReverseIteratorWithIndex", iterator.Index(), iterator.Value(), index, values)
+	}
+	checkForward(t, "TestReverseIteratorWithIndex", iterator, check, len(values))
+	checkBackward(t, "TestReverseIteratorWithIndex", iterator, check, len(values))
+}
+
+// TestIteratorWithKey tests an iterator in its initial state (one-before-first) that is expected to iterate over
+// key-value pairs of keys and values in order. The container of the iterator must not be modified during the test.
+func TestIteratorWithKey(t *testing.T, iterator containers.IteratorWithKey, keys []interface{}, values []interface{}) {
+	checkForward(t, "TestIteratorWithKey", iterator, func(index int) bool {
+		return checkKey(t, "TestIteratorWithKey", iterator.Key(), iterator.Value(), index, keys, values)
+	}, len(keys))
+}
+
+// TestReverseIteratorWithKey tests a reverse iterator in its initial state (one-before-first) that is expected to iterate over
+// key-value pairs of keys and values in order. The container of the iterator must not be modified during the test.
+func TestReverseIteratorWithKey(t *testing.T, iterator containers.ReverseIteratorWithKey, keys []interface{}, values []interface{}) {
+	check := func(index int) bool {
+		return checkKey(t, "TestReverseIteratorWithKey", iterator.Key(), iterator.Value(), index, keys, values)
+	}
+	checkForward(t, "TestReverseIteratorWithKey", iterator, check, len(keys))
+	checkBackward(t, "TestReverseIteratorWithKey", iterator, check, len(keys))
+}
+
+// iterator holds the methods shared by all iterators
+type iterator interface {
+	Next() bool
+	Begin()
+	First() bool
+}
+
+// reverseIterator holds the methods shared by all reverse iterators
+type reverseIterator interface {
+	Prev() bool
+	End()
+	Last() bool
+	iterator
+}
+
+// checkForward checks iteration over n elements with Next, Begin and First, checking each element with check.
+func checkForward(t *testing.T, context string, it iterator, check func(index int) bool, n int) {
+	for pass := 0; pass < 2; pass++ {
+		for index := 0; index < n; index++ {
+			if !it.Next() {
+				t.Errorf("%s: pass %v Next() got %v expected %v at index %v", context, pass, false, true, index)
+				return
+			}
+			if !check(index) {
+				return
+			}
+		}
+		for i := 0; i < 2; i++ {
+			if actualValue := it.Next(); actualValue != false {
+				t.Errorf("%s: pass %v Next() got %v expected %v past the end", context, pass, actualValue, false)
+				return
+			}
+		}
+		it.Begin()
+	}
+	if actualValue, expectedValue := it.First(), n > 0; actualValue != expectedValue {
+		t.Errorf("%s: First() got %v expected %v", context, actualValue, expectedValue)
+		return
+	}
+	if n > 0 && check(0) && n > 1 && it.Next() {
+		check(1)
+	}
+	it.Begin()
+}
+
+// checkBackward checks iteration over n elements with Prev, End and Last, checking each element with check.
+func checkBackward(t *testing.T, context string, it reverseIterator, check func(index int) bool, n int) {
+	it.End()
+	for pass := 0; pass < 2; pass++ {
+		for index := n - 1; index >= 0; index-- {
+			if !it.Prev() {
+				t.Errorf("%s: pass %v Prev() got %v expected %v at index %v", context, pass, false, true, index)
+				return
+			}
+			if !check(index) {
+				return
+			}
+		}
+		for i := 0; i < 2; i++ {
+			if actualValue := it.Prev(); actualValue != false {
+				t.Errorf("%s: pass %v Prev() got %v expected %v before the beginning", context, pass, actualValue, false)
+				return
+			}
+		}
+		it.End()
+	}
+	if actualValue, expectedValue := it.Last(), n > 0; actualValue != expectedValue {
+		t.Errorf("%s: Last() got %v expected %v", context, actualValue, expectedValue)
+		return
+	}
+	if n > 0 && check(n-1) && n > 1 && it.Prev() {
+		check(n - 2)
+	}
+
+	// change of direction
+	it.Begin()
+	for index := 0; index < n; index++ {
+		if !it.Next() || !check(index) {
+			t.Errorf("%s: Next() failed at index %v", context, index)
+			return
+		}
+		if index > 0 {
+			if !it.Prev() || !check(index-1) || !it.Next() || !check(index) {
+				t.Errorf("%s: Prev() and Next() failed at index %v", context, index)
+				return
+			}
+		}
+	}
+	if it.Next() {
+		t.Errorf("%s: Next() got %v expected %v past the end", context, true, false)
+		return
+	}
+	if n > 0 && (!it.Prev() || !check(n-1)) {
+		t.Errorf("%s: Prev() failed after the end", context)
+		return
+	}
+	it.Begin()
+	if it.Prev() {
+		t.Errorf("%s: Prev() got %v expected %v before the beginning", context, true, false)
+		return
+	}
+	if n > 0 && (!it.Next() || !check(0)) {
+		t.Errorf("%s: Next() failed after the beginning", context)
+	}
+}
+
+// checkIndex checks the index and value of an element against the expected values.
+func checkIndex(t *testing.T, context string, index int, value interface{}, expectedIndex int, values []interface{}) bool {
+	if index != expectedIndex || value != values[expectedIndex] {
+		t.Errorf("%s: Index(), Value() got %v,%v expected %v,%v", context, index, value, expectedIndex, values[expectedIndex])
+		return false
+	}
+	return true
+}
+
+// checkKey checks the key and value of an element against the expected keys and values.
+func checkKey(t *testing.T, context string, key interface{}, value interface{}, expectedIndex int, keys []interface{}, values []interface{}) bool {
+	if key != keys[expectedIndex] || value != values[expectedIndex] {
+		t.Errorf("%s: Key(), Value() got %v,%v expected %v,%v at position %v", context, key, value, keys[expectedIndex], values[expectedIndex], expectedIndex)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package containertest
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/lists"
+	"github.com/emirpasic/gods/utils"
+	"testing"
+)
+
+// TestList tests an implementation of lists.List created by newList, which must return an empty list.
+func TestList(t *testing.T, newList func() lists.List) {
+	tests := []struct {
+		name     string
+		run      func(list lists.List)
+		expected []interface{}
+	}{
+		{"Add", func(list lists.List) { list.Add(1, 2); list.Add(3) }, []interface{}{1, 2, 3}},
+		{"Add nothing", func(list lists.List) { list.Add() }, []interface{}{}},
+		{"Add duplicates", func(list lists.List) { list.Add(1, 1, 2, 1) }, []interface{}{1, 1, 2, 1}},
+		{"Insert at start", func(list lists.List) { list.Add(2, 3); list.Insert(0, 0, 1) }, []interface{}{0, 1, 2, 3}},
+		{"Insert in middle", func(list lists.List) { list.Add(1, 4); list.Insert(1, 2, 3) }, []interface{}{1, 2, 3, 4}},
+		{"Insert at end", func(list lists.List) { list.Add(1); list.Insert(1, 2, 3) }, []interface{}{1, 2, 3}},
+		{"Insert into empty", func(list lists.List) { list.Insert(0, 1) }, []interface{}{1}},
+		{"Insert out of bounds", func(list lists.List) { list.Add(1); list.Insert(2, 3); list.Insert(-1, 0) }, []interface{}{1}},
+		{"Remove first", func(list lists.List) { list.Add(1, 2, 3); list.Remove(0) }, []interface{}{2, 3}},
+		{"Remove middle", func(list lists.List) { list.Add(1, 2, 3); list.Remove(1) }, []interface{}{1, 3}},
+		{"Remove last", func(list lists.List) { list.Add(1, 2, 3); list.Remove(2) }, []interface{}{1, 2}},
+		{"Remove only", func(list lists.List) { list.Add(1); list.Remove(0) }, []interface{}{}},
+		{"Remove out of bounds", func(list lists.List) { list.Add(1, 2); list.Remove(2); list.Remove(-1) }, []interface{}{1, 2}},
+		{"Remove and add", func(list lists.List) { list.Add(1, 2); list.Remove(1); list.Remove(0); list.Add(3) }, []interface{}{3}},
+		{"Swap", func(list lists.List) { list.Add(1, 2, 3); list.Swap(0, 2) }, []interface{}{3, 2, 1}},
+		{"Swap same", func(list lists.List) { list.Add(1, 2, 3); list.Swap(1, 1) }, []interface{}{1, 2, 3}},
+		{"Swap out of bounds", func(list lists.List) { list.Add(1, 2); list.Swap(0, 2); list.Swap(-1, 1) }, []interface{}{1, 2}},
+		{"Sort", func(list lists.List) { list.Add(3, 1, 2, 1); list.Sort(utils.IntComparator) }, []interface{}{1, 1, 2, 3}},
+		{"Sort empty", func(list lists.List) { list.Sort(utils.IntComparator) }, []interface{}{}},
+		{"Clear", func(list lists.List) { list.Add(1, 2); list.Clear() }, []interface{}{}},
+		{"Clear and add", func(list lists.List) { list.Add(1, 2); list.Clear(); list.Add(3) }, []interface{}{3}},
+	}
+	for _, test := range tests {
+		list := newList()
+		test.run(list)
+		checkList(t, "TestList "+test.name, list, test.expected)
+	}
+
+	random := newRand()
+	list := newList()
+	model := []interface{}{}
+	for i := 0; i < operations; i++ {
+		var operation string
+		switch n := random.Intn(100); {
+		case n < 35:
+			value := random.Intn(elements)
+			operation = fmt.Sprintf("Add(%v)", value)
+			list.Add(value)
+			model = append(model, value)
+		case n < 55:
+			index, value := random.Intn(len(model)+3)-1, random.Intn(elements)
+			operation = fmt.Sprintf("Insert(%v, %v)", index, value)
+			list.Insert(index, value)
+			if index >= 0 && index <= len(model) {
+				model = append(model[:index], append([]interface{}{value}, model[index:]...)...)
+			}
+		case n < 85:
+			index := random.Intn(len(model)+2) - 1
+			operation = fmt.Sprintf("Remove(%v)", index)
+			list.Remove(index)
+			if index >= 0 && index < len(model) {
+				model = append(model[:index], model[index+1:]...)
+			}
+		case n < 97:
+			i, j := random.Intn(len(model)+1), random.Intn(len(model)+1)
+			operation = fmt.Sprintf("Swap(%v, %v)", i, j)
+			list.Swap(i, j)
+			if i < len(model) && j < len(model) {
+				model[i], model[j] = model[j], model[i]
+			}
+		case n < 99:
+			operation = "Sort(utils.IntComparator)"
+			list.Sort(utils.IntComparator)
+			model = sorted(model)
+		default:
+			operation = "Clear()"
+			list.Clear()
+			model = model[:0]
+		}
+		checkList(t, fmt.Sprintf("TestList operation %v %v", i, operation), list, model)
+		if t.Failed() {
+			return
+		}
+	}
+}
+
+// checkList checks the methods of lists.List that do not modify the list against the expected values.
+func checkList(t *testing.T, context string, list lists.List, expected []interface{}) {
+	checkContainer(t, context, list, expected, true)
+	for index, expectedValue := range expected {
+		if actualValue, found := list.Get(index); actualValue != expectedValue || !found {
+			t.Errorf("%s: Get(%v) got %v,%v expected %v,%v", context, index, actualValue, found, expectedValue, true)
+		}
+	}
+	for _, index := range []int{-1, len(expected)} {
+		if actualValue, found := list.Get(index); actualValue != nil || found {
+			t.Errorf("%s: Get(%v) got %v,%v expected %v,%v", context, index, actualValue, found, nil, false)
+		}
+	}
+	if actualValue := list.Contains(expected...); actualValue != true {
+		t.Errorf("%s: Contains(%v) got %v expected %v", context, expected, actualValue, true)
+	}
+	if actualValue := list.Contains(); actualValue != true {
+		t.Errorf("%s: Contains() got %v expected %v", context, actualValue, true)
+	}
+	if actualValue := list.Contains(-1); actualValue != false {
+		t.Errorf("%s: Contains(-1) got %v expected %v", context, actualValue, false)
+	}
+	if len(expected) > 0 {
+		if actualValue := list.Contains(expected[0], -1); actualValue != false {
+			t.Errorf("%s: Contains(%v, -1) got %v expected %v", context, expected[0], actualValue, false)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package containertest
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/maps"
+	"testing"
+)
+
+// TestMap tests an implementation of maps.Map created by newMap, which must return an empty map.
+//
+// Values put into the map are distinct, so implementations of maps.BidiMap can be tested as well (see TestBidiMap).
+func TestMap(t *testing.T, newMap func() maps.Map) {
+	tests := []struct {
+		name     string
+		run      func(m maps.Map)
+		expected map[interface{}]interface{}
+	}{
+		{"Put", func(m maps.Map) { m.Put(1, 10); m.Put(2, 20) }, map[interface{}]interface{}{1: 10, 2: 20}},
+		{"Put existing", func(m maps.Map) { m.Put(1, 10); m.Put(2, 20); m.Put(1, 30) }, map[interface{}]interface{}{1: 30, 2: 20}},
+		{"Remove", func(m maps.Map) { m.Put(1, 10); m.Put(2, 20); m.Remove(1) }, map[interface{}]interface{}{2: 20}},
+		{"Remove missing", func(m maps.Map) { m.Put(1, 10); m.Remove(2) }, map[interface{}]interface{}{1: 10}},
+		{"Remove only", func(m maps.Map) { m.Put(1, 10); m.Remove(1) }, map[interface{}]interface{}{}},
+		{"Remove and put", func(m maps.Map) { m.Put(1, 10); m.Remove(1); m.Put(1, 20) }, map[interface{}]interface{}{1: 20}},
+		{"Clear", func(m maps.Map) { m.Put(1, 10); m.Put(2, 20); m.Clear() }, map[interface{}]interface{}{}},
+		{"Clear and put", func(m maps.Map) { m.Put(1, 10); m.Clear(); m.Put(2, 20) }, map[interface{}]interface{}{2: 20}},
+	}
+	for _, test := range tests {
+		m := newMap()
+		test.run(m)
+		checkMap(t, "TestMap "+test.name, m, test.expected)
+	}
+
+	random := newRand()
+	m := newMap()
+	model := map[interface{}]interface{}{}
+	for i := 0; i < operations; i++ {
+		var operation string
+		switch n, key := random.Intn(100), random.Intn(elements); {
+		case n < 60:
+			value := elements + i
+			operation = fmt.Sprintf("Put(%v, %v)", key, value)
+			m.Put(key, value)
+			model[key] = value
+		case n < 99:
+			operation = fmt.Sprintf("Remove(%v)", key)
+			m.Remove(key)
+			delete(model, key)
+		default:
+			operation = "Clear()"
+			m.Clear()
+			model = map[interface{}]interface{}{}
+		}
+		checkMap(t, fmt.Sprintf("TestMap operation %v %v", i, operation), m, model)
+		if t.Failed() {
+			return
+		}
+	}
+}
+
+// TestBidiMap tests an implementation of maps.BidiMap created by newMap, which must return an empty map.
+// Besides the tests of TestMap, it checks that putting a value already in the map replaces its key.
+func TestBidiMap(t *testing.T, newMap func() maps.BidiMap) {
+	TestMap(t, func() maps.Map { return newMap() })
+	if t.Failed() {
+		return
+	}
+
+	tests := []struct {
+		name     string
+		run      func(m maps.BidiMap)
+		expected map[interface{}]interface{}
+	}{
+		{"Put", func(m maps.BidiMap) { m.Put(1, 10); m.Put(2, 20) }, map[interface{}]interface{}{1: 10, 2: 20}},
+		{"Put existing key", func(m maps.BidiMap) { m.Put(1, 10); m.Put(1, 20) }, map[interface{}]interface{}{1: 20}},
+		{"Put existing value", func(m maps.BidiMap) { m.Put(1, 10); m.Put(2, 10) }, map[interface{}]interface{}{2: 10}},
+		{"Put existing pair", func(m maps.BidiMap) { m.Put(1, 10); m.Put(1, 10) }, map[interface{}]interface{}{1: 10}},
+		{"Put existing key and value", func(m maps.BidiMap) { m.Put(1, 10); m.Put(2, 20); m.Put(1, 20) }, map[interface{}]interface{}{1: 20}},
+		{"Remove", func(m maps.BidiMap) { m.Put(1, 10); m.Put(2, 20); m.Remove(1) }, map[interface{}]interface{}{2: 20}},
+	}
+	for _, test := range tests {
+		m := newMap()
+		test.run(m)
+		checkBidiMap(t, "TestBidiMap "+test.name, m, test.expected)
+	}
+
+	random := newRand()
+	m := newMap()
+	model := map[interface{}]interface{}{}
+	for i := 0; i < operations; i++ {
+		var operation string
+		switch n, key := random.Intn(100), random.Intn(elements); {
+		case n < 70:
+			value := elements + random.Intn(elements)
+			operation = fmt.Sprintf("Put(%v, %v)", key, value)
+			m.Put(key, value)
+			for k, v := range model {
+				if v == value {
+					delete(model, k)
+				}
+			}
+			model[key] = value
+		default:
+			operation = fmt.Sprintf("Remove(%v)", key)
+			m.Remove(key)
+			delete(model, key)
+		}
+		checkBidiMap(t, fmt.Sprintf("TestBidiMap operation %v %v", i, operation), m, model)
+		if t.Failed() {
+			return
+		}
+	}
+}
+
+// checkMap checks the methods of maps.Map that do not modify the map against the expected key-value pairs.
+// Keys and values are compared regardless of their order.
+func checkMap(t *testing.T, context string, m maps.Map, expected map[interface{}]interface{}) {
+	expectedKeys, expectedValues := []interface{}{}, []interface{}{}
+	for key, value := range expected {
+		expectedKeys, expectedValues = append(expectedKeys, key), append(expectedValues, value)
+	}
+	checkContainer(t, context, m, expectedValues, false)
+	if actualValue, expectedValue := fmt.Sprint(sorted(m.Keys())), fmt.Sprint(sorted(expectedKeys)); actualValue != expectedValue {
+		t.Errorf("%s: Keys() got %v expected %v", context, actualValue, expectedValue)
+	}
+	for key, expectedValue := range expected {
+		if actualValue, found := m.Get(key); actualValue != expectedValue || !found {
+			t.Errorf("%s: Get(%v) got %v,%v expected %v,%v", context, key, actualValue, found, expectedValue, true)
+		}
+	}
+	if actualValue, found := m.Get(-1); actualValue != nil || found {
+		t.Errorf("%s: Get(-1) got %v,%v expected %v,%v", context, actualValue, found, nil, false)
+	}
+}
+
+// checkBidiMap checks the methods of maps.BidiMap that do not modify the map against the expected key-value pairs.
+func checkBidiMap(t *testing.T, context string, m maps.BidiMap, expected map[interface{}]interface{}) {
+	checkMap(t, context, m, expected)
+	for expectedKey, value := range expected {
+		if actualValue, found := m.GetKey(value); actualValue != expectedKey || !found {
+			t.Errorf("%s: GetKey(%v) got %v,%v expected %v,%v", context, value, actualValue, found, expectedKey, true)
+		}
+	}
+	if actualValue, found := m.GetKey(-1); actualValue != nil || found {
+		t.Errorf("%s: GetKey(-1) got %v,%v expected %v,%v", context, actualValue, found, nil, false)
+	}
+}
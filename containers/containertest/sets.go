@@ -0,0 +1,89 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package containertest
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/sets"
+	"testing"
+)
+
+// TestSet tests an implementation of sets.Set created by newSet, which must return an empty set.
+func TestSet(t *testing.T, newSet func() sets.Set) {
+	tests := []struct {
+		name     string
+		run      func(set sets.Set)
+		expected []interface{}
+	}{
+		{"Add", func(set sets.Set) { set.Add(1, 2); set.Add(3) }, []interface{}{1, 2, 3}},
+		{"Add nothing", func(set sets.Set) { set.Add() }, []interface{}{}},
+		{"Add duplicates", func(set sets.Set) { set.Add(1, 1, 2); set.Add(2) }, []interface{}{1, 2}},
+		{"Remove", func(set sets.Set) { set.Add(1, 2, 3); set.Remove(1, 3) }, []interface{}{2}},
+		{"Remove nothing", func(set sets.Set) { set.Add(1); set.Remove() }, []interface{}{1}},
+		{"Remove missing", func(set sets.Set) { set.Add(1); set.Remove(2) }, []interface{}{1}},
+		{"Remove and add", func(set sets.Set) { set.Add(1); set.Remove(1); set.Add(1) }, []interface{}{1}},
+		{"Clear", func(set sets.Set) { set.Add(1, 2); set.Clear() }, []interface{}{}},
+		{"Clear and add", func(set sets.Set) { set.Add(1, 2); set.Clear(); set.Add(3) }, []interface{}{3}},
+	}
+	for _, test := range tests {
+		set := newSet()
+		test.run(set)
+		checkSet(t, "TestSet "+test.name, set, test.expected)
+	}
+
+	random := newRand()
+	set := newSet()
+	model := map[interface{}]bool{}
+	for i := 0; i < operations; i++ {
+		var operation string
+		switch n, value, other := random.Intn(100), random.Intn(elements), random.Intn(elements); {
+		case n < 60:
+			operation = fmt.Sprintf("Add(%v, %v)", value, other)
+			set.Add(value, other)
+			model[value], model[other] = true, true
+		case n < 99:
+			operation = fmt.Sprintf("Remove(%v, %v)", value, other)
+			set.Remove(value, other)
+			delete(model, value)
+			delete(model, other)
+		default:
+			operation = "Clear()"
+			set.Clear()
+			model = map[interface{}]bool{}
+		}
+		expected := []interface{}{}
+		for value := range model {
+			expected = append(expected, value)
+		}
+		checkSet(t, fmt.Sprintf("TestSet operation %v %v", i, operation), set, expected)
+		if t.Failed() {
+			return
+		}
+	}
+}
+
+// checkSet checks the methods of sets.Set that do not modify the set against the expected elements.
+func checkSet(t *testing.T, context string, set sets.Set, expected []interface{}) {
+	checkContainer(t, context, set, expected, false)
+	for _, value := range expected {
+		if actualValue := set.Contains(value); actualValue != true {
+			t.Errorf("%s: Contains(%v) got %v expected %v", context, value, actualValue, true)
+		}
+	}
+	if actualValue := set.Contains(expected...); actualValue != true {
+		t.Errorf("%s: Contains(%v) got %v expected %v", context, expected, actualValue, true)
+	}
+	if actualValue := set.Contains(); actualValue != true {
+		t.Errorf("%s: Contains() got %v expected %v", context, actualValue, true)
+	}
+	if actualValue := set.Contains(-1); actualValue != false {
+		t.Errorf("%s: Contains(-1) got %v expected %v", context, actualValue, false)
+	}
+	if len(expected) > 0 {
+		if actualValue := set.Contains(expected[0], -1); actualValue != false {
+			t.Errorf("%s: Contains(%v, -1) got %v expected %v", context, expected[0], actualValue, false)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package containertest
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/stacks"
+	"testing"
+)
+
+// TestStack tests an implementation of stacks.Stack created by newStack, which must return an empty stack.
+// Values() of the stack have to list elements in LIFO order.
+func TestStack(t *testing.T, newStack func() stacks.Stack) {
+	tests := []struct {
+		name     string
+		run      func(stack stacks.Stack)
+		expected []interface{} // from bottom to top
+	}{
+		{"Push", func(stack stacks.Stack) { stack.Push(1); stack.Push(2); stack.Push(3) }, []interface{}{1, 2, 3}},
+		{"Push duplicates", func(stack stacks.Stack) { stack.Push(1); stack.Push(1) }, []interface{}{1, 1}},
+		{"Pop", func(stack stacks.Stack) { stack.Push(1); stack.Push(2); stack.Pop() }, []interface{}{1}},
+		{"Pop only", func(stack stacks.Stack) { stack.Push(1); stack.Pop() }, []interface{}{}},
+		{"Pop empty", func(stack stacks.Stack) { stack.Pop(); stack.Push(1); stack.Pop(); stack.Pop() }, []interface{}{}},
+		{"Pop and push", func(stack stacks.Stack) { stack.Push(1); stack.Pop(); stack.Push(2) }, []interface{}{2}},
+		{"Peek", func(stack stacks.Stack) { stack.Push(1); stack.Peek() }, []interface{}{1}},
+		{"Clear", func(stack stacks.Stack) { stack.Push(1); stack.Push(2); stack.Clear() }, []interface{}{}},
+		{"Clear and push", func(stack stacks.Stack) { stack.Push(1); stack.Clear(); stack.Push(2) }, []interface{}{2}},
+	}
+	for _, test := range tests {
+		stack := newStack()
+		test.run(stack)
+		checkStack(t, "TestStack "+test.name, stack, test.expected)
+	}
+
+	random := newRand()
+	stack := newStack()
+	model := []interface{}{}
+	for i := 0; i < operations; i++ {
+		var operation string
+		switch n := random.Intn(100); {
+		case n < 55:
+			value := random.Intn(elements)
+			operation = fmt.Sprintf("Push(%v)", value)
+			stack.Push(value)
+			model = append(model, value)
+		case n < 99:
+			operation = "Pop()"
+			var expectedValue interface{}
+			if len(model) > 0 {
+				expectedValue, model = model[len(model)-1], model[:len(model)-1]
+			}
+			if actualValue, ok := stack.Pop(); actualValue != expectedValue || ok != (expectedValue != nil) {
+				t.Errorf("TestStack operation %v Pop() got %v,%v expected %v,%v", i, actualValue, ok, expectedValue, expectedValue != nil)
+			}
+		default:
+			operation = "Clear()"
+			stack.Clear()
+			model = model[:0]
+		}
+		checkStack(t, fmt.Sprintf("TestStack operation %v %v", i, operation), stack, model)
+		if t.Failed() {
+			return
+		}
+	}
+}
+
+// checkStack checks the methods of stacks.Stack that do not modify the stack against the expected elements (from bottom to top).
+func checkStack(t *testing.T, context string, stack stacks.Stack, expected []interface{}) {
+	lifo := make([]interface{}, len(expected))
+	for i, value := range expected {
+		lifo[len(expected)-1-i] = value
+	}
+	checkContainer(t, context, stack, lifo, true)
+	if len(expected) == 0 {
+		if actualValue, ok := stack.Peek(); actualValue != nil || ok {
+			t.Errorf("%s: Peek() got %v,%v expected %v,%v", context, actualValue, ok, nil, false)
+		}
+	} else if actualValue, ok := stack.Peek(); actualValue != lifo[0] || !ok {
+		t.Errorf("%s: Peek() got %v,%v expected %v,%v", context, actualValue, ok, lifo[0], true)
+	}
+}
@@ -4,6 +4,13 @@
 
 package containers
 
+import "errors"
+
+// ErrConcurrentModification is the value fail-fast iterators panic with when their container has been
+// structurally modified (elements added or removed) during iteration, i.e. since the iterator moved from
+// its initial state (one-before-first) or was reset with Begin() or End().
+var ErrConcurrentModification = errors.New("container modified during iteration")
+
 // IteratorWithIndex is stateful iterator for ordered containers whose values can be fetched by an index.
 type IteratorWithIndex interface {
 	// Next moves the iterator to the next element and returns true if there was a next element in the container.
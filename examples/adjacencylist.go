@@ -0,0 +1,38 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import (
+	"github.com/emirpasic/gods/graphs"
+	"github.com/emirpasic/gods/graphs/adjacencylist"
+)
+
+// AdjacencyListExample to demonstrate basic usage of graphs and their algorithms
+func AdjacencyListExample() {
+	graph := adjacencylist.NewDirected() // empty
+	graph.AddVertex("app", "v1.2")       // app
+	graph.AddEdge("app", "lib", nil)     // app -> lib (lib is added first)
+	graph.AddEdge("app", "log", nil)     // app -> lib, log
+	graph.AddEdge("lib", "log", "^1.0")  // app -> lib, log; lib -> log
+	_, _ = graph.Vertex("app")           // v1.2, true
+	_, _ = graph.Edge("lib", "log")      // ^1.0, true
+	_ = graph.ContainsEdge("log", "lib") // false
+	_ = graph.Neighbors("app")           // []interface {}{"lib", "log"}
+	_ = graph.Predecessors("log")        // []interface {}{"app", "lib"}
+	_ = graph.EdgeCount()                // 3
+	_, _ = graphs.TopologicalSort(graph) // []interface {}{"app", "lib", "log"}, nil
+	_ = graphs.HasCycle(graph)           // false
+	graph.AddEdge("log", "app", nil)     // app -> lib, log; lib -> log; log -> app
+	_ = graphs.FindCycle(graph)          // []interface {}{"app", "lib", "log"}
+	_, _ = graphs.TopologicalSort(graph) // nil, graphs.ErrCycle
+	graphs.BFS(graph, "lib", func(vertex interface{}, depth int) bool {
+		return true // visits lib (0), log (1), app (2)
+	})
+	_ = graphs.StronglyConnectedComponents(graph) // [][]interface {}{{"app", "lib", "log"}}
+	graph.RemoveVertex("log")                     // app -> lib
+	graph.Clear()                                 // empty
+	graph.Empty()                                 // true
+	graph.Size()                                  // 0
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees/bplustree"
+)
+
+// BPlusTreeExample to demonstrate basic usage of BPlusTree
+func BPlusTreeExample() {
+	tree := bplustree.NewWithIntComparator(3) // empty (keys are of type int)
+
+	tree.Put(1, "x") // 1->x
+	tree.Put(2, "b") // 1->x, 2->b (in order)
+	tree.Put(1, "a") // 1->a, 2->b (in order, replacement)
+	tree.Put(3, "c") // 1->a, 2->b, 3->c (in order)
+	tree.Put(4, "d") // 1->a, 2->b, 3->c, 4->d (in order)
+	tree.Put(5, "e") // 1->a, 2->b, 3->c, 4->d, 5->e (in order)
+
+	fmt.Println(tree)
+	// BPlusTree
+	//         1
+	//     2
+	//         2
+	// 3
+	//         3
+	//     4
+	//         4 5
+
+	_ = tree.Values() // []interface {}{"a", "b", "c", "d", "e"} (in order)
+	_ = tree.Keys()   // []interface {}{1, 2, 3, 4, 5} (in order)
+
+	// visit the keys in [2, 5) by walking the linked leaves
+	tree.Range(2, 5, func(key, value interface{}) bool {
+		fmt.Print(key, value, " ") // 2b 3c 4d
+		return true
+	})
+	fmt.Println()
+
+	// iterate from the first key greater than or equal to 3
+	for it := tree.IteratorFrom(3); it.Next(); {
+		fmt.Print(it.Key(), " ") // 3 4 5
+	}
+	fmt.Println()
+
+	tree.Remove(2) // 1->a, 3->c, 4->d, 5->e (in order)
+
+	// replace the elements with sorted keys, building the tree bottom-up
+	tree.BulkLoad([]interface{}{10, 20, 30}, []interface{}{"j", "t", "d"})
+	_ = tree.Keys() // []interface {}{10, 20, 30} (in order)
+
+	tree.Clear() // empty
+	tree.Empty() // true
+	tree.Size()  // 0
+}
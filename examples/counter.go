@@ -0,0 +1,33 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import "github.com/emirpasic/gods/maps/counter"
+
+// CounterExample to demonstrate basic usage of Counter
+func CounterExample() {
+	c := counter.NewWithStringComparator() // empty (keys are of type string)
+	c.Increment("a", 1)                    // a->1
+	c.Increment("b", 3)                    // a->1, b->3 (in order)
+	c.Increment("a", 1)                    // a->2, b->3 (in order)
+	_ = c.Count("b")                       // 3
+	_ = c.Count("c")                       // 0
+	_ = c.Total()                          // 5
+	_ = c.MostCommon(1)                    // []counter.Entry{{"b", 3}}
+	_ = c.LeastCommon(1)                   // []counter.Entry{{"a", 2}}
+
+	other := counter.NewWithStringComparator()
+	other.Increment("a", 1) // a->1
+	other.Increment("c", 4) // a->1, c->4 (in order)
+	_ = c.Add(other)        // a->3, b->3, c->4
+	_ = c.Subtract(other)   // a->1, b->3
+	_ = c.Intersect(other)  // a->1
+	_ = c.Union(other)      // a->2, b->3, c->4
+
+	c.Remove("a") // b->3
+	c.Clear()     // empty
+	c.Empty()     // true
+	c.Size()      // 0
+}
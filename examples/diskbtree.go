@@ -0,0 +1,72 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees/diskbtree"
+	"github.com/emirpasic/gods/utils"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DiskBTreeExample to demonstrate basic usage of DiskBTree
+func DiskBTreeExample() {
+	dir, err := ioutil.TempDir("", "example")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tree.db")
+
+	// keys are of type int and values of type string, nil options for 4 KiB pages
+	tree, err := diskbtree.OpenWithIntComparator(path, utils.StringCodec, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	tree.Put(1, "x") // 1->x
+	tree.Put(2, "b") // 1->x, 2->b (in order)
+	tree.Put(1, "a") // 1->a, 2->b (in order, replacement)
+	tree.Put(3, "c") // 1->a, 2->b, 3->c (in order)
+	tree.Put(4, "d") // 1->a, 2->b, 3->c, 4->d (in order)
+
+	// commit the changes, a crash from now on keeps them
+	if err := tree.Sync(); err != nil {
+		panic(err)
+	}
+
+	value, found, err := tree.Get(2) // "b", true, nil
+	fmt.Println(value, found, err)
+
+	tree.Remove(2) // 1->a, 3->c, 4->d (in order), not committed yet
+
+	// visit the keys in [1, 4)
+	tree.Range(1, 4, func(key, value interface{}) bool {
+		fmt.Print(key, value, " ") // 1a 3c
+		return true
+	})
+	fmt.Println()
+
+	// iterate in reverse order
+	it := tree.Iterator()
+	for it.End(); it.Prev(); {
+		fmt.Print(it.Key(), " ") // 4 3 1
+	}
+	if err := it.Err(); err != nil {
+		panic(err)
+	}
+	fmt.Println()
+
+	// commit and close the file
+	if err := tree.Close(); err != nil {
+		panic(err)
+	}
+
+	tree, _ = diskbtree.OpenWithIntComparator(path, utils.StringCodec, nil)
+	_ = tree.Size() // 3
+	tree.Close()
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees/fenwicktree"
+)
+
+// FenwickTreeExample to demonstrate basic usage of FenwickTree
+func FenwickTreeExample() {
+	tree := fenwicktree.New(3, 1, 4, 1, 5) // 3, 1, 4, 1, 5 (built in linear time)
+
+	_ = tree.PrefixSum(3)   // 8 (3+1+4)
+	_ = tree.RangeSum(1, 4) // 6 (1+4+1)
+	_ = tree.Sum()          // 14
+
+	tree.Update(1, 10) // 3, 11, 4, 1, 5
+	tree.Set(4, 0)     // 3, 11, 4, 1, 0
+	tree.Append(2, 6)  // 3, 11, 4, 1, 0, 2, 6
+	_, _ = tree.Get(1) // 11, true
+
+	fmt.Println(tree)
+	// FenwickTree
+	// 3, 11, 4, 1, 0, 2, 6
+
+	_ = tree.LowerBound(14) // 1 (index of the first element at which the running total reaches 14)
+	_ = tree.LowerBound(19) // 3
+	_ = tree.LowerBound(28) // 7 (Size(), total is less than 28)
+
+	_ = tree.Values() // []interface {}{3, 11, 4, 1, 0, 2, 6}
+
+	tree.Clear() // empty
+	tree.Empty() // true
+	tree.Size()  // 0
+}
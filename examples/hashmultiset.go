@@ -0,0 +1,25 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import "github.com/emirpasic/gods/sets/hashmultiset"
+
+// HashMultisetExample to demonstrate basic usage of HashMultiset
+func HashMultisetExample() {
+	set := hashmultiset.New() // empty
+	set.Add("a", 1)           // a
+	set.Add("b", 3)           // a, b, b, b (random order)
+	set.Add("a", 1)           // a, a, b, b, b (random order)
+	set.Remove("b", 2)        // a, a, b (random order)
+	_ = set.Count("a")        // 2
+	_ = set.Count("c")        // 0
+	set.SetCount("c", 2)      // a, a, b, c, c (random order)
+	set.Contains("a", "b")    // true
+	_ = set.ElementSet()      // a, b, c (random order)
+	_ = set.Values()          // []interface {}{"a", "a", "b", "c", "c"} (random order)
+	set.Clear()               // empty
+	set.Empty()               // true
+	set.Size()                // 0
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees/radixtree"
+)
+
+// RadixTreeExample to demonstrate basic usage of RadixTree
+func RadixTreeExample() {
+	tree := radixtree.New() // empty (keys are strings or byte slices)
+
+	tree.Put("/", "index")              // /->index
+	tree.Put("/api", "api")             // /->index, /api->api (in order)
+	tree.Put("/api/users", "users")     // /->index, /api->api, /api/users->users (in order)
+	tree.Put("/apidocs", "docs")        // /->index, /api->api, /api/users->users, /apidocs->docs (in order)
+	tree.Put([]byte("/about"), "about") // /->index, /about->about, /api->api, /api/users->users, /apidocs->docs (in order)
+
+	fmt.Println(tree)
+	// RadixTree
+	// └── /: index
+	//     └── a
+	//         ├── bout: about
+	//         └── pi: api
+	//             ├── /users: users
+	//             └── docs: docs
+
+	_, _ = tree.Get("/api")                         // api, true
+	_, _, _ = tree.LongestPrefix("/api/users/42")   // /api/users, users, true
+	_, _, _ = tree.LongestPrefix("/static/app.css") // /, index, true
+
+	tree.WalkPrefix("/api", func(key string, value interface{}) bool {
+		fmt.Println(key, value) // /api api, /api/users users, /apidocs docs (in order)
+		return true
+	})
+
+	_ = tree.Keys()   // []interface {}{"/", "/about", "/api", "/api/users", "/apidocs"} (in order)
+	_ = tree.Values() // []interface {}{"index", "about", "api", "users", "docs"} (in order)
+
+	tree.Remove("/api") // /->index, /about->about, /api/users->users, /apidocs->docs (in order)
+	fmt.Println(tree)
+	// RadixTree
+	// └── /: index
+	//     └── a
+	//         ├── bout: about
+	//         └── pi
+	//             ├── /users: users
+	//             └── docs: docs
+
+	tree.Clear() // empty
+	tree.Empty() // true
+	tree.Size()  // 0
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees/segmenttree"
+)
+
+// SegmentTreeExample to demonstrate basic usage of SegmentTree
+func SegmentTreeExample() {
+	// range minimum queries with range assignments
+	tree := segmenttree.NewWithUpdater(segmenttree.IntMin, segmenttree.MaxInt, segmenttree.IntMinMaxAssign, 5, 3, 8, 6, 1, 4) // 5, 3, 8, 6, 1, 4
+
+	_ = tree.Query(0, 3) // 3 (minimum of 5, 3, 8)
+	_ = tree.Query(2, 6) // 1 (minimum of 8, 6, 1, 4)
+	_ = tree.Query(3, 3) // segmenttree.MaxInt (identity, empty range)
+
+	tree.Set(4, 7)       // 5, 3, 8, 6, 7, 4
+	tree.Update(0, 2, 9) // 9, 9, 8, 6, 7, 4
+	_ = tree.Query(0, 3) // 8
+	_, _ = tree.Get(1)   // 9, true
+
+	fmt.Println(tree)
+	// SegmentTree
+	// 9, 9, 8, 6, 7, 4
+
+	// range sums with range additions
+	sums := segmenttree.NewWithUpdater(segmenttree.IntSum, 0, segmenttree.IntSumAdd, 1, 2, 3, 4) // 1, 2, 3, 4
+
+	sums.Update(1, 4, 10) // 1, 12, 13, 14
+	_ = sums.Query(0, 2)  // 13
+
+	// custom combiner without range updates
+	concat := func(left, right interface{}) interface{} { return left.(string) + right.(string) }
+	letters := segmenttree.NewWith(concat, "", "a", "b", "c", "d") // a, b, c, d
+
+	_ = letters.Query(1, 3) // "bc"
+
+	tree.Clear() // empty
+	tree.Empty() // true
+	tree.Size()  // 0
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import (
+	"github.com/emirpasic/gods/trees/treap"
+)
+
+// TreapExample to demonstrate basic usage of Treap
+func TreapExample() {
+	tree := treap.NewWithIntComparator() // empty(keys are of type int)
+
+	tree.Put(1, "x") // 1->x
+	tree.Put(2, "b") // 1->x, 2->b (in order)
+	tree.Put(1, "a") // 1->a, 2->b (in order, replacement)
+	tree.Put(3, "c") // 1->a, 2->b, 3->c (in order)
+	tree.Put(4, "d") // 1->a, 2->b, 3->c, 4->d (in order)
+	tree.Put(5, "e") // 1->a, 2->b, 3->c, 4->d, 5->e (in order)
+	tree.Put(6, "f") // 1->a, 2->b, 3->c, 4->d, 5->e, 6->f (in order)
+
+	_ = tree.Values() // []interface {}{"a", "b", "c", "d", "e", "f"} (in order)
+	_ = tree.Keys()   // []interface {}{1, 2, 3, 4, 5, 6} (in order)
+
+	left, right := tree.Split(4) // left: 1->a, 2->b, 3->c, right: 4->d, 5->e, 6->f, tree: empty
+	left.Remove(2)               // left: 1->a, 3->c
+	right.Put(7, "g")            // right: 4->d, 5->e, 6->f, 7->g
+
+	tree = treap.Join(left, right) // 1->a, 3->c, 4->d, 5->e, 6->f, 7->g, left and right: empty
+	tree.Size()                    // 6
+
+	tree.Clear() // empty
+	tree.Empty() // true
+	tree.Size()  // 0
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import "github.com/emirpasic/gods/sets/treemultiset"
+
+// TreeMultisetExample to demonstrate basic usage of TreeMultiset
+func TreeMultisetExample() {
+	set := treemultiset.NewWithStringComparator() // empty
+	set.Add("b", 1)                               // b
+	set.Add("a", 3)                               // a, a, a, b (in order)
+	set.Add("b", 1)                               // a, a, a, b, b (in order)
+	set.Remove("a", 2)                            // a, b, b (in order)
+	_ = set.Count("b")                            // 2
+	_ = set.Count("c")                            // 0
+	set.SetCount("c", 2)                          // a, b, b, c, c (in order)
+	set.Contains("a", "b")                        // true
+	_ = set.ElementSet()                          // a, b, c (in order)
+	_ = set.Values()                              // []interface {}{"a", "b", "b", "c", "c"} (in order)
+	set.Each(func(element interface{}, count interface{}) {
+		// "a" 1, "b" 2, "c" 2 (in order)
+	})
+	set.Clear() // empty
+	set.Empty() // true
+	set.Size()  // 0
+}
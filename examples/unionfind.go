@@ -0,0 +1,26 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import "github.com/emirpasic/gods/sets/unionfind"
+
+// UnionFindExample to demonstrate basic usage of UnionFind
+func UnionFindExample() {
+	uf := unionfind.New()      // empty
+	uf.MakeSet("a", "b", "c")  // {a}, {b}, {c}
+	uf.Union("a", "b")         // {a, b}, {c}
+	uf.Union("c", "d")         // {a, b}, {c, d} (d is added first)
+	uf.Union("b", "a")         // {a, b}, {c, d} (already in the same set)
+	_, _ = uf.Find("b")        // a, true (representative of the set of b)
+	_ = uf.Connected("a", "b") // true
+	_ = uf.Connected("a", "c") // false
+	_ = uf.SetSize("d")        // 2
+	_ = uf.SetCount()          // 2
+	_ = uf.Sets()              // [][]interface {}{{"a", "b"}, {"c", "d"}}
+	_ = uf.Values()            // []interface {}{"a", "b", "c", "d"} (insertion order)
+	uf.Clear()                 // empty
+	uf.Empty()                 // true
+	uf.Size()                  // 0
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package examples
+
+import (
+	"github.com/emirpasic/gods/graphs"
+	"github.com/emirpasic/gods/graphs/adjacencylist"
+)
+
+// WeightedGraphExample to demonstrate shortest paths and spanning trees of weighted graphs
+func WeightedGraphExample() {
+	graph := adjacencylist.NewUndirected()
+	network := graphs.NewWeightedWithIntWeights(graph) // edge attributes are int weights
+	network.AddEdge("a", "b", 4)                       // a -- b (4)
+	network.AddEdge("a", "c", 1)                       // a -- b (4), c (1)
+	network.AddEdge("c", "b", 2)                       // a -- b (4), c (1); b -- c (2)
+	network.AddEdge("b", "d", 5)                       // a -- b (4), c (1); b -- c (2), d (5)
+
+	paths, _ := graphs.Dijkstra(network, "a")      // shortest paths from a, nil
+	_, _ = paths.Distance("d")                     // 8, true
+	_ = paths.Path("d")                            // []interface {}{"a", "c", "b", "d"}
+	_, _ = graphs.BellmanFord(network, "a")        // same paths, negative weights allowed
+	_, _, _ = graphs.AStar(network, "a", "d", nil) // []interface {}{"a", "c", "b", "d"}, 8, nil
+	all, _ := graphs.FloydWarshall(network)        // shortest paths between all vertices, nil
+	_, _ = all.Distance("c", "d")                  // 7, true
+
+	_, _ = graphs.Kruskal(network) // []graphs.Edge{{"a", "c", 1}, {"b", "c", 2}, {"b", "d", 5}}, nil
+	_, _ = graphs.Prim(network)    // []graphs.Edge{{"a", "c", 1}, {"c", "b", 2}, {"b", "d", 5}}, nil
+}
@@ -0,0 +1,306 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package adjacencylist implements directed and undirected graphs backed by adjacency lists.
+//
+// Each vertex holds a map of the vertices it has edges to (and a map of the vertices that have edges to it in directed
+// graphs), so that adding, removing and looking up vertices and edges take the time of a map operation, and that the
+// neighbors of a vertex are listed in time proportional to their number.
+//
+// Vertices and adjacency lists are linked hash maps, ordered by insertion, or tree maps, ordered by the comparator (see NewDirectedWith).
+// Each pair of vertices has at most one edge between them (in each direction in directed graphs), loops are allowed.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Adjacency_list
+package adjacencylist
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/graphs"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/maps/linkedhashmap"
+	"github.com/emirpasic/gods/maps/treemap"
+	"github.com/emirpasic/gods/utils"
+	"strings"
+)
+
+func assertGraphImplementation() {
+	var _ graphs.Graph = (*Graph)(nil)
+}
+
+// Graph holds the vertices with their adjacency lists
+type Graph struct {
+	vertices maps.Map        // vertex -> *vertex
+	directed bool            // true if edges are ordered pairs of vertices
+	edges    int             // number of edges, undirected edges counted once
+	newMap   func() maps.Map // instantiates maps of vertices and adjacency lists
+}
+
+// vertex holds the attribute of a vertex and its adjacency lists
+type vertex struct {
+	attribute interface{}
+	out       maps.Map // neighbor -> attribute of the edge to the neighbor
+	in        maps.Map // predecessor -> attribute of the edge from the predecessor, nil in undirected graphs
+}
+
+// NewDirected instantiates a directed graph with vertices ordered by insertion.
+// Vertices should be comparable (see Go's spec), otherwise methods panic.
+func NewDirected() *Graph {
+	return newGraph(true, func() maps.Map { return linkedhashmap.New() })
+}
+
+// NewUndirected instantiates an undirected graph with vertices ordered by insertion.
+// Vertices should be comparable (see Go's spec), otherwise methods panic.
+func NewUndirected() *Graph {
+	return newGraph(false, func() maps.Map { return linkedhashmap.New() })
+}
+
+// NewDirectedWith instantiates a directed graph with vertices ordered by the custom comparator.
+func NewDirectedWith(comparator utils.Comparator) *Graph {
+	return newGraph(true, func() maps.Map { return treemap.NewWith(comparator) })
+}
+
+// NewUndirectedWith instantiates an undirected graph with vertices ordered by the custom comparator.
+func NewUndirectedWith(comparator utils.Comparator) *Graph {
+	return newGraph(false, func() maps.Map { return treemap.NewWith(comparator) })
+}
+
+func newGraph(directed bool, newMap func() maps.Map) *Graph {
+	return &Graph{vertices: newMap(), directed: directed, newMap: newMap}
+}
+
+// Directed returns true if edges are ordered pairs of vertices, false if they are unordered.
+func (g *Graph) Directed() bool {
+	return g.directed
+}
+
+// AddVertex adds the vertex with the attribute, replacing the attribute if the vertex is already in the graph.
+func (g *Graph) AddVertex(vertex interface{}, attribute interface{}) {
+	g.vertex(vertex).attribute = attribute
+}
+
+// RemoveVertex removes the vertex and its edges from the graph.
+func (g *Graph) RemoveVertex(vertex interface{}) {
+	v := g.lookup(vertex)
+	if v == nil {
+		return
+	}
+	g.edges -= v.out.Size()
+	for _, neighbor := range v.out.Keys() {
+		if g.directed {
+			g.lookup(neighbor).in.Remove(vertex)
+		} else {
+			g.lookup(neighbor).out.Remove(vertex)
+		}
+	}
+	if g.directed {
+		for _, predecessor := range v.in.Keys() {
+			if p := g.lookup(predecessor); p != v { // loops are counted with the edges to neighbors
+				p.out.Remove(vertex)
+				g.edges--
+			}
+		}
+	}
+	g.vertices.Remove(vertex)
+}
+
+// ContainsVertex returns true if the vertex is in the graph.
+func (g *Graph) ContainsVertex(vertex interface{}) bool {
+	return g.lookup(vertex) != nil
+}
+
+// Vertex returns the attribute of the vertex, second return parameter is true if the vertex was found, otherwise false.
+func (g *Graph) Vertex(vertex interface{}) (attribute interface{}, found bool) {
+	if v := g.lookup(vertex); v != nil {
+		return v.attribute, true
+	}
+	return nil, false
+}
+
+// Vertices returns all vertices of the graph in order of insertion or in order of the comparator.
+func (g *Graph) Vertices() []interface{} {
+	return g.vertices.Keys()
+}
+
+// AddEdge adds the edge between the vertices with the attribute, replacing the attribute if the edge is already in the graph.
+// Vertices that are not in the graph are added with nil attributes.
+func (g *Graph) AddEdge(from interface{}, to interface{}, attribute interface{}) {
+	source, target := g.vertex(from), g.vertex(to)
+	if _, found := source.out.Get(to); !found {
+		g.edges++
+	}
+	source.out.Put(to, attribute)
+	if g.directed {
+		target.in.Put(from, attribute)
+	} else {
+		target.out.Put(from, attribute)
+	}
+}
+
+// RemoveEdge removes the edge between the vertices from the graph.
+func (g *Graph) RemoveEdge(from interface{}, to interface{}) {
+	source, target := g.lookup(from), g.lookup(to)
+	if source == nil || target == nil {
+		return
+	}
+	if _, found := source.out.Get(to); !found {
+		return
+	}
+	source.out.Remove(to)
+	if g.directed {
+		target.in.Remove(from)
+	} else {
+		target.out.Remove(from)
+	}
+	g.edges--
+}
+
+// ContainsEdge returns true if the edge between the vertices is in the graph.
+func (g *Graph) ContainsEdge(from interface{}, to interface{}) bool {
+	_, found := g.Edge(from, to)
+	return found
+}
+
+// Edge returns the attribute of the edge between the vertices, second return parameter is true if the edge was found, otherwise false.
+func (g *Graph) Edge(from interface{}, to interface{}) (attribute interface{}, found bool) {
+	if v := g.lookup(from); v != nil {
+		return v.out.Get(to)
+	}
+	return nil, false
+}
+
+// Edges returns all edges of the graph ordered by the vertices they are from and then by the vertices they are to.
+// Each edge of an undirected graph is returned once, from the vertex that comes first.
+func (g *Graph) Edges() []graphs.Edge {
+	edges := make([]graphs.Edge, 0, g.edges)
+	visited := g.newMap() // vertices whose undirected edges are already listed
+	for _, from := range g.vertices.Keys() {
+		out := g.lookup(from).out
+		for _, to := range out.Keys() {
+			if _, found := visited.Get(to); found && !g.directed {
+				continue
+			}
+			attribute, _ := out.Get(to)
+			edges = append(edges, graphs.Edge{From: from, To: to, Attribute: attribute})
+		}
+		visited.Put(from, true)
+	}
+	return edges
+}
+
+// EdgeCount returns the number of edges of the graph.
+func (g *Graph) EdgeCount() int {
+	return g.edges
+}
+
+// Neighbors returns the vertices the vertex has edges to (all adjacent vertices in undirected graphs).
+// Returns nil if the vertex is not in the graph.
+func (g *Graph) Neighbors(vertex interface{}) []interface{} {
+	if v := g.lookup(vertex); v != nil {
+		return v.out.Keys()
+	}
+	return nil
+}
+
+// Predecessors returns the vertices that have edges to the vertex (all adjacent vertices in undirected graphs).
+// Returns nil if the vertex is not in the graph.
+func (g *Graph) Predecessors(vertex interface{}) []interface{} {
+	v := g.lookup(vertex)
+	if v == nil {
+		return nil
+	}
+	if g.directed {
+		return v.in.Keys()
+	}
+	return v.out.Keys()
+}
+
+// Empty returns true if graph does not contain any vertices.
+func (g *Graph) Empty() bool {
+	return g.vertices.Empty()
+}
+
+// Size returns number of vertices within the graph.
+func (g *Graph) Size() int {
+	return g.vertices.Size()
+}
+
+// Clear removes all vertices and edges from the graph.
+func (g *Graph) Clear() {
+	g.vertices.Clear()
+	g.edges = 0
+}
+
+// Values returns all vertices of the graph (see Vertices).
+func (g *Graph) Values() []interface{} {
+	return g.Vertices()
+}
+
+// Clone returns a shallow copy of the graph with the same ordering (vertices and attributes themselves are not copied).
+func (g *Graph) Clone() *Graph {
+	clone := newGraph(g.directed, g.newMap)
+	clone.edges = g.edges
+	for _, key := range g.vertices.Keys() {
+		v := g.lookup(key)
+		copied := &vertex{attribute: v.attribute, out: g.copy(v.out)}
+		if g.directed {
+			copied.in = g.copy(v.in)
+		}
+		clone.vertices.Put(key, copied)
+	}
+	return clone
+}
+
+// String returns a string representation of container
+func (g *Graph) String() string {
+	str, arrow := "UndirectedGraph\n", " -- "
+	if g.directed {
+		str, arrow = "DirectedGraph\n", " -> "
+	}
+	lines := []string{}
+	for _, key := range g.vertices.Keys() {
+		line := fmt.Sprintf("%v", key)
+		neighbors := []string{}
+		for _, neighbor := range g.lookup(key).out.Keys() {
+			neighbors = append(neighbors, fmt.Sprintf("%v", neighbor))
+		}
+		if len(neighbors) > 0 {
+			line += arrow + strings.Join(neighbors, ", ")
+		}
+		lines = append(lines, line)
+	}
+	return str + strings.Join(lines, "\n")
+}
+
+// lookup returns the vertex or nil if it is not in the graph.
+func (g *Graph) lookup(key interface{}) *vertex {
+	if v, found := g.vertices.Get(key); found {
+		return v.(*vertex)
+	}
+	return nil
+}
+
+// vertex returns the vertex, adding it with a nil attribute if it is not in the graph.
+func (g *Graph) vertex(key interface{}) *vertex {
+	if v := g.lookup(key); v != nil {
+		return v
+	}
+	v := &vertex{out: g.newMap()}
+	if g.directed {
+		v.in = g.newMap()
+	}
+	g.vertices.Put(key, v)
+	return v
+}
+
+// copy returns a copy of the adjacency list with its neighbors in the same order.
+func (g *Graph) copy(list maps.Map) maps.Map {
+	copied := g.newMap()
+	for _, key := range list.Keys() {
+		value, _ := list.Get(key)
+		copied.Put(key, value)
+	}
+	return copied
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package adjacencylist
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/utils"
+	"testing"
+)
+
+func TestGraphDirected(t *testing.T) {
+	graph := NewDirected()
+	graph.AddVertex("a", 1)
+	graph.AddEdge("a", "b", "ab")
+	graph.AddEdge("a", "c", "ac")
+	graph.AddEdge("b", "c", "bc")
+	graph.AddEdge("c", "a", "ca")
+	graph.AddEdge("a", "b", "AB") // replace
+
+	if actualValue, expectedValue := graph.Directed(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := graph.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := graph.EdgeCount(), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Vertices()), "[a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Neighbors("a")), "[b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Predecessors("c")), "[a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Edges()), "[{a b AB} {a c ac} {b c bc} {c a ca}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := graph.Vertex("a"); actualValue != 1 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, found := graph.Vertex("b"); actualValue != nil || !found {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, found := graph.Vertex("x"); actualValue != nil || found {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, found := graph.Edge("a", "b"); actualValue != "AB" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "AB")
+	}
+	if actualValue, expectedValue := graph.ContainsEdge("b", "a"), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := graph.String(), "DirectedGraph\na -> b, c\nb -> c\nc -> a"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graph.RemoveEdge("a", "c")
+	graph.RemoveEdge("a", "c") // already removed
+	graph.RemoveEdge("x", "y") // not in graph
+	if actualValue, expectedValue := graph.EdgeCount(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Predecessors("c")), "[b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graph.AddEdge("c", "c", nil) // loop
+	graph.RemoveVertex("c")
+	if actualValue, expectedValue := graph.EdgeCount(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Predecessors("a")), "[]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Neighbors("b")), "[]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := graph.Neighbors("c"); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	graph.Clear()
+	if actualValue, expectedValue := graph.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := graph.EdgeCount(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestGraphUndirected(t *testing.T) {
+	graph := NewUndirectedWith(utils.IntComparator)
+	graph.AddEdge(3, 1, "31")
+	graph.AddEdge(1, 2, "12")
+	graph.AddEdge(2, 3, "23")
+	graph.AddEdge(2, 2, "22")
+	graph.AddVertex(4, "four")
+
+	if actualValue, expectedValue := graph.Directed(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := graph.EdgeCount(), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Values()), "[1 2 3 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Edges()), "[{1 2 12} {1 3 31} {2 2 22} {2 3 23}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Neighbors(2)), "[1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Predecessors(3)), "[1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := graph.Edge(1, 3); actualValue != "31" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "31")
+	}
+	if actualValue, expectedValue := graph.String(), "UndirectedGraph\n1 -- 2, 3\n2 -- 1, 2, 3\n3 -- 1, 2\n4"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graph.RemoveEdge(3, 1)
+	if actualValue, expectedValue := graph.ContainsEdge(1, 3), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	graph.RemoveVertex(2)
+	if actualValue, expectedValue := graph.EdgeCount(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Neighbors(1)), "[]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := graph.ContainsVertex(2), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := graph.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestGraphClone(t *testing.T) {
+	graph := NewDirected()
+	graph.AddEdge(1, 2, nil)
+	graph.AddEdge(3, 2, nil)
+	graph.AddEdge(2, 1, nil)
+
+	clone := graph.Clone()
+	clone.RemoveVertex(2)
+	clone.AddEdge(1, 3, nil)
+
+	if actualValue, expectedValue := graph.String(), "DirectedGraph\n1 -> 2\n2 -> 1\n3 -> 2"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(graph.Predecessors(2)), "[1 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := clone.String(), "DirectedGraph\n1 -> 3\n3"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := clone.EdgeCount(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := graph.EdgeCount(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func BenchmarkGraphAddEdge100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		graph := NewDirected()
+		for n := 0; n < size; n++ {
+			graph.AddEdge(n, (n*7)%size, nil)
+		}
+	}
+}
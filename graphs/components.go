@@ -0,0 +1,95 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphs
+
+import "github.com/emirpasic/gods/stacks/arraystack"
+
+// StronglyConnectedComponents returns the strongly connected components of the graph, i.e. the maximal sets of vertices
+// in which every vertex is reachable from every other vertex, using Tarjan's algorithm.
+// Components of a directed graph are returned in reverse topological order (a component comes after all components it
+// has edges to), components of an undirected graph are its connected components.
+// Vertices of each component are in order of discovery by the depth-first search.
+// Vertices must be comparable (usable as keys of Go maps).
+func StronglyConnectedComponents(g Graph) [][]interface{} {
+	search := &componentSearch{
+		graph:   g,
+		indexes: make(map[interface{}]int, g.Size()),
+		lows:    make(map[interface{}]int, g.Size()),
+		stacked: make(map[interface{}]bool, g.Size()),
+		stack:   arraystack.New(),
+	}
+	for _, vertex := range g.Vertices() {
+		if _, found := search.indexes[vertex]; !found {
+			search.visit(vertex)
+		}
+	}
+	return search.components
+}
+
+// componentSearch holds the state of Tarjan's depth-first search for strongly connected components
+type componentSearch struct {
+	graph      Graph
+	indexes    map[interface{}]int  // vertex -> order of its discovery
+	lows       map[interface{}]int  // vertex -> lowest index reachable from its subtree through the stacked vertices
+	stacked    map[interface{}]bool // vertex -> true if it is on the stack
+	stack      *arraystack.Stack    // visited vertices that are not assigned to a component yet
+	components [][]interface{}
+}
+
+// visit assigns the root and its descendants to components.
+// The path of the search is kept on an explicit stack, so that long paths do not grow the call stack.
+func (search *componentSearch) visit(root interface{}) {
+	path := arraystack.New()
+	search.discover(root)
+	path.Push(&frame{root, search.graph.Neighbors(root)})
+	for !path.Empty() {
+		value, _ := path.Peek()
+		current := value.(*frame)
+		vertex := current.vertex
+		if len(current.neighbors) > 0 {
+			neighbor := current.neighbors[0]
+			current.neighbors = current.neighbors[1:]
+			if _, found := search.indexes[neighbor]; !found {
+				search.discover(neighbor)
+				path.Push(&frame{neighbor, search.graph.Neighbors(neighbor)})
+			} else if search.stacked[neighbor] && search.indexes[neighbor] < search.lows[vertex] {
+				search.lows[vertex] = search.indexes[neighbor]
+			}
+			continue
+		}
+		path.Pop()
+		if value, found := path.Peek(); found {
+			if parent := value.(*frame).vertex; search.lows[vertex] < search.lows[parent] {
+				search.lows[parent] = search.lows[vertex]
+			}
+		}
+		if search.lows[vertex] == search.indexes[vertex] {
+			search.assign(vertex)
+		}
+	}
+}
+
+// discover numbers the vertex in order of discovery and pushes it on the stack of vertices without component.
+func (search *componentSearch) discover(vertex interface{}) {
+	index := len(search.indexes)
+	search.indexes[vertex] = index
+	search.lows[vertex] = index
+	search.stack.Push(vertex)
+	search.stacked[vertex] = true
+}
+
+// assign pops the component of the vertex, which is the first of its vertices that was discovered, off the stack.
+func (search *componentSearch) assign(vertex interface{}) {
+	component := []interface{}{}
+	for {
+		value, _ := search.stack.Pop()
+		search.stacked[value] = false
+		component = append(component, value)
+		if value == vertex {
+			break
+		}
+	}
+	search.components = append(search.components, reverse(component))
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package graphs provides an abstract Graph interface and algorithms working on any of its implementations.
+//
+// In computer science, a graph is an abstract data type that is meant to implement the undirected graph and directed graph concepts from the field of graph theory within mathematics.
+// A graph consists of a set of vertices together with a set of edges, i.e. pairs of vertices, which are unordered for undirected graphs and ordered for directed graphs.
+//
+// Vertices and edges carry an attribute each (e.g. a label, a weight or any user defined value, nil if not needed).
+// Edge attributes are used as weights by wrapping a graph in a WeightedGraph.
+//
+// Reference: https://en.wikipedia.org/wiki/Graph_(abstract_data_type)
+package graphs
+
+import (
+	"errors"
+	"github.com/emirpasic/gods/containers"
+)
+
+var (
+	// ErrCycle is returned by algorithms that require an acyclic graph when the graph has a cycle.
+	ErrCycle = errors.New("graphs: graph has a cycle")
+	// ErrUndirected is returned by algorithms that require a directed graph when the graph is undirected.
+	ErrUndirected = errors.New("graphs: graph is undirected")
+	// ErrDirected is returned by algorithms that require an undirected graph when the graph is directed.
+	ErrDirected = errors.New("graphs: graph is directed")
+	// ErrNegativeWeight is returned by algorithms that require non-negative weights when the graph has a negative weight.
+	ErrNegativeWeight = errors.New("graphs: graph has a negative weight")
+	// ErrNegativeCycle is returned by shortest path algorithms when the graph has a cycle of negative total weight.
+	ErrNegativeCycle = errors.New("graphs: graph has a negative cycle")
+	// ErrNoPath is returned by path finding algorithms when there is no path between the vertices.
+	ErrNoPath = errors.New("graphs: no path between the vertices")
+)
+
+// Graph interface that all graphs implement
+type Graph interface {
+	// Directed returns true if edges are ordered pairs of vertices, false if they are unordered.
+	Directed() bool
+
+	// AddVertex adds the vertex with the attribute, replacing the attribute if the vertex is already in the graph.
+	AddVertex(vertex interface{}, attribute interface{})
+	// RemoveVertex removes the vertex and its edges from the graph.
+	RemoveVertex(vertex interface{})
+	// ContainsVertex returns true if the vertex is in the graph.
+	ContainsVertex(vertex interface{}) bool
+	// Vertex returns the attribute of the vertex, second return parameter is true if the vertex was found, otherwise false.
+	Vertex(vertex interface{}) (attribute interface{}, found bool)
+	// Vertices returns all vertices of the graph.
+	Vertices() []interface{}
+
+	// AddEdge adds the edge between the vertices with the attribute, replacing the attribute if the edge is already in the graph.
+	// Vertices that are not in the graph are added with nil attributes.
+	AddEdge(from interface{}, to interface{}, attribute interface{})
+	// RemoveEdge removes the edge between the vertices from the graph.
+	RemoveEdge(from interface{}, to interface{})
+	// ContainsEdge returns true if the edge between the vertices is in the graph.
+	ContainsEdge(from interface{}, to interface{}) bool
+	// Edge returns the attribute of the edge between the vertices, second return parameter is true if the edge was found, otherwise false.
+	Edge(from interface{}, to interface{}) (attribute interface{}, found bool)
+	// Edges returns all edges of the graph, each edge of an undirected graph once.
+	Edges() []Edge
+	// EdgeCount returns the number of edges of the graph.
+	EdgeCount() int
+
+	// Neighbors returns the vertices the vertex has edges to (all adjacent vertices in undirected graphs).
+	Neighbors(vertex interface{}) []interface{}
+	// Predecessors returns the vertices that have edges to the vertex (all adjacent vertices in undirected graphs).
+	Predecessors(vertex interface{}) []interface{}
+
+	containers.Container
+	// Empty() bool
+	// Size() int
+	// Clear()
+	// Values() []interface{}
+}
+
+// Edge is an edge of a graph between two vertices, with its attribute
+type Edge struct {
+	From      interface{}
+	To        interface{}
+	Attribute interface{}
+}
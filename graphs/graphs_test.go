@@ -0,0 +1,176 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphs_test
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/graphs"
+	"github.com/emirpasic/gods/graphs/adjacencylist"
+	"testing"
+)
+
+// newDirected returns a directed graph with the edges given as pairs of vertices
+func newDirected(edges ...int) graphs.Graph {
+	graph := adjacencylist.NewDirected()
+	for i := 0; i+1 < len(edges); i += 2 {
+		graph.AddEdge(edges[i], edges[i+1], nil)
+	}
+	return graph
+}
+
+// newUndirected returns an undirected graph with the edges given as pairs of vertices
+func newUndirected(edges ...int) graphs.Graph {
+	graph := adjacencylist.NewUndirected()
+	for i := 0; i+1 < len(edges); i += 2 {
+		graph.AddEdge(edges[i], edges[i+1], nil)
+	}
+	return graph
+}
+
+func TestBFS(t *testing.T) {
+	graph := newDirected(1, 2, 1, 3, 2, 4, 3, 4, 4, 5, 6, 1)
+	visited := []string{}
+	graphs.BFS(graph, 1, func(vertex interface{}, depth int) bool {
+		visited = append(visited, fmt.Sprintf("%v:%v", vertex, depth))
+		return true
+	})
+	if actualValue, expectedValue := fmt.Sprint(visited), "[1:0 2:1 3:1 4:2 5:3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	visited = []string{}
+	graphs.BFS(graph, 1, func(vertex interface{}, depth int) bool {
+		visited = append(visited, fmt.Sprintf("%v:%v", vertex, depth))
+		return depth < 1
+	})
+	if actualValue, expectedValue := fmt.Sprint(visited), "[1:0 2:1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graphs.BFS(graph, 7, func(vertex interface{}, depth int) bool {
+		t.Errorf("Got %v visited in a graph without the start vertex", vertex)
+		return true
+	})
+}
+
+func TestDFS(t *testing.T) {
+	graph := newUndirected(1, 2, 1, 3, 2, 4, 3, 4, 4, 5)
+	visited := []string{}
+	graphs.DFS(graph, 1, func(vertex interface{}, depth int) bool {
+		visited = append(visited, fmt.Sprintf("%v:%v", vertex, depth))
+		return true
+	})
+	if actualValue, expectedValue := fmt.Sprint(visited), "[1:0 2:1 4:2 3:3 5:3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	visited = []string{}
+	graphs.DFS(graph, 3, func(vertex interface{}, depth int) bool {
+		visited = append(visited, fmt.Sprint(vertex))
+		return vertex != 2
+	})
+	if actualValue, expectedValue := fmt.Sprint(visited), "[3 1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	size := 100000
+	graph = adjacencylist.NewDirected()
+	for n := 0; n < size; n++ {
+		graph.AddEdge(n, n+1, nil)
+	}
+	count := 0
+	graphs.DFS(graph, 0, func(vertex interface{}, depth int) bool {
+		count++
+		return true
+	})
+	if actualValue, expectedValue := count, size+1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	graph := newDirected(5, 11, 7, 11, 7, 8, 3, 8, 3, 10, 11, 2, 11, 9, 11, 10, 8, 9)
+	sorted, err := graphs.TopologicalSort(graph)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(sorted), "[5 7 3 11 8 2 10 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graph.AddEdge(9, 7, nil)
+	if _, err := graphs.TopologicalSort(graph); err != graphs.ErrCycle {
+		t.Errorf("Got %v expected %v", err, graphs.ErrCycle)
+	}
+	if _, err := graphs.TopologicalSort(newUndirected(1, 2)); err != graphs.ErrUndirected {
+		t.Errorf("Got %v expected %v", err, graphs.ErrUndirected)
+	}
+
+	sorted, err = graphs.TopologicalSort(newDirected())
+	if err != nil || len(sorted) != 0 {
+		t.Errorf("Got %v, %v expected an empty order", sorted, err)
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	tests := []struct {
+		graph    graphs.Graph
+		expected string
+	}{
+		{newDirected(1, 2, 2, 3, 1, 3), "[]"},
+		{newDirected(1, 2, 2, 3, 3, 4, 4, 2), "[2 3 4]"},
+		{newDirected(1, 2, 2, 1), "[1 2]"},
+		{newDirected(1, 2, 3, 3), "[3]"},
+		{newDirected(), "[]"},
+		{newUndirected(1, 2, 2, 3, 3, 4), "[]"},
+		{newUndirected(1, 2, 2, 3, 3, 4, 4, 2), "[2 3 4]"},
+		{newUndirected(1, 2, 2, 2), "[2]"},
+	}
+	for i, test := range tests {
+		if actualValue, expectedValue := fmt.Sprint(graphs.FindCycle(test.graph)), test.expected; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for graph %v", actualValue, expectedValue, i)
+		}
+		if actualValue, expectedValue := graphs.HasCycle(test.graph), test.expected != "[]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for graph %v", actualValue, expectedValue, i)
+		}
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	graph := newDirected(1, 2, 2, 3, 3, 1, 3, 4, 4, 5, 5, 6, 6, 4, 7, 6, 7, 8, 8, 7)
+	graph.AddVertex(9, nil)
+	if actualValue, expectedValue := fmt.Sprint(graphs.StronglyConnectedComponents(graph)), "[[4 5 6] [1 2 3] [7 8] [9]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graph = newUndirected(1, 2, 3, 4, 4, 5, 2, 6)
+	if actualValue, expectedValue := fmt.Sprint(graphs.StronglyConnectedComponents(graph)), "[[1 2 6] [3 4 5]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue := graphs.StronglyConnectedComponents(newDirected()); len(actualValue) != 0 {
+		t.Errorf("Got %v expected no components", actualValue)
+	}
+}
+
+func TestSearchesOnLongPath(t *testing.T) {
+	// a path of many vertices closed into a cycle by its last edge, searched without recursion
+	size := 100000
+	edges := make([]int, 0, 2*size)
+	for i := 0; i < size; i++ {
+		edges = append(edges, i, (i+1)%size)
+	}
+	graph := newDirected(edges...)
+	if actualValue, expectedValue := len(graphs.FindCycle(graph)), size; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	components := graphs.StronglyConnectedComponents(graph)
+	if actualValue, expectedValue := len(components), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := len(components[0]), size; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
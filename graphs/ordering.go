@@ -0,0 +1,127 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphs
+
+import (
+	"github.com/emirpasic/gods/lists/doublylinkedlist"
+	"github.com/emirpasic/gods/stacks/arraystack"
+)
+
+// TopologicalSort returns the vertices of the directed graph ordered such that each vertex comes before all the vertices
+// it has edges to (e.g. dependencies ordered after the dependents that require them).
+// Among the vertices that can go next, the ones that became available first (or come first in Vertices) go first.
+// Returns ErrUndirected if the graph is undirected and ErrCycle if the graph has a cycle (see FindCycle).
+// Vertices must be comparable (usable as keys of Go maps).
+func TopologicalSort(g Graph) ([]interface{}, error) {
+	if !g.Directed() {
+		return nil, ErrUndirected
+	}
+	degrees := make(map[interface{}]int, g.Size()) // vertex -> number of its predecessors that are not sorted yet
+	queue := doublylinkedlist.New()
+	for _, vertex := range g.Vertices() {
+		degrees[vertex] = len(g.Predecessors(vertex))
+		if degrees[vertex] == 0 {
+			queue.Add(vertex)
+		}
+	}
+	sorted := make([]interface{}, 0, g.Size())
+	for !queue.Empty() {
+		vertex, _ := queue.Get(0)
+		queue.Remove(0)
+		sorted = append(sorted, vertex)
+		for _, neighbor := range g.Neighbors(vertex) {
+			degrees[neighbor]--
+			if degrees[neighbor] == 0 {
+				queue.Add(neighbor)
+			}
+		}
+	}
+	if len(sorted) < g.Size() {
+		return nil, ErrCycle
+	}
+	return sorted, nil
+}
+
+// HasCycle returns true if the graph has a cycle (see FindCycle).
+func HasCycle(g Graph) bool {
+	return FindCycle(g) != nil
+}
+
+// FindCycle returns the vertices of a cycle of the graph in order along its edges (the edge from the last vertex back
+// to the first one closes the cycle), or nil if the graph is acyclic.
+// In undirected graphs an edge does not make a cycle by being traversed back and forth, but a loop (an edge from a vertex
+// to itself) is a cycle of that vertex alone, as it is in directed graphs.
+// Vertices must be comparable (usable as keys of Go maps).
+func FindCycle(g Graph) []interface{} {
+	search := &cycleSearch{
+		graph:   g,
+		colors:  make(map[interface{}]color, g.Size()),
+		parents: make(map[interface{}]interface{}, g.Size()),
+	}
+	for _, vertex := range g.Vertices() {
+		if search.colors[vertex] == white {
+			if cycle := search.visit(vertex); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+type color int
+
+const (
+	white color = iota // not visited yet
+	gray               // on the path of the current search
+	black              // visited with all of its descendants
+)
+
+// cycleSearch holds the state of a depth-first search for a cycle
+type cycleSearch struct {
+	graph   Graph
+	colors  map[interface{}]color
+	parents map[interface{}]interface{} // vertex -> vertex it was reached from (roots have no parents)
+}
+
+// visit searches for a cycle through the descendants of the root and returns it, or nil if there is none.
+// The path of the search is kept on an explicit stack, so that long paths do not grow the call stack.
+func (search *cycleSearch) visit(root interface{}) []interface{} {
+	path := arraystack.New()
+	search.colors[root] = gray
+	path.Push(&frame{root, search.graph.Neighbors(root)})
+	for !path.Empty() {
+		value, _ := path.Peek()
+		current := value.(*frame)
+		if len(current.neighbors) == 0 {
+			search.colors[current.vertex] = black
+			path.Pop()
+			continue
+		}
+		neighbor := current.neighbors[0]
+		current.neighbors = current.neighbors[1:]
+		if parent, found := search.parents[current.vertex]; found && parent == neighbor && !search.graph.Directed() {
+			continue // edge the vertex was reached through
+		}
+		switch search.colors[neighbor] {
+		case gray:
+			return search.path(neighbor, current.vertex)
+		case white:
+			search.parents[neighbor] = current.vertex
+			search.colors[neighbor] = gray
+			path.Push(&frame{neighbor, search.graph.Neighbors(neighbor)})
+		}
+	}
+	return nil
+}
+
+// path returns the vertices on the path of the search from its ancestor to the vertex.
+func (search *cycleSearch) path(ancestor interface{}, vertex interface{}) []interface{} {
+	path := []interface{}{vertex}
+	for vertex != ancestor {
+		vertex = search.parents[vertex]
+		path = append(path, vertex)
+	}
+	return reverse(path)
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphs
+
+import "github.com/emirpasic/gods/trees/binaryheap"
+
+// ShortestPaths holds the shortest paths from a source vertex to all vertices reachable from it
+type ShortestPaths struct {
+	source       interface{}
+	distances    map[interface{}]interface{} // vertex -> weight of the shortest path to the vertex
+	predecessors map[interface{}]interface{} // vertex -> previous vertex on the shortest path to the vertex
+}
+
+func newShortestPaths(source interface{}) *ShortestPaths {
+	return &ShortestPaths{
+		source:       source,
+		distances:    make(map[interface{}]interface{}),
+		predecessors: make(map[interface{}]interface{}),
+	}
+}
+
+// Source returns the vertex the paths start from.
+func (paths *ShortestPaths) Source() interface{} {
+	return paths.source
+}
+
+// Distance returns the weight of the shortest path from the source to the vertex, second return parameter is true if
+// the vertex is reachable from the source, otherwise false.
+func (paths *ShortestPaths) Distance(vertex interface{}) (distance interface{}, found bool) {
+	distance, found = paths.distances[vertex]
+	return
+}
+
+// Path returns the vertices on the shortest path from the source to the vertex, both included.
+// Returns nil if the vertex is not reachable from the source.
+func (paths *ShortestPaths) Path(vertex interface{}) []interface{} {
+	if _, found := paths.distances[vertex]; !found {
+		return nil
+	}
+	path := []interface{}{vertex}
+	for vertex != paths.source {
+		vertex = paths.predecessors[vertex]
+		path = append(path, vertex)
+	}
+	return reverse(path)
+}
+
+// candidate is a vertex waiting in the priority queue with the weight of the path it was reached with
+type candidate struct {
+	vertex   interface{}
+	distance interface{} // weight of the path to the vertex
+	priority interface{} // distance plus the estimated weight of the rest of the path (A* only)
+}
+
+// Dijkstra returns the shortest paths from the source vertex to all vertices reachable from it using Dijkstra's algorithm,
+// which takes O((V+E) log V) time with a binary heap as the priority queue.
+// Returns ErrNegativeWeight if an edge with a negative weight is reachable from the source.
+// Vertices must be comparable (usable as keys of Go maps).
+func Dijkstra(g *WeightedGraph, source interface{}) (*ShortestPaths, error) {
+	paths, _, err := search(g, source, nil, nil)
+	return paths, err
+}
+
+// AStar returns the vertices on the shortest path from the source vertex to the target vertex (both included) and the
+// weight of the path using the A* search algorithm, which explores the vertices in order of the weights of the paths to
+// them plus the heuristic's estimate of the weights of the shortest paths from them to the target.
+// The heuristic must not overestimate the weights for the path to be the shortest one (e.g. straight line distances in
+// geometric graphs) and search is the fastest when it is consistent, i.e. the estimate for a vertex is not greater than
+// the weight of an edge from it plus the estimate for the vertex the edge is to. A nil heuristic estimates zero for all
+// vertices, which makes the search equivalent to Dijkstra's algorithm.
+// Returns ErrNoPath if the target is not reachable from the source and ErrNegativeWeight if a reachable edge with a
+// negative weight is found.
+// Vertices must be comparable (usable as keys of Go maps).
+func AStar(g *WeightedGraph, source interface{}, target interface{}, heuristic func(vertex interface{}) interface{}) (path []interface{}, distance interface{}, err error) {
+	if heuristic == nil {
+		heuristic = func(vertex interface{}) interface{} { return g.Zero }
+	}
+	paths, found, err := search(g, source, target, heuristic)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !found {
+		return nil, nil, ErrNoPath
+	}
+	distance, _ = paths.Distance(target)
+	return paths.Path(target), distance, nil
+}
+
+// search explores the graph from the source in order of the priorities of the reached vertices until the target is
+// reached (if heuristic is not nil) or all reachable vertices are explored (otherwise).
+// Second return parameter is true if the target was reached.
+func search(g *WeightedGraph, source interface{}, target interface{}, heuristic func(vertex interface{}) interface{}) (*ShortestPaths, bool, error) {
+	paths := newShortestPaths(source)
+	if !g.ContainsVertex(source) {
+		return paths, false, nil
+	}
+	priority := func(vertex interface{}, distance interface{}) interface{} {
+		if heuristic == nil {
+			return distance
+		}
+		return g.Adder(distance, heuristic(vertex))
+	}
+	queue := binaryheap.NewWith(func(a, b interface{}) int {
+		return g.Comparator(a.(candidate).priority, b.(candidate).priority)
+	})
+	paths.distances[source] = g.Zero
+	queue.Push(candidate{source, g.Zero, priority(source, g.Zero)})
+	for !queue.Empty() {
+		value, _ := queue.Pop()
+		current := value.(candidate)
+		if g.Comparator(current.distance, paths.distances[current.vertex]) > 0 {
+			continue // vertex was reached through a shorter path since
+		}
+		if heuristic != nil && current.vertex == target {
+			return paths, true, nil
+		}
+		for _, neighbor := range g.Neighbors(current.vertex) {
+			weight, _ := g.Weight(current.vertex, neighbor)
+			if g.negative(weight) {
+				return nil, false, ErrNegativeWeight
+			}
+			distance := g.Adder(current.distance, weight)
+			if known, found := paths.distances[neighbor]; !found || g.Comparator(distance, known) < 0 {
+				paths.distances[neighbor] = distance
+				paths.predecessors[neighbor] = current.vertex
+				queue.Push(candidate{neighbor, distance, priority(neighbor, distance)})
+			}
+		}
+	}
+	return paths, false, nil
+}
+
+// BellmanFord returns the shortest paths from the source vertex to all vertices reachable from it using the
+// Bellman–Ford algorithm, which allows negative weights, but takes O(V*E) time.
+// Returns ErrNegativeCycle if a cycle with a negative total weight is reachable from the source (in undirected graphs
+// each edge with a negative weight is such a cycle).
+// Vertices must be comparable (usable as keys of Go maps).
+func BellmanFord(g *WeightedGraph, source interface{}) (*ShortestPaths, error) {
+	paths := newShortestPaths(source)
+	if !g.ContainsVertex(source) {
+		return paths, nil
+	}
+	paths.distances[source] = g.Zero
+	vertices := g.Vertices()
+	for i := 0; i < len(vertices); i++ {
+		relaxed := false
+		for _, vertex := range vertices {
+			known, found := paths.distances[vertex]
+			if !found {
+				continue
+			}
+			for _, neighbor := range g.Neighbors(vertex) {
+				weight, _ := g.Weight(vertex, neighbor)
+				distance := g.Adder(known, weight)
+				if current, found := paths.distances[neighbor]; !found || g.Comparator(distance, current) < 0 {
+					paths.distances[neighbor] = distance
+					paths.predecessors[neighbor] = vertex
+					relaxed = true
+				}
+			}
+		}
+		if !relaxed {
+			return paths, nil
+		}
+	}
+	// paths are still shortened after as many rounds as there are vertices, while V-1 rounds suffice without negative cycles
+	return nil, ErrNegativeCycle
+}
+
+// AllShortestPaths holds the shortest paths between all pairs of vertices
+type AllShortestPaths struct {
+	indexes   map[interface{}]int // vertex -> index of the vertex in vertices
+	vertices  []interface{}
+	distances [][]interface{} // distances[i][j] is the weight of the shortest path from vertex i to vertex j, nil if there is none
+	next      [][]int         // next[i][j] is the index of the vertex after vertex i on the shortest path to vertex j
+}
+
+// Distance returns the weight of the shortest path between the vertices, second return parameter is true if there is a
+// path between the vertices, otherwise false.
+func (paths *AllShortestPaths) Distance(from interface{}, to interface{}) (distance interface{}, found bool) {
+	i, foundFrom := paths.indexes[from]
+	j, foundTo := paths.indexes[to]
+	if !foundFrom || !foundTo || paths.distances[i][j] == nil {
+		return nil, false
+	}
+	return paths.distances[i][j], true
+}
+
+// Path returns the vertices on the shortest path between the vertices, both included.
+// Returns nil if there is no path between the vertices.
+func (paths *AllShortestPaths) Path(from interface{}, to interface{}) []interface{} {
+	if _, found := paths.Distance(from, to); !found {
+		return nil
+	}
+	i, j := paths.indexes[from], paths.indexes[to]
+	path := []interface{}{from}
+	for i != j {
+		i = paths.next[i][j]
+		path = append(path, paths.vertices[i])
+	}
+	return path
+}
+
+// FloydWarshall returns the shortest paths between all pairs of vertices using the Floyd–Warshall algorithm, which
+// allows negative weights and takes O(V^3) time and O(V^2) space.
+// Returns ErrNegativeCycle if the graph has a cycle with a negative total weight (in undirected graphs each edge with a
+// negative weight is such a cycle).
+// Vertices must be comparable (usable as keys of Go maps).
+func FloydWarshall(g *WeightedGraph) (*AllShortestPaths, error) {
+	vertices := g.Vertices()
+	n := len(vertices)
+	paths := &AllShortestPaths{
+		indexes:   make(map[interface{}]int, n),
+		vertices:  vertices,
+		distances: make([][]interface{}, n),
+		next:      make([][]int, n),
+	}
+	for i, vertex := range vertices {
+		paths.indexes[vertex] = i
+		paths.distances[i] = make([]interface{}, n)
+		paths.next[i] = make([]int, n)
+		paths.distances[i][i] = g.Zero
+		paths.next[i][i] = i
+	}
+	for i, vertex := range vertices {
+		for _, neighbor := range g.Neighbors(vertex) {
+			j := paths.indexes[neighbor]
+			weight, _ := g.Weight(vertex, neighbor)
+			if paths.distances[i][j] == nil || g.Comparator(weight, paths.distances[i][j]) < 0 {
+				paths.distances[i][j] = weight
+				paths.next[i][j] = j
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if paths.distances[i][k] == nil {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if paths.distances[k][j] == nil {
+					continue
+				}
+				distance := g.Adder(paths.distances[i][k], paths.distances[k][j])
+				if paths.distances[i][j] == nil || g.Comparator(distance, paths.distances[i][j]) < 0 {
+					paths.distances[i][j] = distance
+					paths.next[i][j] = paths.next[i][k]
+				}
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if g.negative(paths.distances[i][i]) {
+			return nil, ErrNegativeCycle
+		}
+	}
+	return paths, nil
+}
+
+// reverse reverses the order of the vertices in place and returns them.
+func reverse(vertices []interface{}) []interface{} {
+	for i, j := 0, len(vertices)-1; i < j; i, j = i+1, j-1 {
+		vertices[i], vertices[j] = vertices[j], vertices[i]
+	}
+	return vertices
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphs
+
+import (
+	"github.com/emirpasic/gods/sets/unionfind"
+	"github.com/emirpasic/gods/trees/binaryheap"
+	"github.com/emirpasic/gods/utils"
+)
+
+// Kruskal returns the edges of a minimum spanning forest of the undirected graph (a minimum spanning tree of each of its
+// connected components) in order of their weights using Kruskal's algorithm, which takes O(E log E) time.
+// Returns ErrDirected if the graph is directed.
+// Vertices must be comparable (usable as keys of Go maps).
+func Kruskal(g *WeightedGraph) ([]Edge, error) {
+	if g.Directed() {
+		return nil, ErrDirected
+	}
+	edges := []interface{}{}
+	for _, edge := range g.Edges() {
+		edges = append(edges, edge)
+	}
+	utils.Sort(edges, g.edgeComparator)
+	components := unionfind.New()
+	forest := []Edge{}
+	for _, value := range edges {
+		edge := value.(Edge)
+		if components.Union(edge.From, edge.To) {
+			forest = append(forest, edge)
+		}
+	}
+	return forest, nil
+}
+
+// Prim returns the edges of a minimum spanning forest of the undirected graph (a minimum spanning tree of each of its
+// connected components) using Prim's algorithm, which takes O(E log V) time with a binary heap as the priority queue.
+// Trees are grown from the vertices in order of Vertices and edges are returned in order of their addition to the trees,
+// each edge from the vertex in the tree to the vertex it adds.
+// Returns ErrDirected if the graph is directed.
+// Vertices must be comparable (usable as keys of Go maps).
+func Prim(g *WeightedGraph) ([]Edge, error) {
+	if g.Directed() {
+		return nil, ErrDirected
+	}
+	visited := make(map[interface{}]bool, g.Size())
+	queue := binaryheap.NewWith(g.edgeComparator)
+	visit := func(vertex interface{}) {
+		visited[vertex] = true
+		for _, neighbor := range g.Neighbors(vertex) {
+			if !visited[neighbor] {
+				weight, _ := g.Weight(vertex, neighbor)
+				queue.Push(Edge{vertex, neighbor, weight})
+			}
+		}
+	}
+	forest := []Edge{}
+	for _, vertex := range g.Vertices() {
+		if visited[vertex] {
+			continue
+		}
+		visit(vertex)
+		for !queue.Empty() {
+			value, _ := queue.Pop()
+			edge := value.(Edge)
+			if !visited[edge.To] {
+				forest = append(forest, edge)
+				visit(edge.To)
+			}
+		}
+	}
+	return forest, nil
+}
+
+// edgeComparator compares edges by their weights.
+func (g *WeightedGraph) edgeComparator(a, b interface{}) int {
+	return g.Comparator(a.(Edge).Attribute, b.(Edge).Attribute)
+}
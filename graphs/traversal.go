@@ -0,0 +1,82 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphs
+
+import (
+	"github.com/emirpasic/gods/lists/doublylinkedlist"
+	"github.com/emirpasic/gods/stacks/arraystack"
+)
+
+// step is a vertex waiting to be visited together with its depth
+type step struct {
+	vertex interface{}
+	depth  int
+}
+
+// frame is a vertex on the path of a depth-first search together with its neighbors that are left to be searched
+type frame struct {
+	vertex    interface{}
+	neighbors []interface{}
+}
+
+// BFS visits the vertices reachable from the start vertex in breadth-first order, passing each vertex with its
+// depth (number of edges on the shortest path from the start vertex) to the visit function.
+// Neighbors of a vertex are visited in the order returned by the graph's Neighbors.
+// Traversal stops as soon as the visit function returns false. Nothing is visited if the start vertex is not in the graph.
+// Vertices must be comparable (usable as keys of Go maps).
+func BFS(g Graph, start interface{}, visit func(vertex interface{}, depth int) bool) {
+	if !g.ContainsVertex(start) {
+		return
+	}
+	visited := map[interface{}]bool{start: true}
+	queue := doublylinkedlist.New()
+	queue.Add(step{start, 0})
+	for !queue.Empty() {
+		value, _ := queue.Get(0)
+		queue.Remove(0)
+		current := value.(step)
+		if !visit(current.vertex, current.depth) {
+			return
+		}
+		for _, neighbor := range g.Neighbors(current.vertex) {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				queue.Add(step{neighbor, current.depth + 1})
+			}
+		}
+	}
+}
+
+// DFS visits the vertices reachable from the start vertex in depth-first order (preorder), passing each vertex with its
+// depth (number of edges on the path from the start vertex in the depth-first search tree) to the visit function.
+// Neighbors of a vertex are visited in the order returned by the graph's Neighbors, i.e. in the same order a recursive
+// search would visit them, but without recursion, so that long paths do not grow the call stack.
+// Traversal stops as soon as the visit function returns false. Nothing is visited if the start vertex is not in the graph.
+// Vertices must be comparable (usable as keys of Go maps).
+func DFS(g Graph, start interface{}, visit func(vertex interface{}, depth int) bool) {
+	if !g.ContainsVertex(start) {
+		return
+	}
+	visited := map[interface{}]bool{}
+	stack := arraystack.New()
+	stack.Push(step{start, 0})
+	for !stack.Empty() {
+		value, _ := stack.Pop()
+		current := value.(step)
+		if visited[current.vertex] {
+			continue
+		}
+		visited[current.vertex] = true
+		if !visit(current.vertex, current.depth) {
+			return
+		}
+		neighbors := g.Neighbors(current.vertex)
+		for i := len(neighbors) - 1; i >= 0; i-- {
+			if !visited[neighbors[i]] {
+				stack.Push(step{neighbors[i], current.depth + 1})
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphs
+
+import "github.com/emirpasic/gods/utils"
+
+// Adder returns the sum of two weights.
+type Adder func(a, b interface{}) interface{}
+
+// IntAdder provides a basic sum of ints
+func IntAdder(a, b interface{}) interface{} {
+	return a.(int) + b.(int)
+}
+
+// Float64Adder provides a basic sum of float64s
+func Float64Adder(a, b interface{}) interface{} {
+	return a.(float64) + b.(float64)
+}
+
+// WeightedGraph is a graph whose edge attributes are weights, which are ordered by the comparator and summed by the adder.
+// Weights of paths are sums of the weights of their edges, starting with zero for paths without edges.
+//
+// The graph is embedded, i.e. a weighted graph is a graph itself and its edges are added to the underlying graph.
+type WeightedGraph struct {
+	Graph
+	Comparator utils.Comparator
+	Adder      Adder
+	Zero       interface{}
+}
+
+// NewWeighted instantiates a weighted graph on top of the graph with the custom weights.
+func NewWeighted(graph Graph, comparator utils.Comparator, adder Adder, zero interface{}) *WeightedGraph {
+	return &WeightedGraph{Graph: graph, Comparator: comparator, Adder: adder, Zero: zero}
+}
+
+// NewWeightedWithIntWeights instantiates a weighted graph on top of the graph with weights of type int.
+func NewWeightedWithIntWeights(graph Graph) *WeightedGraph {
+	return NewWeighted(graph, utils.IntComparator, IntAdder, 0)
+}
+
+// NewWeightedWithFloat64Weights instantiates a weighted graph on top of the graph with weights of type float64.
+func NewWeightedWithFloat64Weights(graph Graph) *WeightedGraph {
+	return NewWeighted(graph, utils.Float64Comparator, Float64Adder, 0.0)
+}
+
+// Weight returns the weight of the edge between the vertices, second return parameter is true if the edge was found, otherwise false.
+func (g *WeightedGraph) Weight(from interface{}, to interface{}) (weight interface{}, found bool) {
+	return g.Edge(from, to)
+}
+
+// negative returns true if the weight is less than zero.
+func (g *WeightedGraph) negative(weight interface{}) bool {
+	return g.Comparator(weight, g.Zero) < 0
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package graphs_test
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/graphs"
+	"github.com/emirpasic/gods/graphs/adjacencylist"
+	"testing"
+)
+
+// newRoads returns a weighted directed graph with int weights
+func newRoads() *graphs.WeightedGraph {
+	graph := graphs.NewWeightedWithIntWeights(adjacencylist.NewDirected())
+	graph.AddEdge("a", "b", 4)
+	graph.AddEdge("a", "c", 2)
+	graph.AddEdge("c", "b", 1)
+	graph.AddEdge("b", "d", 5)
+	graph.AddEdge("c", "d", 8)
+	graph.AddEdge("c", "e", 10)
+	graph.AddEdge("d", "e", 2)
+	graph.AddEdge("e", "f", 3)
+	graph.AddEdge("d", "f", 6)
+	graph.AddVertex("g", nil)
+	return graph
+}
+
+func TestDijkstra(t *testing.T) {
+	paths, err := graphs.Dijkstra(newRoads(), "a")
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	tests := []struct {
+		vertex   string
+		distance interface{}
+		path     string
+	}{
+		{"a", 0, "[a]"},
+		{"b", 3, "[a c b]"},
+		{"d", 8, "[a c b d]"},
+		{"f", 13, "[a c b d e f]"},
+		{"g", nil, "[]"},
+		{"x", nil, "[]"},
+	}
+	for _, test := range tests {
+		if actualValue, _ := paths.Distance(test.vertex); actualValue != test.distance {
+			t.Errorf("Got %v expected %v for %v", actualValue, test.distance, test.vertex)
+		}
+		if actualValue := fmt.Sprint(paths.Path(test.vertex)); actualValue != test.path {
+			t.Errorf("Got %v expected %v for %v", actualValue, test.path, test.vertex)
+		}
+	}
+	if actualValue, expectedValue := paths.Source(), "a"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graph := graphs.NewWeightedWithFloat64Weights(adjacencylist.NewUndirected())
+	graph.AddEdge(1, 2, 0.5)
+	graph.AddEdge(2, 3, 0.25)
+	graph.AddEdge(1, 3, 1.0)
+	paths, err = graphs.Dijkstra(graph, 3)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := paths.Distance(1); actualValue != 0.75 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 0.75)
+	}
+
+	graph.AddEdge(3, 4, -1.0)
+	if _, err := graphs.Dijkstra(graph, 1); err != graphs.ErrNegativeWeight {
+		t.Errorf("Got %v expected %v", err, graphs.ErrNegativeWeight)
+	}
+}
+
+func TestBellmanFord(t *testing.T) {
+	paths, err := graphs.BellmanFord(newRoads(), "a")
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := paths.Distance("f"); actualValue != 13 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 13)
+	}
+	if actualValue, expectedValue := fmt.Sprint(paths.Path("e")), "[a c b d e]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graph := graphs.NewWeightedWithIntWeights(adjacencylist.NewDirected())
+	graph.AddEdge("a", "b", 4)
+	graph.AddEdge("a", "c", 5)
+	graph.AddEdge("c", "b", -3)
+	graph.AddEdge("b", "d", 1)
+	paths, err = graphs.BellmanFord(graph, "a")
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := paths.Distance("d"); actualValue != 3 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, expectedValue := fmt.Sprint(paths.Path("d")), "[a c b d]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	graph.AddEdge("b", "c", 2)
+	if _, err := graphs.BellmanFord(graph, "a"); err != graphs.ErrNegativeCycle {
+		t.Errorf("Got %v expected %v", err, graphs.ErrNegativeCycle)
+	}
+	if _, err := graphs.BellmanFord(graph, "d"); err != nil {
+		t.Errorf("Got error %v for a source that does not reach the cycle", err)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	path, distance, err := graphs.AStar(newRoads(), "a", "f", nil)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(path, distance), "[a c b d e f] 13"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, _, err := graphs.AStar(newRoads(), "a", "g", nil); err != graphs.ErrNoPath {
+		t.Errorf("Got %v expected %v", err, graphs.ErrNoPath)
+	}
+	if _, _, err := graphs.AStar(newRoads(), "x", "a", nil); err != graphs.ErrNoPath {
+		t.Errorf("Got %v expected %v", err, graphs.ErrNoPath)
+	}
+
+	// grid with walls, where Manhattan distances never overestimate
+	size := 10
+	grid := graphs.NewWeightedWithIntWeights(adjacencylist.NewUndirected())
+	wall := func(x, y int) bool { return (x == 3 && y < 8) || (x == 6 && y > 1) }
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			if wall(x, y) {
+				continue
+			}
+			grid.AddVertex([2]int{x, y}, nil)
+			if x > 0 && !wall(x-1, y) {
+				grid.AddEdge([2]int{x - 1, y}, [2]int{x, y}, 1)
+			}
+			if y > 0 && !wall(x, y-1) {
+				grid.AddEdge([2]int{x, y - 1}, [2]int{x, y}, 1)
+			}
+		}
+	}
+	source := [2]int{0, 0}
+	paths, err := graphs.Dijkstra(grid, source)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	for _, vertex := range grid.Vertices() {
+		target := vertex.([2]int)
+		manhattan := func(vertex interface{}) interface{} {
+			position := vertex.([2]int)
+			return abs(position[0]-target[0]) + abs(position[1]-target[1])
+		}
+		path, distance, err := graphs.AStar(grid, source, target, manhattan)
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if expectedValue, _ := paths.Distance(target); distance != expectedValue || len(path) != distance.(int)+1 {
+			t.Errorf("Got %v expected %v for %v", distance, expectedValue, target)
+		}
+	}
+	if _, distance, _ := graphs.AStar(grid, source, [2]int{9, 0}, nil); distance != 25 {
+		t.Errorf("Got %v expected %v", distance, 25)
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestFloydWarshall(t *testing.T) {
+	paths, err := graphs.FloydWarshall(newRoads())
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	tests := []struct {
+		from, to string
+		distance interface{}
+		path     string
+	}{
+		{"a", "f", 13, "[a c b d e f]"},
+		{"c", "e", 8, "[c b d e]"},
+		{"b", "f", 10, "[b d e f]"},
+		{"g", "g", 0, "[g]"},
+		{"f", "a", nil, "[]"},
+		{"a", "x", nil, "[]"},
+	}
+	for _, test := range tests {
+		if actualValue, _ := paths.Distance(test.from, test.to); actualValue != test.distance {
+			t.Errorf("Got %v expected %v from %v to %v", actualValue, test.distance, test.from, test.to)
+		}
+		if actualValue := fmt.Sprint(paths.Path(test.from, test.to)); actualValue != test.path {
+			t.Errorf("Got %v expected %v from %v to %v", actualValue, test.path, test.from, test.to)
+		}
+	}
+
+	graph := graphs.NewWeightedWithIntWeights(adjacencylist.NewUndirected())
+	graph.AddEdge(1, 2, 3)
+	graph.AddEdge(2, 3, -1)
+	if _, err := graphs.FloydWarshall(graph); err != graphs.ErrNegativeCycle {
+		t.Errorf("Got %v expected %v", err, graphs.ErrNegativeCycle)
+	}
+}
+
+// newSpanned returns a weighted undirected graph with two connected components
+func newSpanned() *graphs.WeightedGraph {
+	graph := graphs.NewWeightedWithIntWeights(adjacencylist.NewUndirected())
+	graph.AddEdge("a", "b", 7)
+	graph.AddEdge("a", "d", 5)
+	graph.AddEdge("b", "c", 8)
+	graph.AddEdge("b", "d", 9)
+	graph.AddEdge("b", "e", 7)
+	graph.AddEdge("c", "e", 5)
+	graph.AddEdge("d", "e", 15)
+	graph.AddEdge("d", "f", 6)
+	graph.AddEdge("e", "f", 8)
+	graph.AddEdge("e", "g", 9)
+	graph.AddEdge("f", "g", 11)
+	graph.AddEdge("h", "i", 1)
+	graph.AddEdge("i", "i", 0)
+	return graph
+}
+
+func TestKruskal(t *testing.T) {
+	forest, err := graphs.Kruskal(newSpanned())
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := len(forest), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	total := 0
+	for i, edge := range forest {
+		total += edge.Attribute.(int)
+		if i > 0 && edge.Attribute.(int) < forest[i-1].Attribute.(int) {
+			t.Errorf("Got %v after %v", edge, forest[i-1])
+		}
+	}
+	if actualValue, expectedValue := total, 40; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, err := graphs.Kruskal(newRoads()); err != graphs.ErrDirected {
+		t.Errorf("Got %v expected %v", err, graphs.ErrDirected)
+	}
+}
+
+func TestPrim(t *testing.T) {
+	forest, err := graphs.Prim(newSpanned())
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(forest), "[{a d 5} {d f 6} {a b 7} {b e 7} {e c 5} {e g 9} {h i 1}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, err := graphs.Prim(newRoads()); err != graphs.ErrDirected {
+		t.Errorf("Got %v expected %v", err, graphs.ErrDirected)
+	}
+}
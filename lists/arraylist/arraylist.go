@@ -22,8 +22,10 @@ func assertListImplementation() {
 
 // List holds the elements in a slice
 type List struct {
-	elements []interface{}
-	size     int
+	elements      []interface{}
+	size          int
+	equaler       utils.Equaler // nil if elements are compared with ==
+	modifications int           // number of structural modifications, used by iterators to fail fast
 }
 
 const (
@@ -36,8 +38,20 @@ func New() *List {
 	return &List{}
 }
 
+// NewWith instantiates a new empty list whose membership operations consider
+// elements equal if the custom comparator returns zero.
+func NewWith(comparator utils.Comparator) *List {
+	return &List{equaler: utils.EqualerFromComparator(comparator)}
+}
+
+// NewWithEqualer instantiates a new empty list whose membership operations use the custom equaler.
+func NewWithEqualer(equaler utils.Equaler) *List {
+	return &List{equaler: equaler}
+}
+
 // Add appends a value at the end of the list
 func (list *List) Add(values ...interface{}) {
+	list.modifications++
 	list.growBy(len(values))
 	for _, value := range values {
 		list.elements[list.size] = value
@@ -63,6 +77,7 @@ func (list *List) Remove(index int) {
 		return
 	}
 
+	list.modifications++
 	list.elements[index] = nil                                    // cleanup reference
 	copy(list.elements[index:], list.elements[index+1:list.size]) // shift to the left by one (slow operation, need ways to optimize this)
 	list.size--
@@ -77,20 +92,18 @@ func (list *List) Remove(index int) {
 func (list *List) Contains(values ...interface{}) bool {
 
 	for _, searchValue := range values {
-		found := false
-		for _, element := range list.elements {
-			if element == searchValue {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if list.IndexOf(searchValue) == -1 {
 			return false
 		}
 	}
 	return true
 }
 
+// ContainsFunc returns true if the given function returns true for any element in the list.
+func (list *List) ContainsFunc(f func(value interface{}) bool) bool {
+	return list.IndexOfFunc(f) != -1
+}
+
 // Values returns all elements in the list.
 func (list *List) Values() []interface{} {
 	newElements := make([]interface{}, list.size, list.size)
@@ -98,18 +111,36 @@ func (list *List) Values() []interface{} {
 	return newElements
 }
 
-//IndexOf returns index of provided element
-func (list *List) IndexOf(value interface{}) int{
-	if list.size == 0 {
-		return -1
+// IndexOf returns index of the first occurrence of the provided element or -1 if it is not found.
+func (list *List) IndexOf(value interface{}) int {
+	for index, element := range list.elements[:list.size] {
+		if list.equal(element, value) {
+			return index
+		}
 	}
-		for index, element := range list.elements {
-			if element == value {
-				return index
-			}
+	return -1
+}
+
+// LastIndexOf returns index of the last occurrence of the provided element or -1 if it is not found.
+func (list *List) LastIndexOf(value interface{}) int {
+	for index := list.size - 1; index >= 0; index-- {
+		if list.equal(list.elements[index], value) {
+			return index
 		}
-		return -1
+	}
+	return -1
 }
+
+// IndexOfFunc returns index of the first element for which the given function returns true or -1 if there is none.
+func (list *List) IndexOfFunc(f func(value interface{}) bool) int {
+	for index, element := range list.elements[:list.size] {
+		if f(element) {
+			return index
+		}
+	}
+	return -1
+}
+
 // Empty returns true if list does not contain any elements.
 func (list *List) Empty() bool {
 	return list.size == 0
@@ -122,15 +153,24 @@ func (list *List) Size() int {
 
 // Clear removes all elements from the list.
 func (list *List) Clear() {
+	list.modifications++
 	list.size = 0
 	list.elements = []interface{}{}
 }
 
+// Clone returns a shallow copy of the list (elements themselves are not copied).
+func (list *List) Clone() *List {
+	elements := make([]interface{}, list.size)
+	copy(elements, list.elements[:list.size])
+	return &List{elements: elements, size: list.size, equaler: list.equaler}
+}
+
 // Sort sorts values (in-place) using.
 func (list *List) Sort(comparator utils.Comparator) {
 	if len(list.elements) < 2 {
 		return
 	}
+	list.modifications++
 	utils.Sort(list.elements[:list.size], comparator)
 }
 
@@ -154,6 +194,7 @@ func (list *List) Insert(index int, values ...interface{}) {
 		return
 	}
 
+	list.modifications++
 	l := len(values)
 	list.growBy(l)
 	list.size += l
@@ -178,6 +219,14 @@ func (list *List) String() string {
 	return str
 }
 
+// Check whether two elements are equal with respect to the list's equaler
+func (list *List) equal(a, b interface{}) bool {
+	if list.equaler == nil {
+		return a == b
+	}
+	return list.equaler(a, b)
+}
+
 // Check that the index is within bounds of the list
 func (list *List) withinRange(index int) bool {
 	return index >= 0 && index < list.size
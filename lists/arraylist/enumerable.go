@@ -20,6 +20,7 @@ func (list *List) Each(f func(index int, value interface{})) {
 
 // Map invokes the given function once for each element and returns a
 // container containing the values returned by the given function.
+// The values need not fit the list's equaler, hence the new list compares them with ==.
 func (list *List) Map(f func(index int, value interface{}) interface{}) *List {
 	newList := &List{}
 	iterator := list.Iterator()
@@ -31,7 +32,7 @@ func (list *List) Map(f func(index int, value interface{}) interface{}) *List {
 
 // Select returns a new container containing all elements for which the given function returns a true value.
 func (list *List) Select(f func(index int, value interface{}) bool) *List {
-	newList := &List{}
+	newList := &List{equaler: list.equaler}
 	iterator := list.Iterator()
 	for iterator.Next() {
 		if f(iterator.Index(), iterator.Value()) {
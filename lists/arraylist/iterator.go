@@ -12,13 +12,18 @@ func assertIteratorImplementation() {
 
 // Iterator holding the iterator's state
 type Iterator struct {
-	list  *List
-	index int
+	list          *List
+	index         int
+	removed       bool // element at index was removed, the iterator is between it and the previous one
+	modifications int  // list's modifications when the iterator was reset
 }
 
 // Iterator returns a stateful iterator whose values can be fetched by an index.
+// The iterator is fail-fast: Next() and Prev() panic with containers.ErrConcurrentModification
+// if the list has been structurally modified since the iterator was reset with Begin() or End()
+// or moved from its initial state (one-before-first).
 func (list *List) Iterator() Iterator {
-	return Iterator{list: list, index: -1}
+	return Iterator{list: list, index: -1, modifications: list.modifications}
 }
 
 // Next moves the iterator to the next element and returns true if there was a next element in the container.
@@ -26,7 +31,14 @@ func (list *List) Iterator() Iterator {
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
 // Modifies the state of the iterator.
 func (iterator *Iterator) Next() bool {
-	if iterator.index < iterator.list.size {
+	if iterator.index < 0 {
+		// iteration starts here, so earlier modifications do not matter
+		iterator.modifications = iterator.list.modifications
+	}
+	iterator.checkModifications()
+	if iterator.removed {
+		iterator.removed = false
+	} else if iterator.index < iterator.list.size {
 		iterator.index++
 	}
 	return iterator.list.withinRange(iterator.index)
@@ -36,6 +48,8 @@ func (iterator *Iterator) Next() bool {
 // If Prev() returns true, then previous element's index and value can be retrieved by Index() and Value().
 // Modifies the state of the iterator.
 func (iterator *Iterator) Prev() bool {
+	iterator.checkModifications()
+	iterator.removed = false
 	if iterator.index >= 0 {
 		iterator.index--
 	}
@@ -58,12 +72,16 @@ func (iterator *Iterator) Index() int {
 // Call Next() to fetch the first element if any.
 func (iterator *Iterator) Begin() {
 	iterator.index = -1
+	iterator.removed = false
+	iterator.modifications = iterator.list.modifications
 }
 
 // End moves the iterator past the last element (one-past-the-end).
 // Call Prev() to fetch the last element if any.
 func (iterator *Iterator) End() {
 	iterator.index = iterator.list.size
+	iterator.removed = false
+	iterator.modifications = iterator.list.modifications
 }
 
 // First moves the iterator to the first element and returns true if there was a first element in the container.
@@ -81,3 +99,34 @@ func (iterator *Iterator) Last() bool {
 	iterator.End()
 	return iterator.Prev()
 }
+
+// Remove removes the current element from the list.
+// Afterwards the iterator is positioned between the neighbours of the removed element,
+// i.e. Next() moves to the element after it and Prev() to the element before it.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) Remove() {
+	if iterator.removed || !iterator.list.withinRange(iterator.index) {
+		return
+	}
+	iterator.checkModifications()
+	iterator.list.Remove(iterator.index)
+	iterator.modifications = iterator.list.modifications
+	iterator.removed = true
+}
+
+// SetValue replaces the current element's value.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) SetValue(value interface{}) {
+	if iterator.removed || !iterator.list.withinRange(iterator.index) {
+		return
+	}
+	iterator.checkModifications()
+	iterator.list.elements[iterator.index] = value
+}
+
+// checkModifications panics if the list has been structurally modified since the iterator was reset.
+func (iterator *Iterator) checkModifications() {
+	if iterator.modifications != iterator.list.modifications {
+		panic(containers.ErrConcurrentModification)
+	}
+}
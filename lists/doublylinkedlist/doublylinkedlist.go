@@ -22,9 +22,11 @@ func assertListImplementation() {
 
 // List holds the elements, where each element points to the next and previous element
 type List struct {
-	first *element
-	last  *element
-	size  int
+	first         *element
+	last          *element
+	size          int
+	equaler       utils.Equaler // nil if elements are compared with ==
+	modifications int           // number of structural modifications, used by iterators to fail fast
 }
 
 type element struct {
@@ -38,8 +40,20 @@ func New() *List {
 	return &List{}
 }
 
+// NewWith instantiates a new empty list whose membership operations consider
+// elements equal if the custom comparator returns zero.
+func NewWith(comparator utils.Comparator) *List {
+	return &List{equaler: utils.EqualerFromComparator(comparator)}
+}
+
+// NewWithEqualer instantiates a new empty list whose membership operations use the custom equaler.
+func NewWithEqualer(equaler utils.Equaler) *List {
+	return &List{equaler: equaler}
+}
+
 // Add appends a value (one or more) at the end of the list (same as Append())
 func (list *List) Add(values ...interface{}) {
+	list.modifications++
 	for _, value := range values {
 		newElement := &element{value: value, prev: list.last}
 		if list.size == 0 {
@@ -61,6 +75,7 @@ func (list *List) Append(values ...interface{}) {
 // Prepend prepends a values (or more)
 func (list *List) Prepend(values ...interface{}) {
 	// in reverse to keep passed order i.e. ["c","d"] -> Prepend(["a","b"]) -> ["a","b","c",d"]
+	list.modifications++
 	for v := len(values) - 1; v >= 0; v-- {
 		newElement := &element{value: values[v], next: list.first}
 		if list.size == 0 {
@@ -119,22 +134,7 @@ func (list *List) Remove(index int) {
 		}
 	}
 
-	if element == list.first {
-		list.first = element.next
-	}
-	if element == list.last {
-		list.last = element.prev
-	}
-	if element.prev != nil {
-		element.prev.next = element.next
-	}
-	if element.next != nil {
-		element.next.prev = element.prev
-	}
-
-	element = nil
-
-	list.size--
+	list.removeElement(element)
 }
 
 // Contains check if values (one or more) are present in the set.
@@ -150,20 +150,18 @@ func (list *List) Contains(values ...interface{}) bool {
 		return false
 	}
 	for _, value := range values {
-		found := false
-		for element := list.first; element != nil; element = element.next {
-			if element.value == value {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if list.IndexOf(value) == -1 {
 			return false
 		}
 	}
 	return true
 }
 
+// ContainsFunc returns true if the given function returns true for any element in the list.
+func (list *List) ContainsFunc(f func(value interface{}) bool) bool {
+	return list.IndexOfFunc(f) != -1
+}
+
 // Values returns all elements in the list.
 func (list *List) Values() []interface{} {
 	values := make([]interface{}, list.size, list.size)
@@ -173,18 +171,36 @@ func (list *List) Values() []interface{} {
 	return values
 }
 
-//IndexOf returns index of provided element
+// IndexOf returns index of the first occurrence of the provided element or -1 if it is not found.
 func (list *List) IndexOf(value interface{}) int {
-	if list.size == 0 {
-		return -1
+	for index, element := 0, list.first; element != nil; index, element = index+1, element.next {
+		if list.equal(element.value, value) {
+			return index
+		}
+	}
+	return -1
+}
+
+// LastIndexOf returns index of the last occurrence of the provided element or -1 if it is not found.
+func (list *List) LastIndexOf(value interface{}) int {
+	for index, element := list.size-1, list.last; element != nil; index, element = index-1, element.prev {
+		if list.equal(element.value, value) {
+			return index
+		}
 	}
-	for index, element := range list.Values() {
-		if element == value {
+	return -1
+}
+
+// IndexOfFunc returns index of the first element for which the given function returns true or -1 if there is none.
+func (list *List) IndexOfFunc(f func(value interface{}) bool) int {
+	for index, element := 0, list.first; element != nil; index, element = index+1, element.next {
+		if f(element.value) {
 			return index
 		}
 	}
 	return -1
 }
+
 // Empty returns true if list does not contain any elements.
 func (list *List) Empty() bool {
 	return list.size == 0
@@ -197,11 +213,21 @@ func (list *List) Size() int {
 
 // Clear removes all elements from the list.
 func (list *List) Clear() {
+	list.modifications++
 	list.size = 0
 	list.first = nil
 	list.last = nil
 }
 
+// Clone returns a shallow copy of the list (elements themselves are not copied).
+func (list *List) Clone() *List {
+	clone := &List{equaler: list.equaler}
+	for element := list.first; element != nil; element = element.next {
+		clone.Add(element.value)
+	}
+	return clone
+}
+
 // Sort sorts values (in-place) using.
 func (list *List) Sort(comparator utils.Comparator) {
 
@@ -248,6 +274,7 @@ func (list *List) Insert(index int, values ...interface{}) {
 	}
 
 	list.size += len(values)
+	list.modifications++
 
 	var beforeElement *element
 	var foundElement *element
@@ -302,7 +329,33 @@ func (list *List) String() string {
 	return str
 }
 
+// Check whether two elements are equal with respect to the list's equaler
+func (list *List) equal(a, b interface{}) bool {
+	if list.equaler == nil {
+		return a == b
+	}
+	return list.equaler(a, b)
+}
+
 // Check that the index is within bounds of the list
 func (list *List) withinRange(index int) bool {
 	return index >= 0 && index < list.size
 }
+
+// removeElement unlinks the element from the list. The element keeps pointing to its neighbours.
+func (list *List) removeElement(element *element) {
+	if element == list.first {
+		list.first = element.next
+	}
+	if element == list.last {
+		list.last = element.prev
+	}
+	if element.prev != nil {
+		element.prev.next = element.next
+	}
+	if element.next != nil {
+		element.next.prev = element.prev
+	}
+	list.size--
+	list.modifications++
+}
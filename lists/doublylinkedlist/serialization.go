@@ -5,13 +5,24 @@
 package doublylinkedlist
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
 	"encoding/json"
 	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"io"
 )
 
 func assertSerializationImplementation() {
 	var _ containers.JSONSerializer = (*List)(nil)
 	var _ containers.JSONDeserializer = (*List)(nil)
+	var _ json.Marshaler = (*List)(nil)
+	var _ json.Unmarshaler = (*List)(nil)
+	var _ encoding.BinaryMarshaler = (*List)(nil)
+	var _ encoding.BinaryUnmarshaler = (*List)(nil)
+	var _ gob.GobEncoder = (*List)(nil)
+	var _ gob.GobDecoder = (*List)(nil)
 }
 
 // ToJSON outputs the JSON representation of list's elements.
@@ -29,3 +40,111 @@ func (list *List) FromJSON(data []byte) error {
 	}
 	return err
 }
+
+// WriteJSON writes the JSON representation of list's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (list *List) WriteJSON(w io.Writer) error {
+	it := list.Iterator()
+	return utils.WriteJSONValues(w, func() (interface{}, bool) {
+		if !it.Next() {
+			return nil, false
+		}
+		return it.Value(), true
+	})
+}
+
+// ReadJSON populates list's elements from the JSON representation read from the reader (see FromJSON).
+// List is not modified if the input can not be decoded.
+func (list *List) ReadJSON(r io.Reader) error {
+	loaded := New()
+	if err := utils.ReadJSONValues(r, nil, func(value interface{}) error {
+		loaded.Add(value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	list.first, list.last, list.size = loaded.first, loaded.last, loaded.size
+	list.modifications++
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of list's elements (implements json.Marshaler).
+func (list *List) MarshalJSON() ([]byte, error) {
+	return list.ToJSON()
+}
+
+// UnmarshalJSON populates list's elements from the input JSON representation (implements json.Unmarshaler).
+func (list *List) UnmarshalJSON(data []byte) error {
+	return list.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of list's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (list *List) MarshalBinary() ([]byte, error) {
+	return list.MarshalBinaryWith(nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of list's elements,
+// encoding each element with the given codec (nil for gob).
+func (list *List) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := list.WriteBinaryWith(buffer, codec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates list's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (list *List) UnmarshalBinary(data []byte) error {
+	return list.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates list's elements from the input binary representation,
+// decoding each element with the given codec, which must be the one the elements were encoded with.
+// List is not modified if the input can not be decoded.
+func (list *List) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return list.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of list's elements (see MarshalBinary) into the writer.
+func (list *List) WriteBinary(w io.Writer) error {
+	return list.WriteBinaryWith(w, nil)
+}
+
+// WriteBinaryWith writes the binary representation of list's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (list *List) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	it := list.Iterator()
+	return utils.WriteBinaryValues(w, list.Size(), func() interface{} {
+		it.Next()
+		return it.Value()
+	}, codec)
+}
+
+// ReadBinary populates list's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (list *List) ReadBinary(r io.Reader) error {
+	return list.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates list's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (list *List) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	loaded := New()
+	if err := utils.ReadBinaryValues(r, codec, func(value interface{}) error {
+		loaded.Add(value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	list.first, list.last, list.size = loaded.first, loaded.last, loaded.size
+	list.modifications++
+	return nil
+}
+
+// GobEncode outputs the binary representation of list's elements (implements gob.GobEncoder).
+func (list *List) GobEncode() ([]byte, error) {
+	return list.MarshalBinary()
+}
+
+// GobDecode populates list's elements from the input binary representation (implements gob.GobDecoder).
+func (list *List) GobDecode(data []byte) error {
+	return list.UnmarshalBinary(data)
+}
@@ -12,9 +12,11 @@ func assertIteratorImplementation() {
 
 // Iterator holding the iterator's state
 type Iterator struct {
-	list    *List
-	index   int
-	element *element
+	list     *List
+	index    int
+	element  *element
+	previous *element // predecessor of element, needed to remove it
+	removed  bool     // element was removed, the iterator is between it and the previous one
 }
 
 // Iterator returns a stateful iterator whose values can be fetched by an index.
@@ -27,6 +29,12 @@ func (list *List) Iterator() Iterator {
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
 // Modifies the state of the iterator.
 func (iterator *Iterator) Next() bool {
+	if iterator.removed {
+		// the removed element still points to its successor, which took over its index
+		iterator.removed = false
+		iterator.element = iterator.element.next
+		return iterator.element != nil
+	}
 	if iterator.index < iterator.list.size {
 		iterator.index++
 	}
@@ -35,9 +43,9 @@ func (iterator *Iterator) Next() bool {
 		return false
 	}
 	if iterator.index == 0 {
-		iterator.element = iterator.list.first
+		iterator.previous, iterator.element = nil, iterator.list.first
 	} else {
-		iterator.element = iterator.element.next
+		iterator.previous, iterator.element = iterator.element, iterator.element.next
 	}
 	return true
 }
@@ -59,6 +67,8 @@ func (iterator *Iterator) Index() int {
 func (iterator *Iterator) Begin() {
 	iterator.index = -1
 	iterator.element = nil
+	iterator.previous = nil
+	iterator.removed = false
 }
 
 // First moves the iterator to the first element and returns true if there was a first element in the container.
@@ -68,3 +78,24 @@ func (iterator *Iterator) First() bool {
 	iterator.Begin()
 	return iterator.Next()
 }
+
+// Remove removes the current element from the list in constant time.
+// Afterwards the iterator is positioned between the removed element and the previous one,
+// i.e. Next() moves to the element after the removed one.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) Remove() {
+	if iterator.removed || !iterator.list.withinRange(iterator.index) {
+		return
+	}
+	iterator.list.removeElement(iterator.previous, iterator.element)
+	iterator.removed = true
+}
+
+// SetValue replaces the current element's value.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) SetValue(value interface{}) {
+	if iterator.removed || !iterator.list.withinRange(iterator.index) {
+		return
+	}
+	iterator.element.value = value
+}
@@ -22,9 +22,10 @@ func assertListImplementation() {
 
 // List holds the elements, where each element points to the next element
 type List struct {
-	first *element
-	last  *element
-	size  int
+	first   *element
+	last    *element
+	size    int
+	equaler utils.Equaler // nil if elements are compared with ==
 }
 
 type element struct {
@@ -37,6 +38,17 @@ func New() *List {
 	return &List{}
 }
 
+// NewWith instantiates a new empty list whose membership operations consider
+// elements equal if the custom comparator returns zero.
+func NewWith(comparator utils.Comparator) *List {
+	return &List{equaler: utils.EqualerFromComparator(comparator)}
+}
+
+// NewWithEqualer instantiates a new empty list whose membership operations use the custom equaler.
+func NewWithEqualer(equaler utils.Equaler) *List {
+	return &List{equaler: equaler}
+}
+
 // Add appends a value (one or more) at the end of the list (same as Append())
 func (list *List) Add(values ...interface{}) {
 	for _, value := range values {
@@ -103,19 +115,7 @@ func (list *List) Remove(index int) {
 		beforeElement = element
 	}
 
-	if element == list.first {
-		list.first = element.next
-	}
-	if element == list.last {
-		list.last = beforeElement
-	}
-	if beforeElement != nil {
-		beforeElement.next = element.next
-	}
-
-	element = nil
-
-	list.size--
+	list.removeElement(beforeElement, element)
 }
 
 // Contains checks if values (one or more) are present in the set.
@@ -131,20 +131,18 @@ func (list *List) Contains(values ...interface{}) bool {
 		return false
 	}
 	for _, value := range values {
-		found := false
-		for element := list.first; element != nil; element = element.next {
-			if element.value == value {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if list.IndexOf(value) == -1 {
 			return false
 		}
 	}
 	return true
 }
 
+// ContainsFunc returns true if the given function returns true for any element in the list.
+func (list *List) ContainsFunc(f func(value interface{}) bool) bool {
+	return list.IndexOfFunc(f) != -1
+}
+
 // Values returns all elements in the list.
 func (list *List) Values() []interface{} {
 	values := make([]interface{}, list.size, list.size)
@@ -154,18 +152,37 @@ func (list *List) Values() []interface{} {
 	return values
 }
 
-//IndexOf returns index of provided element
-func (list *List) IndexOf(value interface{}) int{
-	if list.size == 0 {
-		return -1
+// IndexOf returns index of the first occurrence of the provided element or -1 if it is not found.
+func (list *List) IndexOf(value interface{}) int {
+	for index, element := 0, list.first; element != nil; index, element = index+1, element.next {
+		if list.equal(element.value, value) {
+			return index
+		}
+	}
+	return -1
+}
+
+// LastIndexOf returns index of the last occurrence of the provided element or -1 if it is not found.
+func (list *List) LastIndexOf(value interface{}) int {
+	lastIndex := -1
+	for index, element := 0, list.first; element != nil; index, element = index+1, element.next {
+		if list.equal(element.value, value) {
+			lastIndex = index
+		}
 	}
-	for index, element := range list.Values() {
-		if element == value {
+	return lastIndex
+}
+
+// IndexOfFunc returns index of the first element for which the given function returns true or -1 if there is none.
+func (list *List) IndexOfFunc(f func(value interface{}) bool) int {
+	for index, element := 0, list.first; element != nil; index, element = index+1, element.next {
+		if f(element.value) {
 			return index
 		}
 	}
 	return -1
 }
+
 // Empty returns true if list does not contain any elements.
 func (list *List) Empty() bool {
 	return list.size == 0
@@ -183,6 +200,15 @@ func (list *List) Clear() {
 	list.last = nil
 }
 
+// Clone returns a shallow copy of the list (elements themselves are not copied).
+func (list *List) Clone() *List {
+	clone := &List{equaler: list.equaler}
+	for element := list.first; element != nil; element = element.next {
+		clone.Add(element.value)
+	}
+	return clone
+}
+
 // Sort sort values (in-place) using.
 func (list *List) Sort(comparator utils.Comparator) {
 
@@ -270,7 +296,30 @@ func (list *List) String() string {
 	return str
 }
 
+// Check whether two elements are equal with respect to the list's equaler
+func (list *List) equal(a, b interface{}) bool {
+	if list.equaler == nil {
+		return a == b
+	}
+	return list.equaler(a, b)
+}
+
 // Check that the index is within bounds of the list
 func (list *List) withinRange(index int) bool {
 	return index >= 0 && index < list.size
 }
+
+// removeElement unlinks the element from the list, beforeElement being its predecessor (nil if the element is first).
+// The element keeps pointing to its successor.
+func (list *List) removeElement(beforeElement *element, element *element) {
+	if element == list.first {
+		list.first = element.next
+	}
+	if element == list.last {
+		list.last = beforeElement
+	}
+	if beforeElement != nil {
+		beforeElement.next = element.next
+	}
+	list.size--
+}
@@ -5,7 +5,13 @@
 package singlylinkedlist
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/lists"
 	"github.com/emirpasic/gods/utils"
 	"testing"
 )
@@ -25,6 +31,84 @@ func TestListAdd(t *testing.T) {
 	}
 }
 
+func TestListLastIndexOf(t *testing.T) {
+	list := New()
+	if actualValue, expectedValue := list.LastIndexOf("a"), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	list.Add("a", "b", "a", "c")
+	if actualValue, expectedValue := list.LastIndexOf("a"), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.LastIndexOf("c"), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.LastIndexOf("d"), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestListIndexOfFunc(t *testing.T) {
+	list := New()
+	list.Add([]int{1}, []int{2, 3}, []int{4, 5})
+	hasTwoElements := func(value interface{}) bool { return len(value.([]int)) == 2 }
+	hasThreeElements := func(value interface{}) bool { return len(value.([]int)) == 3 }
+	if actualValue, expectedValue := list.IndexOfFunc(hasTwoElements), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.IndexOfFunc(hasThreeElements), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.ContainsFunc(hasTwoElements), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.ContainsFunc(hasThreeElements), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestListNewWith(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+	byID := func(a, b interface{}) int {
+		return utils.IntComparator(a.(user).id, b.(user).id)
+	}
+	list := NewWith(byID)
+	list.Add(user{1, "a"}, user{2, "b"}, user{1, "c"})
+	if actualValue, expectedValue := list.Contains(user{2, "x"}), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.Contains(user{3, "c"}), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.IndexOf(user{1, "x"}), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.LastIndexOf(user{1, "x"}), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	selected := list.Select(func(index int, value interface{}) bool { return index > 0 })
+	if actualValue, expectedValue := selected.IndexOf(user{1, "x"}), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestListNewWithEqualer(t *testing.T) {
+	list := NewWithEqualer(utils.BytesEqualer)
+	list.Add([]byte("a"), []byte("b"))
+	if actualValue, expectedValue := list.Contains([]byte("b"), []byte("a")), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.IndexOf([]byte("b")), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.IndexOf([]byte("c")), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestListRemove(t *testing.T) {
 	list := New()
 	list.Add("a")
@@ -220,6 +304,26 @@ func TestListMap(t *testing.T) {
 	}
 }
 
+func TestListMapWithEqualer(t *testing.T) {
+	list := NewWithEqualer(utils.CaseInsensitiveStringEqualer)
+	list.Add("a", "Bc")
+	mappedList := list.Map(func(index int, value interface{}) interface{} {
+		return len(value.(string))
+	})
+	if actualValue, expectedValue := mappedList.Contains(2), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := mappedList.IndexOf(1), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	selectedList := list.Select(func(index int, value interface{}) bool {
+		return index > 0
+	})
+	if actualValue, expectedValue := selectedList.Contains("bC"), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestListSelect(t *testing.T) {
 	list := New()
 	list.Add("a", "b", "c")
@@ -372,6 +476,66 @@ func TestListIteratorFirst(t *testing.T) {
 	}
 }
 
+func TestListIteratorRemove(t *testing.T) {
+	list := New()
+	list.Add(1, 2, 3, 4, 5, 6)
+	it := list.Iterator()
+	it.Remove() // not positioned on an element
+	for it.Next() {
+		if it.Value().(int)%2 == 0 {
+			it.Remove()
+			it.Remove() // already removed
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[1 3 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := list.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	it.Begin()
+	it.Next()
+	it.Remove()
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Value(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.SetValue(30)
+	it.Next()
+	it.Remove()
+	if actualValue, expectedValue := it.Next(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[30]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	list.Add(4)
+	if actualValue, expectedValue := fmt.Sprint(list.Values()), "[30 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestListClone(t *testing.T) {
+	list := New()
+	list.Add("c", "a", "b")
+
+	clone := list.Clone()
+	if actualValue := containers.Equal(clone, list, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	clone.Add("x")
+	if actualValue := containers.Equal(clone, list, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(list.Clone(), list, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestListSerialization(t *testing.T) {
 	list := New()
 	list.Add("a", "b", "c")
@@ -398,6 +562,115 @@ func TestListSerialization(t *testing.T) {
 	assert()
 }
 
+func TestListMarshalJSON(t *testing.T) {
+	type document struct {
+		List *List `json:"list"`
+	}
+	list := New()
+	list.Add("a", "b", "c")
+
+	data, err := json.Marshal(&document{List: list})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := list.ToJSON()
+	if actualValue, expectedValue := string(data), `{"list":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{List: New()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.List.Size(), list.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.List.Values()), fmt.Sprint(list.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestListBinarySerialization(t *testing.T) {
+	list := New()
+	list.Add(3, 1, 2)
+
+	assert := func(restored *List, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), list.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, value := range list.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := list.MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = list.MarshalBinaryWith(utils.IntCodec)
+	restored = New()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		List *List
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{List: list})
+	doc := &document{List: New()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.List, err)
+}
+
+func TestListStreaming(t *testing.T) {
+	list := New()
+	list.Add("c", "a", "b")
+
+	assert := func(restored *List, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(list.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := list.WriteJSON(buffer)
+	restored := New()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = list.WriteBinary(buffer)
+	restored = New()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
 func benchmarkGet(b *testing.B, list *List, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -422,6 +695,16 @@ func benchmarkRemove(b *testing.B, list *List, size int) {
 	}
 }
 
+func TestListConformance(t *testing.T) {
+	containertest.TestList(t, func() lists.List { return New() })
+	for _, values := range [][]interface{}{{}, {1}, {1, 2, 3}} {
+		list := New()
+		list.Add(values...)
+		it := list.Iterator()
+		containertest.TestIteratorWithIndex(t, &it, values)
+	}
+}
+
 func BenchmarkSinglyLinkedListGet100(b *testing.B) {
 	b.StopTimer()
 	size := 100
@@ -0,0 +1,253 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package counter implements a map from keys to their integer counts.
+//
+// A counter (tally) is backed either by a hash map (unordered keys) or by a tree map (keys ordered with respect to the comparator).
+// Missing keys have a count of zero.
+//
+// Arithmetic between counters (Add, Subtract, Intersect, Union) returns a new counter holding only positive counts.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Multiset
+package counter
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/maps/hashmap"
+	"github.com/emirpasic/gods/maps/treemap"
+	"github.com/emirpasic/gods/utils"
+	"strings"
+)
+
+func assertMapImplementation() {
+	var _ maps.Map = (*Counter)(nil)
+}
+
+// Counter holds the counts in a hash map or a tree map
+type Counter struct {
+	m          maps.Map
+	comparator utils.Comparator // nil for hash map backed counters
+	decoder    utils.Decoder    // decodes keys from JSON, nil to keep them as strings (see SetDecoder)
+}
+
+// Entry represents a key and its count
+type Entry struct {
+	Key   interface{}
+	Count int
+}
+
+// New instantiates a counter backed by a hash map, i.e. keys are unordered.
+func New() *Counter {
+	return &Counter{m: hashmap.New()}
+}
+
+// NewWith instantiates a counter backed by a tree map with the custom comparator, i.e. keys are ordered.
+func NewWith(comparator utils.Comparator) *Counter {
+	return &Counter{m: treemap.NewWith(comparator), comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a counter backed by a tree map with the IntComparator, i.e. keys are of type int.
+func NewWithIntComparator() *Counter {
+	counter := NewWith(utils.IntComparator)
+	counter.decoder = utils.IntDecoder
+	return counter
+}
+
+// NewWithStringComparator instantiates a counter backed by a tree map with the StringComparator, i.e. keys are of type string.
+func NewWithStringComparator() *Counter {
+	counter := NewWith(utils.StringComparator)
+	counter.decoder = utils.StringDecoder
+	return counter
+}
+
+// Increment adds delta to the count of the key (delta may be negative).
+func (counter *Counter) Increment(key interface{}, delta int) {
+	counter.m.Put(key, counter.Count(key)+delta)
+}
+
+// Count returns the count of the key or zero if key is not found in the counter.
+func (counter *Counter) Count(key interface{}) int {
+	if count, found := counter.m.Get(key); found {
+		return count.(int)
+	}
+	return 0
+}
+
+// Put sets the count of the key.
+// Value should be of type int, otherwise method panics.
+func (counter *Counter) Put(key interface{}, value interface{}) {
+	counter.m.Put(key, value.(int))
+}
+
+// Get searches the count of the key and returns it or nil if key is not found in the counter.
+// Second return parameter is true if key was found, otherwise false.
+func (counter *Counter) Get(key interface{}) (value interface{}, found bool) {
+	return counter.m.Get(key)
+}
+
+// Remove removes the key and its count from the counter.
+func (counter *Counter) Remove(key interface{}) {
+	counter.m.Remove(key)
+}
+
+// Total returns the sum of all counts.
+func (counter *Counter) Total() int {
+	total := 0
+	for _, count := range counter.m.Values() {
+		total += count.(int)
+	}
+	return total
+}
+
+// MostCommon returns the n keys with the highest counts, ordered from the most to the least common.
+// All keys are returned if n is negative or larger than the number of keys.
+// Keys with equal counts keep the key order of the counter (in-order for tree map backed counters).
+func (counter *Counter) MostCommon(n int) []Entry {
+	return counter.sortedEntries(n, func(a, b int) int { return utils.IntComparator(b, a) })
+}
+
+// LeastCommon returns the n keys with the lowest counts, ordered from the least to the most common.
+// All keys are returned if n is negative or larger than the number of keys.
+// Keys with equal counts keep the key order of the counter (in-order for tree map backed counters).
+func (counter *Counter) LeastCommon(n int) []Entry {
+	return counter.sortedEntries(n, func(a, b int) int { return utils.IntComparator(a, b) })
+}
+
+// Add returns a new counter holding the sum of counts of both counters.
+// Only keys with positive counts are kept.
+func (counter *Counter) Add(other *Counter) *Counter {
+	return counter.combine(other, func(a, b int) int { return a + b })
+}
+
+// Subtract returns a new counter holding the counts of this counter minus the counts of the other counter.
+// Only keys with positive counts are kept.
+func (counter *Counter) Subtract(other *Counter) *Counter {
+	return counter.combine(other, func(a, b int) int { return a - b })
+}
+
+// Intersect returns a new counter holding the minimum of counts of both counters.
+// Only keys with positive counts are kept.
+func (counter *Counter) Intersect(other *Counter) *Counter {
+	return counter.combine(other, func(a, b int) int {
+		if a < b {
+			return a
+		}
+		return b
+	})
+}
+
+// Union returns a new counter holding the maximum of counts of both counters.
+// Only keys with positive counts are kept.
+func (counter *Counter) Union(other *Counter) *Counter {
+	return counter.combine(other, func(a, b int) int {
+		if a > b {
+			return a
+		}
+		return b
+	})
+}
+
+// Empty returns true if counter does not contain any keys
+func (counter *Counter) Empty() bool {
+	return counter.m.Empty()
+}
+
+// Size returns number of keys in the counter.
+func (counter *Counter) Size() int {
+	return counter.m.Size()
+}
+
+// Keys returns all keys (in-order for tree map backed counters, random order otherwise).
+func (counter *Counter) Keys() []interface{} {
+	return counter.m.Keys()
+}
+
+// Values returns all counts (in-order of keys for tree map backed counters, random order otherwise).
+func (counter *Counter) Values() []interface{} {
+	return counter.m.Values()
+}
+
+// Clear removes all keys from the counter.
+func (counter *Counter) Clear() {
+	counter.m.Clear()
+}
+
+// Clone returns a copy of the counter (keys themselves are not copied).
+func (counter *Counter) Clone() *Counter {
+	if counter.comparator == nil {
+		return &Counter{m: counter.m.(*hashmap.Map).Clone(), decoder: counter.decoder}
+	}
+	return &Counter{m: counter.m.(*treemap.Map).Clone(), comparator: counter.comparator, decoder: counter.decoder}
+}
+
+// String returns a string representation of container
+func (counter *Counter) String() string {
+	str := "Counter\n"
+	items := []string{}
+	for _, key := range counter.m.Keys() {
+		items = append(items, fmt.Sprintf("%v:%v", key, counter.Count(key)))
+	}
+	str += strings.Join(items, ", ")
+	return str
+}
+
+// newEmpty instantiates an empty counter with the same backing map as this counter.
+func (counter *Counter) newEmpty() *Counter {
+	if counter.comparator == nil {
+		return New()
+	}
+	return NewWith(counter.comparator)
+}
+
+// combine applies the operation to the counts of every key of both counters and keeps positive results.
+func (counter *Counter) combine(other *Counter, operation func(a, b int) int) *Counter {
+	result := counter.newEmpty()
+	for _, key := range counter.m.Keys() {
+		if count := operation(counter.Count(key), other.Count(key)); count > 0 {
+			result.m.Put(key, count)
+		}
+	}
+	for _, key := range other.m.Keys() {
+		if _, found := counter.m.Get(key); found {
+			continue
+		}
+		if count := operation(0, other.Count(key)); count > 0 {
+			result.m.Put(key, count)
+		}
+	}
+	return result
+}
+
+type indexedEntry struct {
+	entry Entry
+	index int
+}
+
+// sortedEntries returns up to n entries sorted by count with respect to the count comparator.
+// Ties are broken by the position of the key within the counter, which keeps the sort stable.
+func (counter *Counter) sortedEntries(n int, countComparator func(a, b int) int) []Entry {
+	keys := counter.m.Keys()
+	values := make([]interface{}, len(keys))
+	for index, key := range keys {
+		values[index] = indexedEntry{entry: Entry{Key: key, Count: counter.Count(key)}, index: index}
+	}
+	utils.Sort(values, func(a, b interface{}) int {
+		entryA, entryB := a.(indexedEntry), b.(indexedEntry)
+		if compare := countComparator(entryA.entry.Count, entryB.entry.Count); compare != 0 {
+			return compare
+		}
+		return entryA.index - entryB.index
+	})
+	if n < 0 || n > len(values) {
+		n = len(values)
+	}
+	entries := make([]Entry, n)
+	for i := 0; i < n; i++ {
+		entries[i] = values[i].(indexedEntry).entry
+	}
+	return entries
+}
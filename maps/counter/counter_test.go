@@ -0,0 +1,414 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package counter
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"testing"
+)
+
+func TestCounterIncrement(t *testing.T) {
+	counter := New()
+	counter.Increment("a", 1)
+	counter.Increment("b", 2)
+	counter.Increment("a", 3)
+	counter.Increment("c", -1)
+	if actualValue, expectedValue := counter.Count("a"), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := counter.Count("c"), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := counter.Count("d"), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := counter.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := counter.Total(), 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	counter.Remove("c")
+	if actualValue, found := counter.Get("c"); actualValue != nil || found {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	counter.Clear()
+	if actualValue := counter.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestCounterMostCommon(t *testing.T) {
+	counter := NewWithStringComparator()
+	for _, word := range []string{"d", "a", "b", "a", "c", "a", "b", "d", "e"} {
+		counter.Increment(word, 1)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", counter.MostCommon(3)), "[{a 3} {b 2} {d 2}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", counter.MostCommon(-1)), "[{a 3} {b 2} {d 2} {c 1} {e 1}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := len(counter.MostCommon(10)), 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := len(counter.MostCommon(0)), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestCounterLeastCommon(t *testing.T) {
+	counter := NewWithStringComparator()
+	for _, word := range []string{"d", "a", "b", "a", "c", "a", "b", "d", "e"} {
+		counter.Increment(word, 1)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", counter.LeastCommon(3)), "[{c 1} {e 1} {b 2}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", New().LeastCommon(3)), "[]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestCounterCommonExtremeCounts(t *testing.T) {
+	maxInt := int(^uint(0) >> 1)
+	minInt := -maxInt - 1
+	counter := NewWithStringComparator()
+	counter.Increment("a", maxInt)
+	counter.Increment("b", minInt)
+	counter.Increment("c", 0)
+	if actualValue, expectedValue := fmt.Sprintf("%v", counter.MostCommon(-1)), fmt.Sprintf("[{a %v} {c 0} {b %v}]", maxInt, minInt); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", counter.LeastCommon(-1)), fmt.Sprintf("[{b %v} {c 0} {a %v}]", minInt, maxInt); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestCounterArithmetic(t *testing.T) {
+	c1 := NewWithStringComparator()
+	c1.Increment("a", 3)
+	c1.Increment("b", 1)
+	c2 := NewWithStringComparator()
+	c2.Increment("a", 1)
+	c2.Increment("b", 2)
+	c2.Increment("c", 4)
+
+	tests := []struct {
+		name     string
+		counter  *Counter
+		expected string
+	}{
+		{"Add", c1.Add(c2), "[a:4 b:3 c:4]"},
+		{"Subtract", c1.Subtract(c2), "[a:2]"},
+		{"Intersect", c1.Intersect(c2), "[a:1 b:1]"},
+		{"Union", c1.Union(c2), "[a:3 b:2 c:4]"},
+	}
+	for _, test := range tests {
+		if actualValue := fmt.Sprintf("%v", keysWithCounts(test.counter)); actualValue != test.expected {
+			t.Errorf("%v: got %v expected %v", test.name, actualValue, test.expected)
+		}
+	}
+}
+
+func TestCounterArithmeticHash(t *testing.T) {
+	c1 := New()
+	c1.Increment(1, 3)
+	c2 := New()
+	c2.Increment(1, 5)
+	c2.Increment(2, 1)
+	if actualValue, expectedValue := c1.Subtract(c2).Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := c2.Subtract(c1).Count(1), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := c1.Add(c2).Total(), 9; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func keysWithCounts(counter *Counter) []string {
+	items := []string{}
+	for _, key := range counter.Keys() {
+		items = append(items, fmt.Sprintf("%v:%v", key, counter.Count(key)))
+	}
+	return items
+}
+
+func TestCounterClone(t *testing.T) {
+	counter := NewWithStringComparator()
+	counter.Increment("a", 2)
+	counter.Increment("b", 1)
+
+	clone := counter.Clone()
+	if actualValue := containers.Equal(clone, counter, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	clone.Increment("a", 1)
+	if actualValue := containers.Equal(clone, counter, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(counter.Clone(), counter, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestCounterSerialization(t *testing.T) {
+	counter := NewWithStringComparator()
+	counter.Increment("a", 1)
+	counter.Increment("b", 2)
+	counter.Increment("c", 3)
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := fmt.Sprintf("%v", keysWithCounts(counter)), "[a:1 b:2 c:3]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := counter.ToJSON()
+	assert()
+
+	err = counter.FromJSON(json)
+	assert()
+}
+
+func TestCounterSerializationWithDecoders(t *testing.T) {
+	counter := NewWithIntComparator()
+	counter.Increment(3, 30)
+	counter.Increment(1, 10)
+	counter.Increment(2, 20)
+
+	json, err := counter.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	counter.Clear()
+	err = counter.FromJSON(json)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%v", keysWithCounts(counter)), "[1:10 2:20 3:30]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = counter.FromJSONWith(json, nil)
+	if err == nil {
+		t.Errorf("Got no error for keys restored as strings")
+	}
+	err = counter.FromJSONWith([]byte(`{"x":40}`), utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if actualValue, expectedValue := counter.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	counter.SetDecoder(nil)
+	err = counter.FromJSON(json)
+	if err == nil {
+		t.Errorf("Got no error for keys restored as strings")
+	}
+	counter.SetDecoder(utils.IntDecoder)
+	err = counter.FromJSON([]byte(`{"4":40}`))
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := counter.Count(4), 40; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	restored := New()
+	err = restored.FromJSON(json)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := restored.Count("2"), 20; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestCounterMarshalJSON(t *testing.T) {
+	type document struct {
+		Counter *Counter `json:"counter"`
+	}
+	counter := New()
+	counter.Increment("a", 2)
+	counter.Increment("b", 1)
+
+	data, err := json.Marshal(&document{Counter: counter})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := counter.ToJSON()
+	if actualValue, expectedValue := string(data), `{"counter":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Counter: New()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Counter.Size(), counter.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(containers.GetSortedValues(doc.Counter, utils.IntComparator)), fmt.Sprint(containers.GetSortedValues(counter, utils.IntComparator)); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	zeroDoc := &document{}
+	err = json.Unmarshal(data, zeroDoc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	zeroDoc.Counter.Increment("c", 1)
+	if actualValue, expectedValue := zeroDoc.Counter.Size(), counter.Size()+1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err = json.Marshal(&document{Counter: &Counter{}})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"counter":{}}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	data, err = new(Counter).MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := restored.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestCounterBinarySerialization(t *testing.T) {
+	counter := New()
+	counter.Increment(1, 2)
+	counter.Increment(2, 1)
+
+	assert := func(restored *Counter, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), counter.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, key := range counter.Keys() {
+			if actualValue, expectedValue := restored.Keys()[i], key; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+		for i, value := range counter.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := counter.MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = counter.MarshalBinaryWith(utils.IntCodec)
+	restored = New()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Counter *Counter
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Counter: counter})
+	doc := &document{Counter: New()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Counter, err)
+}
+
+func TestCounterStreaming(t *testing.T) {
+	counter := New()
+	counter.Increment("a", 2)
+	counter.Increment("b", 1)
+
+	assert := func(restored *Counter, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(counter.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := counter.WriteJSON(buffer)
+	restored := New()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = counter.WriteBinary(buffer)
+	restored = New()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
+func benchmarkIncrement(b *testing.B, counter *Counter, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			counter.Increment(n, 1)
+		}
+	}
+}
+
+func BenchmarkCounterIncrement100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	counter := New()
+	b.StartTimer()
+	benchmarkIncrement(b, counter, size)
+}
+
+func BenchmarkCounterIncrement10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	counter := New()
+	b.StartTimer()
+	benchmarkIncrement(b, counter, size)
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package counter
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/maps/treemap"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
+
+func assertSerializationImplementation() {
+	var _ containers.JSONSerializer = (*Counter)(nil)
+	var _ containers.JSONDeserializer = (*Counter)(nil)
+	var _ json.Marshaler = (*Counter)(nil)
+	var _ json.Unmarshaler = (*Counter)(nil)
+	var _ encoding.BinaryMarshaler = (*Counter)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Counter)(nil)
+	var _ gob.GobEncoder = (*Counter)(nil)
+	var _ gob.GobDecoder = (*Counter)(nil)
+}
+
+// ToJSON outputs the JSON representation of counter's elements.
+func (counter *Counter) ToJSON() ([]byte, error) {
+	counter = counter.orEmpty()
+	elements := make(map[string]int)
+	for _, key := range counter.m.Keys() {
+		elements[utils.ToString(key)] = counter.Count(key)
+	}
+	return json.Marshal(&elements)
+}
+
+// FromJSON populates counter's elements from the input JSON representation.
+// Keys are decoded with the counter's decoder (see SetDecoder and FromJSONWith for other decoders) or restored as strings without one.
+// Counter is not modified if the input can not be decoded.
+func (counter *Counter) FromJSON(data []byte) error {
+	return counter.FromJSONWith(data, counter.decoder)
+}
+
+// FromJSONWith populates counter's elements from the input JSON representation, decoding keys with the given decoder
+// (nil to restore them as strings), e.g. the IntDecoder for a counter with the IntComparator.
+// Returns an error if the comparator of a tree map backed counter can not compare the decoded keys.
+// Counter is not modified if the input can not be decoded.
+func (counter *Counter) FromJSONWith(data []byte, decoder utils.Decoder) error {
+	return counter.ReadJSONWith(bytes.NewReader(data), decoder)
+}
+
+// SetDecoder sets the decoder of keys used by FromJSON, ReadJSON and UnmarshalJSON (nil to restore keys as strings),
+// e.g. for unmarshalling a field holding the counter with json.Unmarshal.
+// Counters instantiated with NewWithIntComparator or NewWithStringComparator decode keys with the IntDecoder or StringDecoder.
+func (counter *Counter) SetDecoder(decoder utils.Decoder) {
+	counter.decoder = decoder
+}
+
+// WriteJSON writes the JSON representation of counter's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (counter *Counter) WriteJSON(w io.Writer) error {
+	counter = counter.orEmpty()
+	keys := counter.m.Keys()
+	i := -1
+	return utils.WriteJSONObject(w, func() (string, interface{}, bool) {
+		if i++; i == len(keys) {
+			return "", nil, false
+		}
+		return utils.ToString(keys[i]), counter.Count(keys[i]), true
+	})
+}
+
+// ReadJSON populates counter's elements from the JSON representation read from the reader (see FromJSON).
+// Counter is not modified if the input can not be decoded.
+func (counter *Counter) ReadJSON(r io.Reader) error {
+	return counter.ReadJSONWith(r, counter.decoder)
+}
+
+// ReadJSONWith populates counter's elements from the JSON representation read from the reader (see FromJSONWith).
+// Counter is not modified if the input can not be decoded.
+func (counter *Counter) ReadJSONWith(r io.Reader, decoder utils.Decoder) error {
+	loaded := counter.newEmpty()
+	if err := utils.ReadJSONPairs(r, decoder, utils.IntDecoder, func(key interface{}, count interface{}) error {
+		if err := loaded.checkKey(key); err != nil {
+			return err
+		}
+		loaded.m.Put(key, count)
+		return nil
+	}); err != nil {
+		return err
+	}
+	counter.m = loaded.m
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of counter's elements (implements json.Marshaler).
+func (counter *Counter) MarshalJSON() ([]byte, error) {
+	return counter.ToJSON()
+}
+
+// UnmarshalJSON populates counter's elements from the input JSON representation (implements json.Unmarshaler).
+func (counter *Counter) UnmarshalJSON(data []byte) error {
+	return counter.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of counter's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (counter *Counter) MarshalBinary() ([]byte, error) {
+	return counter.MarshalBinaryWith(nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of counter's elements,
+// encoding keys with the given codec (nil for gob).
+func (counter *Counter) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := counter.WriteBinaryWith(buffer, codec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates counter's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (counter *Counter) UnmarshalBinary(data []byte) error {
+	return counter.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates counter's elements from the input binary representation,
+// decoding keys with the given codec, which must be the one they were encoded with.
+// Counter is not modified if the input can not be decoded.
+func (counter *Counter) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return counter.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of counter's elements (see MarshalBinary) into the writer.
+func (counter *Counter) WriteBinary(w io.Writer) error {
+	return counter.WriteBinaryWith(w, nil)
+}
+
+// WriteBinaryWith writes the binary representation of counter's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (counter *Counter) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	counter = counter.orEmpty()
+	keys := counter.m.Keys()
+	i := -1
+	return utils.WriteBinaryPairs(w, len(keys), func() (interface{}, interface{}) {
+		i++
+		return keys[i], counter.Count(keys[i])
+	}, codec, utils.IntCodec)
+}
+
+// ReadBinary populates counter's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (counter *Counter) ReadBinary(r io.Reader) error {
+	return counter.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates counter's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (counter *Counter) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	loaded := counter.newEmpty()
+	if err := utils.ReadBinaryPairs(r, codec, utils.IntCodec, func(key interface{}, count interface{}) error {
+		if err := loaded.checkKey(key); err != nil {
+			return err
+		}
+		loaded.Increment(key, count.(int))
+		return nil
+	}); err != nil {
+		return err
+	}
+	counter.m = loaded.m
+	return nil
+}
+
+// GobEncode outputs the binary representation of counter's elements (implements gob.GobEncoder).
+func (counter *Counter) GobEncode() ([]byte, error) {
+	return counter.MarshalBinary()
+}
+
+// GobDecode populates counter's elements from the input binary representation (implements gob.GobDecoder).
+func (counter *Counter) GobDecode(data []byte) error {
+	return counter.UnmarshalBinary(data)
+}
+
+// checkKey returns an error if the comparator of a tree map backed counter can not compare the key with the last key of the counter.
+func (counter *Counter) checkKey(key interface{}) error {
+	if counter.comparator == nil {
+		return nil
+	}
+	last := key
+	if max, _ := counter.m.(*treemap.Map).Max(); max != nil {
+		last = max
+	}
+	_, err := utils.CompareKeys(counter.comparator, last, key)
+	return err
+}
+
+// orEmpty returns the counter, or an empty counter in place of a zero value counter (e.g. a field that was never instantiated),
+// so that writing a zero value counter outputs no elements.
+func (counter *Counter) orEmpty() *Counter {
+	if counter.m == nil {
+		return New()
+	}
+	return counter
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"github.com/emirpasic/gods/maps"
 	"github.com/emirpasic/gods/maps/hashmap"
+	"github.com/emirpasic/gods/utils"
+	"strings"
 )
 
 func assertMapImplementation() {
@@ -27,13 +29,45 @@ func assertMapImplementation() {
 
 // Map holds the elements in two hashmaps.
 type Map struct {
-	forwardMap hashmap.Map
-	inverseMap hashmap.Map
+	forwardMap   hashMap
+	inverseMap   hashMap
+	keyHasher    utils.Hasher  // nil if keys are hashed by go's native map
+	keyEqualer   utils.Equaler // nil if keys are hashed by go's native map
+	valueHasher  utils.Hasher  // nil if values are hashed by go's native map
+	valueEqualer utils.Equaler // nil if values are hashed by go's native map
+}
+
+// hashMap is one of the two hashmaps, a *hashmap.Map or a *hashmap.CustomMap if its keys are hashed with a custom hasher
+type hashMap interface {
+	maps.Map
+	Iterator() hashmap.Iterator
+	ToJSON() ([]byte, error)
 }
 
 // New instantiates a bidirectional map.
 func New() *Map {
-	return &Map{*hashmap.New(), *hashmap.New()}
+	return &Map{forwardMap: hashmap.New(), inverseMap: hashmap.New()}
+}
+
+// NewWith instantiates a bidirectional map with the custom hashers and equalers for keys and values.
+// Keys and values need not be comparable, but equal elements (with respect to the equaler) must have equal hashes.
+// Either side can be hashed by go's native map by passing a nil hasher and equaler for it.
+func NewWith(keyHasher utils.Hasher, keyEqualer utils.Equaler, valueHasher utils.Hasher, valueEqualer utils.Equaler) *Map {
+	return &Map{
+		forwardMap:   newHashMap(keyHasher, keyEqualer),
+		inverseMap:   newHashMap(valueHasher, valueEqualer),
+		keyHasher:    keyHasher,
+		keyEqualer:   keyEqualer,
+		valueHasher:  valueHasher,
+		valueEqualer: valueEqualer,
+	}
+}
+
+func newHashMap(hasher utils.Hasher, equaler utils.Equaler) hashMap {
+	if hasher == nil {
+		return hashmap.New()
+	}
+	return hashmap.NewWith(hasher, equaler)
 }
 
 // Put inserts element into the map.
@@ -94,9 +128,24 @@ func (m *Map) Clear() {
 	m.inverseMap.Clear()
 }
 
+// Clone returns a shallow copy of the map with the same hashers and equalers (keys and values themselves are not copied).
+func (m *Map) Clone() *Map {
+	clone := NewWith(m.keyHasher, m.keyEqualer, m.valueHasher, m.valueEqualer)
+	it := m.forwardMap.Iterator()
+	for it.Next() {
+		clone.forwardMap.Put(it.Key(), it.Value())
+		clone.inverseMap.Put(it.Value(), it.Key())
+	}
+	return clone
+}
+
 // String returns a string representation of container
 func (m *Map) String() string {
 	str := "HashBidiMap\n"
-	str += fmt.Sprintf("%v", m.forwardMap)
-	return str
+	items := []string{}
+	it := m.Iterator()
+	for it.Next() {
+		items = append(items, fmt.Sprintf("%v:%v", it.Key(), it.Value()))
+	}
+	return str + "map[" + strings.Join(items, " ") + "]"
 }
@@ -5,7 +5,14 @@
 package hashbidimap
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/utils"
 	"testing"
 )
 
@@ -150,6 +157,120 @@ func TestMapGetKey(t *testing.T) {
 	}
 }
 
+func TestMapNewWith(t *testing.T) {
+	m := NewWith(utils.BytesHasher, utils.BytesEqualer, utils.CaseInsensitiveStringHasher, utils.CaseInsensitiveStringEqualer)
+	m.Put([]byte{1}, "a")
+	m.Put([]byte{2}, "b")
+	m.Put([]byte{3}, "A") // replaces 1->a
+
+	if actualValue := m.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, found := m.GetKey("a"); fmt.Sprintf("%v", actualValue) != "[3]" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "[3]")
+	}
+	if actualValue, found := m.Get([]byte{1}); actualValue != nil || found {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	m.Remove([]byte{2})
+	if actualValue, found := m.GetKey("B"); actualValue != nil || found {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+}
+
+func TestMapEnumerable(t *testing.T) {
+	m := New()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	sum := 0
+	m.Each(func(key interface{}, value interface{}) {
+		sum += value.(int)
+	})
+	if actualValue, expectedValue := sum, 6; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	mappedMap := m.Map(func(key1 interface{}, value1 interface{}) (key2 interface{}, value2 interface{}) {
+		return key1, value1.(int) * 10
+	})
+	if actualValue, _ := mappedMap.GetKey(20); actualValue != "b" {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+	selectedMap := m.Select(func(key interface{}, value interface{}) bool {
+		return value.(int) > 1
+	})
+	if actualValue, expectedValue := selectedMap.Keys(), []interface{}{"b", "c"}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := m.Any(func(key interface{}, value interface{}) bool { return value.(int) == 3 }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := m.All(func(key interface{}, value interface{}) bool { return value.(int) > 1 }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if key, value := m.Find(func(key interface{}, value interface{}) bool { return value.(int) == 2 }); key != "b" || value != 2 {
+		t.Errorf("Got %v,%v expected %v,%v", key, value, "b", 2)
+	}
+}
+
+func TestMapMapWithHashers(t *testing.T) {
+	m := NewWith(utils.BytesHasher, utils.BytesEqualer, utils.BytesHasher, utils.BytesEqualer)
+	m.Put([]byte("a"), []byte("x"))
+	m.Put([]byte("b"), []byte("y"))
+	mappedMap := m.Map(func(key1 interface{}, value1 interface{}) (key2 interface{}, value2 interface{}) {
+		return string(key1.([]byte)), string(value1.([]byte))
+	})
+	if actualValue, _ := mappedMap.Get("b"); actualValue != "y" {
+		t.Errorf("Got %v expected %v", actualValue, "y")
+	}
+	if actualValue, _ := mappedMap.GetKey("x"); actualValue != "a" {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+}
+
+func TestMapIterator(t *testing.T) {
+	m := New()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	it := m.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, _ := m.GetKey(it.Value()); actualValue != it.Key() {
+			t.Errorf("Got %v expected %v", actualValue, it.Key())
+		}
+	}
+	if actualValue, expectedValue := count, 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		count--
+	}
+	if actualValue, expectedValue := count, 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapClone(t *testing.T) {
+	m := New()
+	m.Put("b", "2")
+	m.Put("a", "1")
+
+	clone := m.Clone()
+	if actualValue := containers.Equal(clone, m, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	clone.Put("a", "3")
+	if actualValue := containers.Equal(clone, m, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(m.Clone(), m, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestMapSerialization(t *testing.T) {
 	m := New()
 	m.Put("a", 1.0)
@@ -181,6 +302,144 @@ func TestMapSerialization(t *testing.T) {
 	assert()
 }
 
+func TestMapString(t *testing.T) {
+	m := New()
+	m.Put("a", 1)
+	if actualValue, expectedValue := m.String(), "HashBidiMap\nmap[a:1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	m.Put("b", 2)
+	if actualValue := m.String(); actualValue != "HashBidiMap\nmap[a:1 b:2]" && actualValue != "HashBidiMap\nmap[b:2 a:1]" {
+		t.Errorf("Got %v expected %v", actualValue, "HashBidiMap\nmap[a:1 b:2]")
+	}
+}
+
+func TestMapMarshalJSON(t *testing.T) {
+	type document struct {
+		Map *Map `json:"m"`
+	}
+	m := New()
+	m.Put("a", "1")
+	m.Put("b", "2")
+
+	data, err := json.Marshal(&document{Map: m})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := m.ToJSON()
+	if actualValue, expectedValue := string(data), `{"m":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Map: New()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Map.Size(), m.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(containers.GetSortedValues(doc.Map, utils.StringComparator)), fmt.Sprint(containers.GetSortedValues(m, utils.StringComparator)); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc = &document{}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := doc.Map.GetKey("2"); actualValue != "b" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+}
+
+func TestMapBinarySerialization(t *testing.T) {
+	m := New()
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	assert := func(restored *Map, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), m.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, key := range m.Keys() {
+			if actualValue, expectedValue := restored.Keys()[i], key; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+		for i, value := range m.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := m.MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = m.MarshalBinaryWith(utils.IntCodec, utils.StringCodec)
+	restored = New()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec, utils.StringCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Map *Map
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Map: m})
+	doc := &document{Map: New()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Map, err)
+}
+
+func TestMapStreaming(t *testing.T) {
+	m := New()
+	m.Put("b", "2")
+	m.Put("a", "1")
+
+	assert := func(restored *Map, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(m.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := m.WriteJSON(buffer)
+	restored := New()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = m.WriteBinary(buffer)
+	restored = New()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
 func sameElements(a []interface{}, b []interface{}) bool {
 	if len(a) != len(b) {
 		return false
@@ -224,6 +483,20 @@ func benchmarkRemove(b *testing.B, m *Map, size int) {
 	}
 }
 
+func TestMapConformance(t *testing.T) {
+	containertest.TestBidiMap(t, func() maps.BidiMap { return New() })
+	for _, keys := range [][]interface{}{{}, {1}, {1, 2, 3}} {
+		m := New()
+		values := []interface{}{}
+		for _, key := range keys {
+			m.Put(key, -key.(int))
+			values = append(values, -key.(int))
+		}
+		it := m.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+	}
+}
+
 func BenchmarkHashBidiMapGet100(b *testing.B) {
 	b.StopTimer()
 	size := 100
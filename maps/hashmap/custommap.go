@@ -0,0 +1,95 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hashmap
+
+import "github.com/emirpasic/gods/utils"
+
+// CustomMap holds the elements in a hash table with custom hashing
+type CustomMap struct {
+	table *table
+	elements
+}
+
+// NewWith instantiates a hash map with the custom hasher and equaler.
+// Keys need not be comparable, but equal keys (with respect to the equaler) must have equal hashes.
+// Keys passed to the map escape to the heap through the hasher and equaler, hence lookups may allocate (unlike in a Map).
+func NewWith(hasher utils.Hasher, equaler utils.Equaler) *CustomMap {
+	return &CustomMap{table: newTable(hasher, equaler)}
+}
+
+// Put inserts element into the map.
+func (m *CustomMap) Put(key interface{}, value interface{}) {
+	if i := m.table.lookup(m.keys, key); i >= 0 {
+		m.values[i] = value
+		return
+	}
+	m.table.insert(key)
+	m.add(key, value)
+}
+
+// Get searches the element in the map by key and returns its value or nil if key is not found in map.
+// Second return parameter is true if key was found, otherwise false.
+func (m *CustomMap) Get(key interface{}) (value interface{}, found bool) {
+	if i := m.table.lookup(m.keys, key); i >= 0 {
+		return m.values[i], true
+	}
+	return nil, false
+}
+
+// Remove removes the element from the map by key.
+func (m *CustomMap) Remove(key interface{}) {
+	i := m.table.lookup(m.keys, key)
+	if i < 0 {
+		return
+	}
+	m.table.remove(i)
+	m.remove(i)
+}
+
+// Empty returns true if map does not contain any elements
+func (m *CustomMap) Empty() bool {
+	return m.Size() == 0
+}
+
+// Size returns number of elements in the map.
+func (m *CustomMap) Size() int {
+	return len(m.keys)
+}
+
+// Keys returns all keys (random order).
+func (m *CustomMap) Keys() []interface{} {
+	return m.copyKeys()
+}
+
+// Values returns all values (random order).
+func (m *CustomMap) Values() []interface{} {
+	return m.copyValues()
+}
+
+// Clear removes all elements from the map.
+func (m *CustomMap) Clear() {
+	m.table.clear()
+	m.clear()
+}
+
+// Clone returns a shallow copy of the map with the same hasher and equaler (keys and values themselves are not copied).
+// Elements of the copy are iterated in the same order as the elements of the map.
+func (m *CustomMap) Clone() *CustomMap {
+	clone := m.newEmpty()
+	for i := range m.keys {
+		clone.Put(m.keys[i], m.values[i])
+	}
+	return clone
+}
+
+// String returns a string representation of container
+func (m *CustomMap) String() string {
+	return m.string("HashMap")
+}
+
+// newEmpty instantiates an empty map with the same hasher and equaler as this map.
+func (m *CustomMap) newEmpty() *CustomMap {
+	return NewWith(m.table.hasher, m.table.equaler)
+}
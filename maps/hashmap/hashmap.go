@@ -6,6 +6,14 @@
 //
 // Elements are unordered in the map.
 //
+// Keys of a Map are looked up in go's native map. Keys that are not comparable (e.g. slices) can be held by a CustomMap
+// instead (see NewWith), which looks them up in a hash table with separate chaining using a custom hasher and equaler.
+// The two are separate types, so that looking up a key in a Map never passes it to a function value,
+// which would make the key escape to the heap, i.e. lookups in a Map do not allocate, while lookups in a CustomMap may.
+// Either way the hash table only holds the positions of the keys, while keys and values are kept in two slices,
+// so that elements need no allocations of their own and iterating over the map does not allocate.
+// Removing an element moves the last element into its position.
+//
 // Structure is not thread safe.
 //
 // Reference: http://en.wikipedia.org/wiki/Associative_array
@@ -14,37 +22,62 @@ package hashmap
 import (
 	"fmt"
 	"github.com/emirpasic/gods/maps"
+	"strings"
 )
 
 func assertMapImplementation() {
 	var _ maps.Map = (*Map)(nil)
+	var _ maps.Map = (*CustomMap)(nil)
 }
 
 // Map holds the elements in go's native map
 type Map struct {
-	m map[interface{}]interface{}
+	m map[interface{}]int // position of each key in keys and values
+	elements
+}
+
+// elements holds the keys and values of a map at the positions held by its hash table
+type elements struct {
+	keys   []interface{}
+	values []interface{}
 }
 
 // New instantiates a hash map.
+// Keys should be comparable (see Go's spec), otherwise methods panic.
 func New() *Map {
-	return &Map{m: make(map[interface{}]interface{})}
+	return &Map{m: make(map[interface{}]int)}
 }
 
 // Put inserts element into the map.
 func (m *Map) Put(key interface{}, value interface{}) {
-	m.m[key] = value
+	if i, found := m.m[key]; found {
+		m.values[i] = value
+		return
+	}
+	m.m[key] = len(m.keys)
+	m.add(key, value)
 }
 
 // Get searches the element in the map by key and returns its value or nil if key is not found in map.
 // Second return parameter is true if key was found, otherwise false.
 func (m *Map) Get(key interface{}) (value interface{}, found bool) {
-	value, found = m.m[key]
-	return
+	if i, found := m.m[key]; found {
+		return m.values[i], true
+	}
+	return nil, false
 }
 
 // Remove removes the element from the map by key.
 func (m *Map) Remove(key interface{}) {
+	i, found := m.m[key]
+	if !found {
+		return
+	}
 	delete(m.m, key)
+	if last := len(m.keys) - 1; i != last {
+		m.m[m.keys[last]] = i
+	}
+	m.remove(i)
 }
 
 // Empty returns true if map does not contain any elements
@@ -54,39 +87,76 @@ func (m *Map) Empty() bool {
 
 // Size returns number of elements in the map.
 func (m *Map) Size() int {
-	return len(m.m)
+	return len(m.keys)
 }
 
 // Keys returns all keys (random order).
 func (m *Map) Keys() []interface{} {
-	keys := make([]interface{}, m.Size())
-	count := 0
-	for key := range m.m {
-		keys[count] = key
-		count++
-	}
-	return keys
+	return m.copyKeys()
 }
 
 // Values returns all values (random order).
 func (m *Map) Values() []interface{} {
-	values := make([]interface{}, m.Size())
-	count := 0
-	for _, value := range m.m {
-		values[count] = value
-		count++
-	}
-	return values
+	return m.copyValues()
 }
 
 // Clear removes all elements from the map.
 func (m *Map) Clear() {
-	m.m = make(map[interface{}]interface{})
+	m.m = make(map[interface{}]int)
+	m.clear()
+}
+
+// Clone returns a shallow copy of the map (keys and values themselves are not copied).
+// Elements of the copy are iterated in the same order as the elements of the map.
+func (m *Map) Clone() *Map {
+	clone := New()
+	for i := range m.keys {
+		clone.Put(m.keys[i], m.values[i])
+	}
+	return clone
 }
 
 // String returns a string representation of container
 func (m *Map) String() string {
-	str := "HashMap\n"
-	str += fmt.Sprintf("%v", m.m)
-	return str
+	return m.string("HashMap")
+}
+
+// add appends the element, whose key the hash table holds at the position following all other elements.
+func (elements *elements) add(key interface{}, value interface{}) {
+	elements.keys = append(elements.keys, key)
+	elements.values = append(elements.values, value)
+}
+
+// remove removes the element at the position and moves the last element into it, as the hash table does with the positions.
+func (elements *elements) remove(i int) {
+	last := len(elements.keys) - 1
+	elements.keys[i], elements.values[i] = elements.keys[last], elements.values[last]
+	elements.keys[last], elements.values[last] = nil, nil
+	elements.keys, elements.values = elements.keys[:last], elements.values[:last]
+}
+
+func (elements *elements) clear() {
+	elements.keys = nil
+	elements.values = nil
+}
+
+func (elements *elements) copyKeys() []interface{} {
+	keys := make([]interface{}, len(elements.keys))
+	copy(keys, elements.keys)
+	return keys
+}
+
+func (elements *elements) copyValues() []interface{} {
+	values := make([]interface{}, len(elements.values))
+	copy(values, elements.values)
+	return values
+}
+
+func (elements *elements) string(name string) string {
+	str := name + "\n"
+	items := []string{}
+	for i := range elements.keys {
+		items = append(items, fmt.Sprintf("%v:%v", elements.keys[i], elements.values[i]))
+	}
+	return str + "map[" + strings.Join(items, " ") + "]"
 }
@@ -5,7 +5,14 @@
 package hashmap
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/utils"
 	"testing"
 )
 
@@ -118,6 +125,283 @@ func TestMapRemove(t *testing.T) {
 	}
 }
 
+func TestMapNewWith(t *testing.T) {
+	m := NewWith(utils.BytesHasher, utils.BytesEqualer)
+	m.Put([]byte("a"), 1)
+	m.Put([]byte("b"), 2)
+	m.Put([]byte("a"), 3) //overwrite
+
+	if actualValue := m.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, expectedValue := m.Values(), []interface{}{3, 2}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get([]byte("a")); actualValue != 3 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, found := m.Get([]byte("c")); actualValue != nil || found {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	m.Remove([]byte("a"))
+	m.Remove([]byte("c"))
+	if actualValue, expectedValue := fmt.Sprintf("%s", m.Keys()), "[b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	m.Clear()
+	if actualValue := m.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestMapNewWithGrowth(t *testing.T) {
+	// a poor hasher puts every key into a handful of chains
+	hasher := func(value interface{}) uint64 { return uint64(value.([]int)[0] % 7) }
+	equaler := func(a, b interface{}) bool { return a.([]int)[0] == b.([]int)[0] }
+	m := NewWith(hasher, equaler)
+	for i := 0; i < 1000; i++ {
+		m.Put([]int{i}, i)
+	}
+	for i := 0; i < 1000; i += 2 {
+		m.Remove([]int{i})
+	}
+	if actualValue := m.Size(); actualValue != 500 {
+		t.Errorf("Got %v expected %v", actualValue, 500)
+	}
+	for i := 0; i < 1000; i++ {
+		value, found := m.Get([]int{i})
+		if found != (i%2 == 1) || (found && value != i) {
+			t.Errorf("Got %v,%v for key %v", value, found, i)
+		}
+	}
+}
+
+func TestMapLookupAllocations(t *testing.T) {
+	m := New()
+	for i := 1000; i < 1100; i++ {
+		m.Put(i, i)
+	}
+	allocations := testing.AllocsPerRun(100, func() {
+		for i := 1000; i < 1200; i++ {
+			m.Get(i)
+			m.Remove(i + 1000)
+		}
+	})
+	if allocations != 0 {
+		t.Errorf("Got %v allocations expected %v", allocations, 0)
+	}
+	if actualValue, expectedValue := m.Size(), 100; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapEach(t *testing.T) {
+	m := New()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	count := 0
+	m.Each(func(key interface{}, value interface{}) {
+		count++
+		switch key {
+		case "a":
+			if actualValue, expectedValue := value, 1; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		case "b":
+			if actualValue, expectedValue := value, 2; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		case "c":
+			if actualValue, expectedValue := value, 3; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		default:
+			t.Errorf("Too many")
+		}
+	})
+	if actualValue, expectedValue := count, 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapMap(t *testing.T) {
+	m := New()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	mappedMap := m.Map(func(key1 interface{}, value1 interface{}) (key2 interface{}, value2 interface{}) {
+		return key1, value1.(int) * value1.(int)
+	})
+	if actualValue, _ := mappedMap.Get("a"); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, _ := mappedMap.Get("b"); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue, _ := mappedMap.Get("c"); actualValue != 9 {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+	if mappedMap.Size() != 3 {
+		t.Errorf("Got %v expected %v", mappedMap.Size(), 3)
+	}
+}
+
+func TestMapMapWithHasher(t *testing.T) {
+	m := NewWith(utils.BytesHasher, utils.BytesEqualer)
+	m.Put([]byte("a"), 1)
+	m.Put([]byte("b"), 2)
+	mappedMap := m.Map(func(key1 interface{}, value1 interface{}) (key2 interface{}, value2 interface{}) {
+		return string(key1.([]byte)), value1
+	})
+	if actualValue, _ := mappedMap.Get("b"); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, expectedValue := mappedMap.Keys(), []interface{}{"a", "b"}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapSelect(t *testing.T) {
+	m := NewWith(utils.CaseInsensitiveStringHasher, utils.CaseInsensitiveStringEqualer)
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	selectedMap := m.Select(func(key interface{}, value interface{}) bool {
+		return key.(string) >= "a" && key.(string) <= "b"
+	})
+	if actualValue, _ := selectedMap.Get("A"); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, _ := selectedMap.Get("B"); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if selectedMap.Size() != 2 {
+		t.Errorf("Got %v expected %v", selectedMap.Size(), 2)
+	}
+}
+
+func TestMapAnyAllFind(t *testing.T) {
+	m := New()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	if actualValue := m.Any(func(key interface{}, value interface{}) bool { return value.(int) == 3 }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := m.Any(func(key interface{}, value interface{}) bool { return value.(int) == 4 }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := m.All(func(key interface{}, value interface{}) bool { return value.(int) >= 1 }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := m.All(func(key interface{}, value interface{}) bool { return value.(int) >= 2 }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if key, value := m.Find(func(key interface{}, value interface{}) bool { return key.(string) == "b" }); key != "b" || value != 2 {
+		t.Errorf("Got %v,%v expected %v,%v", key, value, "b", 2)
+	}
+	if key, value := m.Find(func(key interface{}, value interface{}) bool { return key.(string) == "x" }); key != nil || value != nil {
+		t.Errorf("Got %v,%v expected %v,%v", key, value, nil, nil)
+	}
+}
+
+func TestMapIteratorOnEmpty(t *testing.T) {
+	m := New()
+	it := m.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty map")
+	}
+}
+
+func TestMapIteratorNextPrev(t *testing.T) {
+	m := New()
+	m.Put("c", 3)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("d", 4)
+	m.Remove("d")
+
+	it := m.Iterator()
+	keys := []interface{}{}
+	for it.Next() {
+		if actualValue, expectedValue := m.Get(it.Key()); actualValue != it.Value() || !expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, it.Value())
+		}
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := keys, []interface{}{"a", "b", "c"}; !sameElements(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := len(keys) - 1; it.Prev(); i-- {
+		if actualValue, expectedValue := it.Key(), keys[i]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestMapIteratorFirstLast(t *testing.T) {
+	m := New()
+	m.Put(1, "a")
+	m.Put(2, "b")
+	it := m.Iterator()
+	if actualValue, expectedValue := it.First(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	first := it.Key()
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := it.Key(); actualValue == first {
+		t.Errorf("Got %v expected other than %v", actualValue, first)
+	}
+	it.Begin()
+	it.Next()
+	if actualValue, expectedValue := it.Key(), first; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIteratorAllocations(t *testing.T) {
+	m := New()
+	for i := 0; i < 100; i++ {
+		m.Put(i, i)
+	}
+	allocations := testing.AllocsPerRun(100, func() {
+		it := m.Iterator()
+		for it.Next() {
+		}
+	})
+	if allocations != 0 {
+		t.Errorf("Got %v allocations expected %v", allocations, 0)
+	}
+}
+
+func TestMapClone(t *testing.T) {
+	m := New()
+	m.Put("b", "2")
+	m.Put("a", "1")
+
+	clone := m.Clone()
+	if actualValue := containers.Equal(clone, m, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), m.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Put("a", "3")
+	if actualValue := containers.Equal(clone, m, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(m.Clone(), m, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestMapSerialization(t *testing.T) {
 	m := New()
 	m.Put("a", 1.0)
@@ -149,6 +433,156 @@ func TestMapSerialization(t *testing.T) {
 	assert()
 }
 
+func TestCustomMapSerialization(t *testing.T) {
+	m := NewWith(utils.CaseInsensitiveStringHasher, utils.CaseInsensitiveStringEqualer)
+	m.Put("a", 1.0)
+	m.Put("B", 2.0)
+
+	json, err := m.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	m = NewWith(utils.CaseInsensitiveStringHasher, utils.CaseInsensitiveStringEqualer)
+	if err := m.FromJSON(json); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := m.Get("b"); actualValue != 2.0 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 2.0)
+	}
+
+	data, err := m.MarshalBinaryWith(utils.StringCodec, nil)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	m.Clear()
+	if err := m.UnmarshalBinaryWith(data, utils.StringCodec, nil); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := m.Get("A"); actualValue != 1.0 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 1.0)
+	}
+	if actualValue, expectedValue := m.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapMarshalJSON(t *testing.T) {
+	type document struct {
+		Map *Map `json:"m"`
+	}
+	m := New()
+	m.Put("a", "1")
+	m.Put("b", "2")
+
+	data, err := json.Marshal(&document{Map: m})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := m.ToJSON()
+	if actualValue, expectedValue := string(data), `{"m":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Map: New()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Map.Size(), m.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(containers.GetSortedValues(doc.Map, utils.StringComparator)), fmt.Sprint(containers.GetSortedValues(m, utils.StringComparator)); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapBinarySerialization(t *testing.T) {
+	m := New()
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	assert := func(restored *Map, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), m.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, key := range m.Keys() {
+			if actualValue, expectedValue := restored.Keys()[i], key; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+		for i, value := range m.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := m.MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = m.MarshalBinaryWith(utils.IntCodec, utils.StringCodec)
+	restored = New()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec, utils.StringCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Map *Map
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Map: m})
+	doc := &document{Map: New()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Map, err)
+}
+
+func TestMapStreaming(t *testing.T) {
+	m := New()
+	m.Put("b", "2")
+	m.Put("a", "1")
+
+	assert := func(restored *Map, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(m.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := m.WriteJSON(buffer)
+	restored := New()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = m.WriteBinary(buffer)
+	restored = New()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
 func sameElements(a []interface{}, b []interface{}) bool {
 	if len(a) != len(b) {
 		return false
@@ -192,6 +626,30 @@ func benchmarkRemove(b *testing.B, m *Map, size int) {
 	}
 }
 
+func TestMapConformance(t *testing.T) {
+	containertest.TestMap(t, func() maps.Map { return New() })
+	// a poor hasher puts keys into shared chains, so that removals relink entries within chains
+	hasher := func(value interface{}) uint64 { return uint64(value.(int) % 3) }
+	equaler := func(a, b interface{}) bool { return a == b }
+	containertest.TestMap(t, func() maps.Map { return NewWith(hasher, equaler) })
+	for _, keys := range [][]interface{}{{}, {1}, {1, 2, 3}} {
+		m := New()
+		values := []interface{}{}
+		for _, key := range keys {
+			m.Put(key, -key.(int))
+			values = append(values, -key.(int))
+		}
+		it := m.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+		customMap := NewWith(hasher, equaler)
+		for _, key := range keys {
+			customMap.Put(key, -key.(int))
+		}
+		it = customMap.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+	}
+}
+
 func BenchmarkHashMapGet100(b *testing.B) {
 	b.StopTimer()
 	size := 100
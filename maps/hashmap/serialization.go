@@ -5,34 +5,287 @@
 package hashmap
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
 	"encoding/json"
 	"github.com/emirpasic/gods/containers"
 	"github.com/emirpasic/gods/utils"
+	"io"
 )
 
 func assertSerializationImplementation() {
 	var _ containers.JSONSerializer = (*Map)(nil)
 	var _ containers.JSONDeserializer = (*Map)(nil)
+	var _ json.Marshaler = (*Map)(nil)
+	var _ json.Unmarshaler = (*Map)(nil)
+	var _ encoding.BinaryMarshaler = (*Map)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Map)(nil)
+	var _ gob.GobEncoder = (*Map)(nil)
+	var _ gob.GobDecoder = (*Map)(nil)
+	var _ containers.JSONSerializer = (*CustomMap)(nil)
+	var _ containers.JSONDeserializer = (*CustomMap)(nil)
+	var _ json.Marshaler = (*CustomMap)(nil)
+	var _ json.Unmarshaler = (*CustomMap)(nil)
+	var _ encoding.BinaryMarshaler = (*CustomMap)(nil)
+	var _ encoding.BinaryUnmarshaler = (*CustomMap)(nil)
+	var _ gob.GobEncoder = (*CustomMap)(nil)
+	var _ gob.GobDecoder = (*CustomMap)(nil)
 }
 
 // ToJSON outputs the JSON representation of list's elements.
 func (m *Map) ToJSON() ([]byte, error) {
+	return m.toJSON()
+}
+
+// FromJSON populates list's elements from the input JSON representation.
+// Keys are restored as strings.
+func (m *Map) FromJSON(data []byte) error {
 	elements := make(map[string]interface{})
-	for key, value := range m.m {
-		elements[utils.ToString(key)] = value
+	err := json.Unmarshal(data, &elements)
+	if err == nil {
+		m.Clear()
+		for key, value := range elements {
+			m.Put(key, value)
+		}
+	}
+	return err
+}
+
+// WriteJSON writes the JSON representation of map's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (m *Map) WriteJSON(w io.Writer) error {
+	return m.writeJSON(w)
+}
+
+// ReadJSON populates map's elements from the JSON representation read from the reader (see FromJSON).
+// Map is not modified if the input can not be decoded.
+func (m *Map) ReadJSON(r io.Reader) error {
+	loaded := New()
+	if err := utils.ReadJSONObject(r, nil, func(key string, value interface{}) error {
+		loaded.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	*m = *loaded
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of map's elements (implements json.Marshaler).
+func (m *Map) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON populates map's elements from the input JSON representation (implements json.Unmarshaler).
+func (m *Map) UnmarshalJSON(data []byte) error {
+	return m.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of map's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (m *Map) MarshalBinary() ([]byte, error) {
+	return m.MarshalBinaryWith(nil, nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of map's elements,
+// encoding keys and values with the given codecs (nil for gob).
+func (m *Map) MarshalBinaryWith(keyCodec utils.Codec, valueCodec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := m.WriteBinaryWith(buffer, keyCodec, valueCodec); err != nil {
+		return nil, err
 	}
-	return json.Marshal(&elements)
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates map's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (m *Map) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalBinaryWith(data, nil, nil)
+}
+
+// UnmarshalBinaryWith populates map's elements from the input binary representation,
+// decoding keys and values with the given codecs, which must be the ones the elements were encoded with.
+// Map is not modified if the input can not be decoded.
+func (m *Map) UnmarshalBinaryWith(data []byte, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return m.ReadBinaryWith(bytes.NewReader(data), keyCodec, valueCodec)
+}
+
+// WriteBinary writes the binary representation of map's elements (see MarshalBinary) into the writer.
+func (m *Map) WriteBinary(w io.Writer) error {
+	return m.WriteBinaryWith(w, nil, nil)
+}
+
+// WriteBinaryWith writes the binary representation of map's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (m *Map) WriteBinaryWith(w io.Writer, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return m.writeBinaryWith(w, keyCodec, valueCodec)
+}
+
+// ReadBinary populates map's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (m *Map) ReadBinary(r io.Reader) error {
+	return m.ReadBinaryWith(r, nil, nil)
+}
+
+// ReadBinaryWith populates map's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (m *Map) ReadBinaryWith(r io.Reader, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	loaded := New()
+	if err := utils.ReadBinaryPairs(r, keyCodec, valueCodec, func(key interface{}, value interface{}) error {
+		loaded.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	*m = *loaded
+	return nil
+}
+
+// GobEncode outputs the binary representation of map's elements (implements gob.GobEncoder).
+func (m *Map) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode populates map's elements from the input binary representation (implements gob.GobDecoder).
+func (m *Map) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
+
+// ToJSON outputs the JSON representation of list's elements.
+func (m *CustomMap) ToJSON() ([]byte, error) {
+	return m.toJSON()
 }
 
 // FromJSON populates list's elements from the input JSON representation.
-func (m *Map) FromJSON(data []byte) error {
+// Keys are restored as strings, hence the hasher and equaler have to accept strings.
+func (m *CustomMap) FromJSON(data []byte) error {
 	elements := make(map[string]interface{})
 	err := json.Unmarshal(data, &elements)
 	if err == nil {
 		m.Clear()
 		for key, value := range elements {
-			m.m[key] = value
+			m.Put(key, value)
 		}
 	}
 	return err
 }
+
+// WriteJSON writes the JSON representation of map's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (m *CustomMap) WriteJSON(w io.Writer) error {
+	return m.writeJSON(w)
+}
+
+// ReadJSON populates map's elements from the JSON representation read from the reader (see FromJSON).
+// Map is not modified if the input can not be decoded.
+func (m *CustomMap) ReadJSON(r io.Reader) error {
+	loaded := m.newEmpty()
+	if err := utils.ReadJSONObject(r, nil, func(key string, value interface{}) error {
+		loaded.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	*m = *loaded
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of map's elements (implements json.Marshaler).
+func (m *CustomMap) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON populates map's elements from the input JSON representation (implements json.Unmarshaler).
+func (m *CustomMap) UnmarshalJSON(data []byte) error {
+	return m.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of map's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (m *CustomMap) MarshalBinary() ([]byte, error) {
+	return m.MarshalBinaryWith(nil, nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of map's elements,
+// encoding keys and values with the given codecs (nil for gob).
+func (m *CustomMap) MarshalBinaryWith(keyCodec utils.Codec, valueCodec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := m.WriteBinaryWith(buffer, keyCodec, valueCodec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates map's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (m *CustomMap) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalBinaryWith(data, nil, nil)
+}
+
+// UnmarshalBinaryWith populates map's elements from the input binary representation,
+// decoding keys and values with the given codecs, which must be the ones the elements were encoded with.
+// Map is not modified if the input can not be decoded.
+func (m *CustomMap) UnmarshalBinaryWith(data []byte, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return m.ReadBinaryWith(bytes.NewReader(data), keyCodec, valueCodec)
+}
+
+// WriteBinary writes the binary representation of map's elements (see MarshalBinary) into the writer.
+func (m *CustomMap) WriteBinary(w io.Writer) error {
+	return m.WriteBinaryWith(w, nil, nil)
+}
+
+// WriteBinaryWith writes the binary representation of map's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (m *CustomMap) WriteBinaryWith(w io.Writer, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return m.writeBinaryWith(w, keyCodec, valueCodec)
+}
+
+// ReadBinary populates map's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (m *CustomMap) ReadBinary(r io.Reader) error {
+	return m.ReadBinaryWith(r, nil, nil)
+}
+
+// ReadBinaryWith populates map's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (m *CustomMap) ReadBinaryWith(r io.Reader, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	loaded := m.newEmpty()
+	if err := utils.ReadBinaryPairs(r, keyCodec, valueCodec, func(key interface{}, value interface{}) error {
+		loaded.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	*m = *loaded
+	return nil
+}
+
+// GobEncode outputs the binary representation of map's elements (implements gob.GobEncoder).
+func (m *CustomMap) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode populates map's elements from the input binary representation (implements gob.GobDecoder).
+func (m *CustomMap) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
+
+func (elements *elements) toJSON() ([]byte, error) {
+	members := make(map[string]interface{})
+	for i := range elements.keys {
+		members[utils.ToString(elements.keys[i])] = elements.values[i]
+	}
+	return json.Marshal(&members)
+}
+
+func (elements *elements) writeJSON(w io.Writer) error {
+	i := 0
+	return utils.WriteJSONObject(w, func() (string, interface{}, bool) {
+		if i == len(elements.keys) {
+			return "", nil, false
+		}
+		i++
+		return utils.ToString(elements.keys[i-1]), elements.values[i-1], true
+	})
+}
+
+func (elements *elements) writeBinaryWith(w io.Writer, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	i := 0
+	return utils.WriteBinaryPairs(w, len(elements.keys), func() (interface{}, interface{}) {
+		i++
+		return elements.keys[i-1], elements.values[i-1]
+	}, keyCodec, valueCodec)
+}
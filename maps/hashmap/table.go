@@ -0,0 +1,94 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hashmap
+
+import "github.com/emirpasic/gods/utils"
+
+const (
+	initialBuckets = 16   // number of buckets in a new table, must be a power of two
+	maxLoadFactor  = 0.75 // table doubles its buckets once the average number of entries per bucket exceeds this
+)
+
+// table is a hash table with separate chaining, used for keys hashed and compared by user-supplied functions.
+// Entries are identified by the positions of their keys in the map, chains link the positions of the entries in a bucket.
+type table struct {
+	hasher  utils.Hasher
+	equaler utils.Equaler
+	buckets []int    // position of the first entry in each bucket plus one, 0 if the bucket is empty
+	hashes  []uint64 // hash of each entry
+	chains  []int    // position of the next entry in the bucket of each entry plus one, 0 if it is the last one
+}
+
+func newTable(hasher utils.Hasher, equaler utils.Equaler) *table {
+	return &table{hasher: hasher, equaler: equaler, buckets: make([]int, initialBuckets)}
+}
+
+// lookup returns the position of the entry whose key, one of the keys, is equal to the given key or -1 if there is none.
+func (table *table) lookup(keys []interface{}, key interface{}) int {
+	hash := table.hasher(key)
+	for i := table.buckets[table.bucketIndex(hash)] - 1; i >= 0; i = table.chains[i] - 1 {
+		if table.hashes[i] == hash && table.equaler(keys[i], key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// insert adds the entry of the key, whose position follows the positions of all entries, and which must not be present in the table yet.
+func (table *table) insert(key interface{}) {
+	hash := table.hasher(key)
+	table.hashes = append(table.hashes, hash)
+	table.chains = append(table.chains, 0)
+	table.link(len(table.hashes) - 1)
+	if float64(len(table.hashes)) > maxLoadFactor*float64(len(table.buckets)) {
+		table.resize(2 * len(table.buckets))
+	}
+}
+
+// remove removes the entry at the position and moves the last entry into it, as the map does with its keys and values.
+func (table *table) remove(i int) {
+	table.unlink(i)
+	last := len(table.hashes) - 1
+	if i != last {
+		table.unlink(last)
+		table.hashes[i] = table.hashes[last]
+		table.link(i)
+	}
+	table.hashes = table.hashes[:last]
+	table.chains = table.chains[:last]
+}
+
+func (table *table) clear() {
+	table.buckets = make([]int, initialBuckets)
+	table.hashes = nil
+	table.chains = nil
+}
+
+// link prepends the entry at the position to the chain of its bucket.
+func (table *table) link(i int) {
+	index := table.bucketIndex(table.hashes[i])
+	table.chains[i] = table.buckets[index]
+	table.buckets[index] = i + 1
+}
+
+// unlink removes the entry at the position from the chain of its bucket.
+func (table *table) unlink(i int) {
+	next := &table.buckets[table.bucketIndex(table.hashes[i])]
+	for *next != i+1 {
+		next = &table.chains[*next-1]
+	}
+	*next = table.chains[i]
+}
+
+func (table *table) bucketIndex(hash uint64) int {
+	return int(hash & uint64(len(table.buckets)-1))
+}
+
+func (table *table) resize(size int) {
+	table.buckets = make([]int, size)
+	for i := range table.hashes {
+		table.link(i)
+	}
+}
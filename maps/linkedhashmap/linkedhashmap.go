@@ -0,0 +1,145 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package linkedhashmap is a map that preserves insertion-order.
+//
+// It is backed by a hash table to store values and by links between the entries to keep the order of insertion:
+// putting an existing key keeps its position, removing a key forgets it.
+// Each element costs an allocation and a few words on top of go's native map, which makes iteration deterministic
+// and free of allocations.
+//
+// Structure is not thread safe.
+//
+// Reference: http://en.wikipedia.org/wiki/Associative_array
+package linkedhashmap
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/maps"
+	"strings"
+)
+
+func assertMapImplementation() {
+	var _ maps.Map = (*Map)(nil)
+}
+
+// Map holds the elements in go's native map, linked to each other in the order of insertion
+type Map struct {
+	m     map[interface{}]*entry
+	first *entry
+	last  *entry
+}
+
+// entry is a key-value pair, linked to its neighbours in the order of insertion
+type entry struct {
+	key   interface{}
+	value interface{}
+	prev  *entry
+	next  *entry
+}
+
+// New instantiates a linked-hash-map.
+// Keys should be comparable (see Go's spec), otherwise methods panic.
+func New() *Map {
+	return &Map{m: make(map[interface{}]*entry)}
+}
+
+// Put inserts key-value pair into the map.
+// Putting an existing key replaces its value, but keeps its position in the order of insertion.
+func (m *Map) Put(key interface{}, value interface{}) {
+	if e, found := m.m[key]; found {
+		e.value = value
+		return
+	}
+	e := &entry{key: key, value: value, prev: m.last}
+	m.m[key] = e
+	if m.last == nil {
+		m.first = e
+	} else {
+		m.last.next = e
+	}
+	m.last = e
+}
+
+// Get searches the element in the map by key and returns its value or nil if key is not found in map.
+// Second return parameter is true if key was found, otherwise false.
+func (m *Map) Get(key interface{}) (value interface{}, found bool) {
+	if e, found := m.m[key]; found {
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Remove removes the element from the map by key.
+func (m *Map) Remove(key interface{}) {
+	e, found := m.m[key]
+	if !found {
+		return
+	}
+	delete(m.m, key)
+	if e.prev == nil {
+		m.first = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		m.last = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+}
+
+// Empty returns true if map does not contain any elements
+func (m *Map) Empty() bool {
+	return m.Size() == 0
+}
+
+// Size returns number of elements in the map.
+func (m *Map) Size() int {
+	return len(m.m)
+}
+
+// Keys returns all keys in-order
+func (m *Map) Keys() []interface{} {
+	keys := make([]interface{}, m.Size())
+	for i, e := 0, m.first; e != nil; i, e = i+1, e.next {
+		keys[i] = e.key
+	}
+	return keys
+}
+
+// Values returns all values in-order based on the key.
+func (m *Map) Values() []interface{} {
+	values := make([]interface{}, m.Size())
+	for i, e := 0, m.first; e != nil; i, e = i+1, e.next {
+		values[i] = e.value
+	}
+	return values
+}
+
+// Clear removes all elements from the map.
+func (m *Map) Clear() {
+	m.m = make(map[interface{}]*entry)
+	m.first = nil
+	m.last = nil
+}
+
+// Clone returns a shallow copy of the map with the same order of insertion (keys and values themselves are not copied).
+func (m *Map) Clone() *Map {
+	clone := New()
+	for e := m.first; e != nil; e = e.next {
+		clone.Put(e.key, e.value)
+	}
+	return clone
+}
+
+// String returns a string representation of container
+func (m *Map) String() string {
+	str := "LinkedHashMap\n"
+	items := []string{}
+	for e := m.first; e != nil; e = e.next {
+		items = append(items, fmt.Sprintf("%v:%v", e.key, e.value))
+	}
+	return str + "map[" + strings.Join(items, " ") + "]"
+}
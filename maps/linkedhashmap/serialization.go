@@ -0,0 +1,148 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linkedhashmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
+
+func assertSerializationImplementation() {
+	var _ containers.JSONSerializer = (*Map)(nil)
+	var _ containers.JSONDeserializer = (*Map)(nil)
+	var _ json.Marshaler = (*Map)(nil)
+	var _ json.Unmarshaler = (*Map)(nil)
+	var _ encoding.BinaryMarshaler = (*Map)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Map)(nil)
+	var _ gob.GobEncoder = (*Map)(nil)
+	var _ gob.GobDecoder = (*Map)(nil)
+}
+
+// ToJSON outputs the JSON representation of map's elements as an object whose members are in insertion order.
+func (m *Map) ToJSON() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := m.WriteJSON(buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// FromJSON populates map's elements from the input JSON representation, inserting them in the order of the members.
+// Keys are restored as strings.
+// Map is not modified if the input can not be decoded.
+func (m *Map) FromJSON(data []byte) error {
+	return m.ReadJSON(bytes.NewReader(data))
+}
+
+// WriteJSON writes the JSON representation of map's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (m *Map) WriteJSON(w io.Writer) error {
+	it := m.Iterator()
+	return utils.WriteJSONObject(w, func() (string, interface{}, bool) {
+		if !it.Next() {
+			return "", nil, false
+		}
+		return utils.ToString(it.Key()), it.Value(), true
+	})
+}
+
+// ReadJSON populates map's elements from the JSON representation read from the reader (see FromJSON).
+// Map is not modified if the input can not be decoded.
+func (m *Map) ReadJSON(r io.Reader) error {
+	loaded := New()
+	if err := utils.ReadJSONObject(r, nil, func(key string, value interface{}) error {
+		loaded.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	*m = *loaded
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of map's elements (implements json.Marshaler).
+func (m *Map) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON populates map's elements from the input JSON representation (implements json.Unmarshaler).
+func (m *Map) UnmarshalJSON(data []byte) error {
+	return m.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of map's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (m *Map) MarshalBinary() ([]byte, error) {
+	return m.MarshalBinaryWith(nil, nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of map's elements in insertion order,
+// encoding keys and values with the given codecs (nil for gob).
+func (m *Map) MarshalBinaryWith(keyCodec utils.Codec, valueCodec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := m.WriteBinaryWith(buffer, keyCodec, valueCodec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates map's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (m *Map) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalBinaryWith(data, nil, nil)
+}
+
+// UnmarshalBinaryWith populates map's elements from the input binary representation,
+// decoding keys and values with the given codecs, which must be the ones the elements were encoded with.
+// Map is not modified if the input can not be decoded.
+func (m *Map) UnmarshalBinaryWith(data []byte, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return m.ReadBinaryWith(bytes.NewReader(data), keyCodec, valueCodec)
+}
+
+// WriteBinary writes the binary representation of map's elements (see MarshalBinary) into the writer.
+func (m *Map) WriteBinary(w io.Writer) error {
+	return m.WriteBinaryWith(w, nil, nil)
+}
+
+// WriteBinaryWith writes the binary representation of map's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (m *Map) WriteBinaryWith(w io.Writer, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	it := m.Iterator()
+	return utils.WriteBinaryPairs(w, m.Size(), func() (interface{}, interface{}) {
+		it.Next()
+		return it.Key(), it.Value()
+	}, keyCodec, valueCodec)
+}
+
+// ReadBinary populates map's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (m *Map) ReadBinary(r io.Reader) error {
+	return m.ReadBinaryWith(r, nil, nil)
+}
+
+// ReadBinaryWith populates map's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (m *Map) ReadBinaryWith(r io.Reader, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	loaded := New()
+	if err := utils.ReadBinaryPairs(r, keyCodec, valueCodec, func(key interface{}, value interface{}) error {
+		loaded.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	*m = *loaded
+	return nil
+}
+
+// GobEncode outputs the binary representation of map's elements (implements gob.GobEncoder).
+func (m *Map) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode populates map's elements from the input binary representation (implements gob.GobDecoder).
+func (m *Map) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
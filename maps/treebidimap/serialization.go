@@ -5,35 +5,185 @@
 package treebidimap
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"github.com/emirpasic/gods/containers"
 	"github.com/emirpasic/gods/utils"
+	"io"
 )
 
 func assertSerializationImplementation() {
 	var _ containers.JSONSerializer = (*Map)(nil)
 	var _ containers.JSONDeserializer = (*Map)(nil)
+	var _ json.Marshaler = (*Map)(nil)
+	var _ json.Unmarshaler = (*Map)(nil)
+	var _ encoding.BinaryMarshaler = (*Map)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Map)(nil)
+	var _ gob.GobEncoder = (*Map)(nil)
+	var _ gob.GobDecoder = (*Map)(nil)
 }
 
-// ToJSON outputs the JSON representation of list's elements.
+// errNoComparator is returned when elements are decoded into a map that was not instantiated by a constructor,
+// e.g. the zero value of a field being unmarshalled, since the map can not order the keys and values without comparators.
+var errNoComparator = errors.New("treebidimap: can not decode into a map without comparators, instantiate it with NewWith")
+
+// ToJSON outputs the JSON representation of map's elements as an array of [key, value] pairs in key order.
 func (m *Map) ToJSON() ([]byte, error) {
-	elements := make(map[string]interface{})
+	elements := make([][2]interface{}, 0, m.Size())
 	it := m.Iterator()
 	for it.Next() {
-		elements[utils.ToString(it.Key())] = it.Value()
+		elements = append(elements, [2]interface{}{it.Key(), it.Value()})
 	}
 	return json.Marshal(&elements)
 }
 
-// FromJSON populates list's elements from the input JSON representation.
+// FromJSON populates map's elements from the input JSON representation.
+// Keys and values are decoded with the map's decoders (see SetDecoders and FromJSONWith for other decoders).
 func (m *Map) FromJSON(data []byte) error {
-	elements := make(map[string]interface{})
-	err := json.Unmarshal(data, &elements)
-	if err == nil {
-		m.Clear()
-		for key, value := range elements {
-			m.Put(key, value)
+	return m.FromJSONWith(data, m.keyDecoder, m.valueDecoder)
+}
+
+// FromJSONWith populates map's elements from the input JSON representation,
+// decoding keys and values with the given decoders (nil for Go's default JSON decoding).
+// Map is not modified if the input can not be decoded.
+func (m *Map) FromJSONWith(data []byte, keyDecoder utils.Decoder, valueDecoder utils.Decoder) error {
+	return m.ReadJSONWith(bytes.NewReader(data), keyDecoder, valueDecoder)
+}
+
+// SetDecoders sets the decoders of keys and values used by FromJSON, ReadJSON and UnmarshalJSON (nil for Go's default JSON decoding),
+// e.g. for unmarshalling a field holding the map with json.Unmarshal.
+// Maps instantiated with NewWithIntComparators or NewWithStringComparators decode keys and values with the IntDecoder or StringDecoder.
+func (m *Map) SetDecoders(keyDecoder utils.Decoder, valueDecoder utils.Decoder) {
+	m.keyDecoder = keyDecoder
+	m.valueDecoder = valueDecoder
+}
+
+// WriteJSON writes the JSON representation of map's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (m *Map) WriteJSON(w io.Writer) error {
+	it := m.Iterator()
+	return utils.WriteJSONPairs(w, func() (interface{}, interface{}, bool) {
+		if !it.Next() {
+			return nil, nil, false
 		}
+		return it.Key(), it.Value(), true
+	})
+}
+
+// ReadJSON populates map's elements from the JSON representation read from the reader (see FromJSON).
+func (m *Map) ReadJSON(r io.Reader) error {
+	return m.ReadJSONWith(r, m.keyDecoder, m.valueDecoder)
+}
+
+// ReadJSONWith populates map's elements from the JSON representation read from the reader (see FromJSONWith).
+func (m *Map) ReadJSONWith(r io.Reader, keyDecoder utils.Decoder, valueDecoder utils.Decoder) error {
+	if m.keyComparator == nil || m.valueComparator == nil {
+		return errNoComparator
+	}
+	loaded := NewWith(m.keyComparator, m.valueComparator)
+	if err := utils.ReadJSONPairs(r, keyDecoder, valueDecoder, loaded.putChecked); err != nil {
+		return err
+	}
+	m.forwardMap, m.inverseMap = loaded.forwardMap, loaded.inverseMap
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of map's elements (implements json.Marshaler).
+func (m *Map) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON populates map's elements from the input JSON representation (implements json.Unmarshaler).
+func (m *Map) UnmarshalJSON(data []byte) error {
+	return m.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of map's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (m *Map) MarshalBinary() ([]byte, error) {
+	return m.MarshalBinaryWith(nil, nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of map's elements,
+// encoding keys and values with the given codecs (nil for gob).
+func (m *Map) MarshalBinaryWith(keyCodec utils.Codec, valueCodec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := m.WriteBinaryWith(buffer, keyCodec, valueCodec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates map's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (m *Map) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalBinaryWith(data, nil, nil)
+}
+
+// UnmarshalBinaryWith populates map's elements from the input binary representation,
+// decoding keys and values with the given codecs, which must be the ones the elements were encoded with.
+// Map is not modified if the input can not be decoded.
+func (m *Map) UnmarshalBinaryWith(data []byte, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return m.ReadBinaryWith(bytes.NewReader(data), keyCodec, valueCodec)
+}
+
+// WriteBinary writes the binary representation of map's elements (see MarshalBinary) into the writer.
+func (m *Map) WriteBinary(w io.Writer) error {
+	return m.WriteBinaryWith(w, nil, nil)
+}
+
+// WriteBinaryWith writes the binary representation of map's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (m *Map) WriteBinaryWith(w io.Writer, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	it := m.Iterator()
+	return utils.WriteBinaryPairs(w, m.Size(), func() (interface{}, interface{}) {
+		it.Next()
+		return it.Key(), it.Value()
+	}, keyCodec, valueCodec)
+}
+
+// ReadBinary populates map's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (m *Map) ReadBinary(r io.Reader) error {
+	return m.ReadBinaryWith(r, nil, nil)
+}
+
+// ReadBinaryWith populates map's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (m *Map) ReadBinaryWith(r io.Reader, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	if m.keyComparator == nil || m.valueComparator == nil {
+		return errNoComparator
+	}
+	loaded := NewWith(m.keyComparator, m.valueComparator)
+	if err := utils.ReadBinaryPairs(r, keyCodec, valueCodec, loaded.putChecked); err != nil {
+		return err
+	}
+	m.forwardMap, m.inverseMap = loaded.forwardMap, loaded.inverseMap
+	return nil
+}
+
+// GobEncode outputs the binary representation of map's elements (implements gob.GobEncoder).
+func (m *Map) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode populates map's elements from the input binary representation (implements gob.GobDecoder).
+func (m *Map) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
+
+// putChecked puts the element into the map like Put, but returns an error instead of panicking
+// if the comparators can not compare the key and the value with the last key of the map and its value.
+func (m *Map) putChecked(key interface{}, value interface{}) error {
+	lastKey, lastValue := key, value
+	if right := m.forwardMap.Right(); right != nil {
+		lastKey, lastValue = right.Key, right.Value.(*data).value
+	}
+	if _, err := utils.CompareKeys(m.keyComparator, lastKey, key); err != nil {
+		return err
+	}
+	if _, err := utils.CompareKeys(m.valueComparator, lastValue, value); err != nil {
+		return err
 	}
-	return err
+	m.Put(key, value)
+	return nil
 }
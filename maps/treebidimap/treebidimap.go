@@ -35,6 +35,8 @@ type Map struct {
 	inverseMap      redblacktree.Tree
 	keyComparator   utils.Comparator
 	valueComparator utils.Comparator
+	keyDecoder      utils.Decoder // decodes keys from JSON, nil for Go's default JSON decoding (see SetDecoders)
+	valueDecoder    utils.Decoder // decodes values from JSON, nil for Go's default JSON decoding
 }
 
 type data struct {
@@ -54,12 +56,16 @@ func NewWith(keyComparator utils.Comparator, valueComparator utils.Comparator) *
 
 // NewWithIntComparators instantiates a bidirectional map with the IntComparator for key and value, i.e. keys and values are of type int.
 func NewWithIntComparators() *Map {
-	return NewWith(utils.IntComparator, utils.IntComparator)
+	m := NewWith(utils.IntComparator, utils.IntComparator)
+	m.SetDecoders(utils.IntDecoder, utils.IntDecoder)
+	return m
 }
 
 // NewWithStringComparators instantiates a bidirectional map with the StringComparator for key and value, i.e. keys and values are of type string.
 func NewWithStringComparators() *Map {
-	return NewWith(utils.StringComparator, utils.StringComparator)
+	m := NewWith(utils.StringComparator, utils.StringComparator)
+	m.SetDecoders(utils.StringDecoder, utils.StringDecoder)
+	return m
 }
 
 // Put inserts element into the map.
@@ -127,6 +133,18 @@ func (m *Map) Clear() {
 	m.inverseMap.Clear()
 }
 
+// Clone returns a copy of the map with the same structure and comparators (keys and values themselves are not copied).
+func (m *Map) Clone() *Map {
+	return &Map{
+		forwardMap:      *m.forwardMap.Clone(),
+		inverseMap:      *m.inverseMap.Clone(),
+		keyComparator:   m.keyComparator,
+		valueComparator: m.valueComparator,
+		keyDecoder:      m.keyDecoder,
+		valueDecoder:    m.valueDecoder,
+	}
+}
+
 // String returns a string representation of container
 func (m *Map) String() string {
 	str := "TreeBidiMap\nmap["
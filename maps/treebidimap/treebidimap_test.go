@@ -5,7 +5,13 @@
 package treebidimap
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/maps"
 	"github.com/emirpasic/gods/utils"
 	"testing"
 )
@@ -473,6 +479,25 @@ func TestMapIteratorLast(t *testing.T) {
 	}
 }
 
+func TestMapClone(t *testing.T) {
+	m := NewWithStringComparators()
+	m.Put("b", "2")
+	m.Put("a", "1")
+
+	clone := m.Clone()
+	if actualValue := containers.Equal(clone, m, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	clone.Put("a", "3")
+	if actualValue := containers.Equal(clone, m, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(m.Clone(), m, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestMapSerialization(t *testing.T) {
 	m := NewWithStringComparators()
 	m.Put("a", "1")
@@ -504,6 +529,193 @@ func TestMapSerialization(t *testing.T) {
 	assert()
 }
 
+func TestMapMarshalJSON(t *testing.T) {
+	type document struct {
+		Map *Map `json:"m"`
+	}
+	m := NewWithStringComparators()
+	m.Put("a", "1")
+	m.Put("b", "2")
+
+	data, err := json.Marshal(&document{Map: m})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := m.ToJSON()
+	if actualValue, expectedValue := string(data), `{"m":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Map: NewWithStringComparators()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Map.Size(), m.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Map.Values()), fmt.Sprint(m.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = json.Unmarshal(data, &document{})
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Map).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapBinarySerialization(t *testing.T) {
+	m := NewWith(utils.IntComparator, utils.StringComparator)
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	assert := func(restored *Map, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), m.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, key := range m.Keys() {
+			if actualValue, expectedValue := restored.Keys()[i], key; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+		for i, value := range m.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := m.MarshalBinary()
+	restored := NewWith(utils.IntComparator, utils.StringComparator)
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = m.MarshalBinaryWith(utils.IntCodec, utils.StringCodec)
+	restored = NewWith(utils.IntComparator, utils.StringComparator)
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec, utils.StringCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Map *Map
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Map: m})
+	doc := &document{Map: NewWith(utils.IntComparator, utils.StringComparator)}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Map, err)
+}
+
+func TestMapStreaming(t *testing.T) {
+	m := NewWithStringComparators()
+	m.Put("b", "2")
+	m.Put("a", "1")
+
+	assert := func(restored *Map, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(m.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := m.WriteJSON(buffer)
+	restored := NewWithStringComparators()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = m.WriteBinary(buffer)
+	restored = NewWithStringComparators()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
+func TestMapSerializationWithDecoders(t *testing.T) {
+	m := NewWithIntComparators()
+	m.Put(3, 30)
+	m.Put(1, 10)
+	m.Put(2, 20)
+
+	json, err := m.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(json), "[[1,10],[2,20],[3,30]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	m.Clear()
+	err = m.FromJSONWith(json, utils.IntDecoder, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get(2); actualValue != 20 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 20)
+	}
+
+	err = m.FromJSONWith([]byte(`[[4,40],[5]]`), utils.IntDecoder, utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for malformed input")
+	}
+	err = m.FromJSONWith([]byte(`[["x",40]]`), utils.IntDecoder, utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = m.FromJSON(json)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	m.SetDecoders(nil, nil)
+	err = m.FromJSON(json)
+	if err == nil {
+		t.Errorf("Got no error for keys decoded as float64")
+	}
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// object of earlier versions
+	err = m.FromJSONWith([]byte(`{"4":40,"5":50}`), utils.IntDecoder, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := m.Get(5); actualValue != 50 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 50)
+	}
+}
+
 func benchmarkGet(b *testing.B, m *Map, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -528,6 +740,20 @@ func benchmarkRemove(b *testing.B, m *Map, size int) {
 	}
 }
 
+func TestMapConformance(t *testing.T) {
+	containertest.TestBidiMap(t, func() maps.BidiMap { return NewWithIntComparators() })
+	for _, keys := range [][]interface{}{{}, {1}, {1, 2, 3}} {
+		m := NewWithIntComparators()
+		values := []interface{}{}
+		for _, key := range keys {
+			m.Put(key, -key.(int))
+			values = append(values, -key.(int))
+		}
+		it := m.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+	}
+}
+
 func BenchmarkTreeBidiMapGet100(b *testing.B) {
 	b.StopTimer()
 	size := 100
@@ -19,6 +19,8 @@ type Iterator struct {
 }
 
 // Iterator returns a stateful iterator whose elements are key/value pairs.
+// The iterator is fail-fast: Next() and Prev() panic with containers.ErrConcurrentModification
+// if the map has been structurally modified since the iterator moved onto its current element.
 func (m *Map) Iterator() Iterator {
 	return Iterator{iterator: m.tree.Iterator()}
 }
@@ -50,6 +52,20 @@ func (iterator *Iterator) Key() interface{} {
 	return iterator.iterator.Key()
 }
 
+// Remove removes the current element from the map.
+// Afterwards the iterator is positioned between the neighbours of the removed element,
+// i.e. Next() moves to the element after it and Prev() to the element before it.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) Remove() {
+	iterator.iterator.Remove()
+}
+
+// SetValue replaces the current element's value.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) SetValue(value interface{}) {
+	iterator.iterator.SetValue(value)
+}
+
 // Begin resets the iterator to its initial state (one-before-first)
 // Call Next() to fetch the first element if any.
 func (iterator *Iterator) Begin() {
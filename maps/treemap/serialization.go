@@ -4,19 +4,152 @@
 
 package treemap
 
-import "github.com/emirpasic/gods/containers"
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"github.com/emirpasic/gods/containers"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
 
 func assertSerializationImplementation() {
 	var _ containers.JSONSerializer = (*Map)(nil)
 	var _ containers.JSONDeserializer = (*Map)(nil)
+	var _ json.Marshaler = (*Map)(nil)
+	var _ json.Unmarshaler = (*Map)(nil)
+	var _ encoding.BinaryMarshaler = (*Map)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Map)(nil)
+	var _ gob.GobEncoder = (*Map)(nil)
+	var _ gob.GobDecoder = (*Map)(nil)
 }
 
-// ToJSON outputs the JSON representation of list's elements.
+// errNoComparator is returned when elements are decoded into a map that was not instantiated by a constructor,
+// e.g. the zero value of a field being unmarshalled, since the map can not order the keys without a comparator.
+var errNoComparator = errors.New("treemap: can not decode into a map without comparator, instantiate it with NewWith")
+
+// ToJSON outputs the JSON representation of map's elements as an array of [key, value] pairs in key order.
 func (m *Map) ToJSON() ([]byte, error) {
-	return m.tree.ToJSON()
+	return m.orEmpty().tree.ToJSON()
 }
 
-// FromJSON populates list's elements from the input JSON representation.
+// FromJSON populates map's elements from the input JSON representation.
+// Keys and values are decoded with the map's decoders (see SetDecoders and FromJSONWith for other decoders).
 func (m *Map) FromJSON(data []byte) error {
-	return m.tree.FromJSON(data)
+	return m.ReadJSON(bytes.NewReader(data))
+}
+
+// FromJSONWith populates map's elements from the input JSON representation,
+// decoding keys and values with the given decoders (nil for Go's default JSON decoding).
+// Map is not modified if the input can not be decoded.
+func (m *Map) FromJSONWith(data []byte, keyDecoder utils.Decoder, valueDecoder utils.Decoder) error {
+	return m.ReadJSONWith(bytes.NewReader(data), keyDecoder, valueDecoder)
+}
+
+// SetDecoders sets the decoders of keys and values used by FromJSON, ReadJSON and UnmarshalJSON (nil for Go's default JSON decoding),
+// e.g. for unmarshalling a field holding the map with json.Unmarshal.
+// Maps instantiated with NewWithIntComparator or NewWithStringComparator decode keys with the IntDecoder or StringDecoder.
+func (m *Map) SetDecoders(keyDecoder utils.Decoder, valueDecoder utils.Decoder) {
+	m.tree.SetDecoders(keyDecoder, valueDecoder)
+}
+
+// WriteJSON writes the JSON representation of map's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (m *Map) WriteJSON(w io.Writer) error {
+	return m.orEmpty().tree.WriteJSON(w)
+}
+
+// ReadJSON populates map's elements from the JSON representation read from the reader (see FromJSON).
+func (m *Map) ReadJSON(r io.Reader) error {
+	if m.tree == nil {
+		return errNoComparator
+	}
+	return m.tree.ReadJSON(r)
+}
+
+// ReadJSONWith populates map's elements from the JSON representation read from the reader (see FromJSONWith).
+func (m *Map) ReadJSONWith(r io.Reader, keyDecoder utils.Decoder, valueDecoder utils.Decoder) error {
+	if m.tree == nil {
+		return errNoComparator
+	}
+	return m.tree.ReadJSONWith(r, keyDecoder, valueDecoder)
+}
+
+// MarshalJSON outputs the JSON representation of map's elements (implements json.Marshaler).
+func (m *Map) MarshalJSON() ([]byte, error) {
+	return m.ToJSON()
+}
+
+// UnmarshalJSON populates map's elements from the input JSON representation (implements json.Unmarshaler).
+func (m *Map) UnmarshalJSON(data []byte) error {
+	return m.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of map's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (m *Map) MarshalBinary() ([]byte, error) {
+	return m.orEmpty().tree.MarshalBinary()
+}
+
+// MarshalBinaryWith outputs the binary representation of map's elements,
+// encoding keys and values with the given codecs (nil for gob).
+func (m *Map) MarshalBinaryWith(keyCodec utils.Codec, valueCodec utils.Codec) ([]byte, error) {
+	return m.orEmpty().tree.MarshalBinaryWith(keyCodec, valueCodec)
+}
+
+// UnmarshalBinary populates map's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (m *Map) UnmarshalBinary(data []byte) error {
+	return m.UnmarshalBinaryWith(data, nil, nil)
+}
+
+// UnmarshalBinaryWith populates map's elements from the input binary representation,
+// decoding keys and values with the given codecs, which must be the ones the elements were encoded with.
+// Map is not modified if the input can not be decoded.
+func (m *Map) UnmarshalBinaryWith(data []byte, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return m.ReadBinaryWith(bytes.NewReader(data), keyCodec, valueCodec)
+}
+
+// WriteBinary writes the binary representation of map's elements (see MarshalBinary) into the writer.
+func (m *Map) WriteBinary(w io.Writer) error {
+	return m.orEmpty().tree.WriteBinary(w)
+}
+
+// WriteBinaryWith writes the binary representation of map's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (m *Map) WriteBinaryWith(w io.Writer, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return m.orEmpty().tree.WriteBinaryWith(w, keyCodec, valueCodec)
+}
+
+// ReadBinary populates map's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (m *Map) ReadBinary(r io.Reader) error {
+	return m.ReadBinaryWith(r, nil, nil)
+}
+
+// ReadBinaryWith populates map's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (m *Map) ReadBinaryWith(r io.Reader, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	if m.tree == nil {
+		return errNoComparator
+	}
+	return m.tree.ReadBinaryWith(r, keyCodec, valueCodec)
+}
+
+// GobEncode outputs the binary representation of map's elements (implements gob.GobEncoder).
+func (m *Map) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode populates map's elements from the input binary representation (implements gob.GobDecoder).
+func (m *Map) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
+
+// orEmpty returns the map, or an empty map in place of a zero value map (e.g. a field that was never instantiated),
+// so that writing a zero value map outputs no elements.
+func (m *Map) orEmpty() *Map {
+	if m.tree == nil {
+		return &Map{tree: rbt.NewWith(nil)}
+	}
+	return m
 }
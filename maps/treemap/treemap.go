@@ -87,6 +87,11 @@ func (m *Map) Clear() {
 	m.tree.Clear()
 }
 
+// Clone returns a copy of the map with the same structure and comparator (keys and values themselves are not copied).
+func (m *Map) Clone() *Map {
+	return &Map{tree: m.tree.Clone()}
+}
+
 // Min returns the minimum key and its value from the tree map.
 // Returns nil, nil if map is empty.
 func (m *Map) Min() (key interface{}, value interface{}) {
@@ -5,7 +5,14 @@
 package treemap
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/utils"
 	"testing"
 )
 
@@ -440,6 +447,137 @@ func TestMapIteratorLast(t *testing.T) {
 	}
 }
 
+func TestMapIteratorConcurrentModification(t *testing.T) {
+	expectPanic := func(f func() bool) {
+		defer func() {
+			if r := recover(); r != containers.ErrConcurrentModification {
+				t.Errorf("Got %v expected %v", r, containers.ErrConcurrentModification)
+			}
+		}()
+		f()
+	}
+
+	m := NewWithIntComparator()
+	m.Put(1, "a")
+	m.Put(2, "b")
+	m.Put(3, "c")
+	it := m.Iterator()
+	it.Next()
+	m.Put(1, "x") // not a structural modification
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	m.Put(4, "d")
+	expectPanic(it.Next)
+	expectPanic(it.Prev)
+
+	it.Begin()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if actualValue, expectedValue := count, 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	m.Remove(1)
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	m.Remove(5) // not found
+	it.Prev()
+	m.Remove(2)
+	expectPanic(it.Next)
+
+	it.End()
+	m.Clear()
+	if actualValue, expectedValue := it.Prev(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapIteratorRemove(t *testing.T) {
+	m := NewWithIntComparator()
+	for _, key := range []int{11, 5, 17, 2, 8, 14, 20, 1, 3, 6, 9, 12, 15, 18, 4, 7, 10, 13, 16, 19} {
+		m.Put(key, key)
+	}
+	it := m.Iterator()
+	it.Remove() // not positioned on an element
+	for it.Next() {
+		if it.Key().(int)%2 == 0 {
+			it.Remove()
+			it.Remove() // already removed
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys()), "[1 3 5 7 9 11 13 15 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.End(); it.Prev(); {
+		if it.Key().(int)%3 == 0 {
+			it.Remove()
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(m.Keys()), "[1 5 7 11 13 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Size(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.Begin(); it.Next(); {
+		if it.Key() == 11 {
+			break
+		}
+	}
+	it.Remove()
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Next()
+	if actualValue, expectedValue := it.Key(), 13; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.SetValue("x")
+	if actualValue, expectedValue := fmt.Sprint(m.Values()), "[1 5 7 x 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.Begin(); it.Next(); {
+		it.Remove()
+	}
+	if actualValue, expectedValue := m.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Prev(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestMapClone(t *testing.T) {
+	m := NewWithStringComparator()
+	m.Put("b", "2")
+	m.Put("a", "1")
+
+	clone := m.Clone()
+	if actualValue := containers.Equal(clone, m, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), m.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Put("a", "3")
+	if actualValue := containers.Equal(clone, m, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(m.Clone(), m, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestMapSerialization(t *testing.T) {
 	m := NewWithStringComparator()
 	m.Put("a", "1")
@@ -471,6 +609,229 @@ func TestMapSerialization(t *testing.T) {
 	assert()
 }
 
+func TestMapMarshalJSON(t *testing.T) {
+	type document struct {
+		Map *Map `json:"m"`
+	}
+	m := NewWithStringComparator()
+	m.Put("a", "1")
+	m.Put("b", "2")
+
+	data, err := json.Marshal(&document{Map: m})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := m.ToJSON()
+	if actualValue, expectedValue := string(data), `{"m":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Map: NewWithStringComparator()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Map.Size(), m.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Map.Values()), fmt.Sprint(m.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = json.Unmarshal(data, &document{})
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Map).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err = json.Marshal(&document{Map: &Map{}})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"m":[]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	data, err = new(Map).MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := restored.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	intDoc := &document{Map: NewWithIntComparator()}
+	err = json.Unmarshal([]byte(`{"m":[[2,20],[1,10]]}`), intDoc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := intDoc.Map.Get(1); actualValue != 10.0 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 10)
+	}
+	intDoc.Map.SetDecoders(utils.IntDecoder, utils.IntDecoder)
+	err = json.Unmarshal([]byte(`{"m":[[2,20],[1,10]]}`), intDoc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := intDoc.Map.Get(2); actualValue != 20 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 20)
+	}
+}
+
+func TestMapBinarySerialization(t *testing.T) {
+	m := NewWithIntComparator()
+	m.Put(1, "a")
+	m.Put(2, "b")
+
+	assert := func(restored *Map, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), m.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, key := range m.Keys() {
+			if actualValue, expectedValue := restored.Keys()[i], key; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+		for i, value := range m.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := m.MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = m.MarshalBinaryWith(utils.IntCodec, utils.StringCodec)
+	restored = NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec, utils.StringCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Map *Map
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Map: m})
+	doc := &document{Map: NewWithIntComparator()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Map, err)
+}
+
+func TestMapStreaming(t *testing.T) {
+	m := NewWithStringComparator()
+	m.Put("b", "2")
+	m.Put("a", "1")
+
+	assert := func(restored *Map, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(m.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := m.WriteJSON(buffer)
+	restored := NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = m.WriteBinary(buffer)
+	restored = NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
+func TestMapSerializationWithDecoders(t *testing.T) {
+	m := NewWithIntComparator()
+	m.Put(3, 30)
+	m.Put(1, 10)
+	m.Put(2, 20)
+
+	json, err := m.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(json), "[[1,10],[2,20],[3,30]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	m.Clear()
+	err = m.FromJSONWith(json, utils.IntDecoder, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := m.Get(2); actualValue != 20 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 20)
+	}
+
+	err = m.FromJSONWith([]byte(`[[4,40],[5]]`), utils.IntDecoder, utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for malformed input")
+	}
+	err = m.FromJSONWith([]byte(`[["x",40]]`), utils.IntDecoder, utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = m.FromJSON(json)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	m.SetDecoders(nil, nil)
+	err = m.FromJSON(json)
+	if err == nil {
+		t.Errorf("Got no error for keys decoded as float64")
+	}
+	if actualValue, expectedValue := m.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// object of earlier versions
+	err = m.FromJSONWith([]byte(`{"4":40,"5":50}`), utils.IntDecoder, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := m.Get(5); actualValue != 50 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 50)
+	}
+}
+
 func benchmarkGet(b *testing.B, m *Map, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -495,6 +856,20 @@ func benchmarkRemove(b *testing.B, m *Map, size int) {
 	}
 }
 
+func TestMapConformance(t *testing.T) {
+	containertest.TestMap(t, func() maps.Map { return NewWithIntComparator() })
+	for _, keys := range [][]interface{}{{}, {1}, {1, 2, 3}} {
+		m := NewWithIntComparator()
+		values := []interface{}{}
+		for _, key := range keys {
+			m.Put(key, -key.(int))
+			values = append(values, -key.(int))
+		}
+		it := m.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+	}
+}
+
 func BenchmarkTreeMapGet100(b *testing.B) {
 	b.StopTimer()
 	size := 100
@@ -0,0 +1,153 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hashmultiset implements a multiset (bag) backed by a hash table.
+//
+// A multiset is a set that allows repeated elements, keeping track of the number of occurrences (count) of each element.
+//
+// Elements are unordered in the multiset.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/Multiset
+package hashmultiset
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/sets"
+	"github.com/emirpasic/gods/sets/hashset"
+	"strings"
+)
+
+func assertMultisetImplementation() {
+	var _ sets.Multiset = (*Set)(nil)
+}
+
+// Set holds elements and their counts in go's native map
+type Set struct {
+	items map[interface{}]int
+	size  int
+}
+
+// New instantiates a new empty multiset
+func New() *Set {
+	return &Set{items: make(map[interface{}]int)}
+}
+
+// Add adds n occurrences of the element to the multiset.
+// Does nothing if n is not positive.
+func (set *Set) Add(element interface{}, n int) {
+	if n <= 0 {
+		return
+	}
+	set.items[element] += n
+	set.size += n
+}
+
+// Remove removes up to n occurrences of the element from the multiset.
+// The element is removed altogether once its count drops to zero.
+// Does nothing if n is not positive.
+func (set *Set) Remove(element interface{}, n int) {
+	if n <= 0 {
+		return
+	}
+	count, found := set.items[element]
+	if !found {
+		return
+	}
+	if n >= count {
+		delete(set.items, element)
+		set.size -= count
+		return
+	}
+	set.items[element] = count - n
+	set.size -= n
+}
+
+// Count returns the number of occurrences of the element in the multiset (zero if not present).
+func (set *Set) Count(element interface{}) int {
+	return set.items[element]
+}
+
+// SetCount sets the number of occurrences of the element in the multiset.
+// The element is removed if count is not positive.
+func (set *Set) SetCount(element interface{}, count int) {
+	set.size -= set.items[element]
+	if count <= 0 {
+		delete(set.items, element)
+		return
+	}
+	set.items[element] = count
+	set.size += count
+}
+
+// Contains checks if elements (one or more) are present in the multiset at least once.
+// All elements have to be present in the multiset for the method to return true.
+// Returns true if no arguments are passed at all, i.e. multiset is always superset of empty set.
+func (set *Set) Contains(elements ...interface{}) bool {
+	for _, element := range elements {
+		if _, contains := set.items[element]; !contains {
+			return false
+		}
+	}
+	return true
+}
+
+// ElementSet returns a set of the distinct elements in the multiset.
+func (set *Set) ElementSet() *hashset.Set {
+	elementSet := hashset.New()
+	for element := range set.items {
+		elementSet.Add(element)
+	}
+	return elementSet
+}
+
+// Empty returns true if multiset does not contain any elements.
+func (set *Set) Empty() bool {
+	return set.Size() == 0
+}
+
+// Size returns the total number of elements within the multiset, counting all occurrences.
+func (set *Set) Size() int {
+	return set.size
+}
+
+// Clear clears all values in the multiset.
+func (set *Set) Clear() {
+	set.items = make(map[interface{}]int)
+	set.size = 0
+}
+
+// Clone returns a shallow copy of the multiset (elements themselves are not copied).
+func (set *Set) Clone() *Set {
+	items := make(map[interface{}]int, len(set.items))
+	for element, count := range set.items {
+		items[element] = count
+	}
+	return &Set{items: items, size: set.size}
+}
+
+// Values returns all elements in the multiset, each repeated as many times as it occurs (random order).
+func (set *Set) Values() []interface{} {
+	values := make([]interface{}, set.Size())
+	index := 0
+	for element, count := range set.items {
+		for i := 0; i < count; i++ {
+			values[index] = element
+			index++
+		}
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (set *Set) String() string {
+	str := "HashMultiset\n"
+	items := []string{}
+	for element, count := range set.items {
+		items = append(items, fmt.Sprintf("%v:%v", element, count))
+	}
+	str += strings.Join(items, ", ")
+	return str
+}
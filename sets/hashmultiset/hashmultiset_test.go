@@ -0,0 +1,338 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hashmultiset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"testing"
+)
+
+func TestSetAdd(t *testing.T) {
+	set := New()
+	set.Add(1, 1)
+	set.Add(2, 2)
+	set.Add(2, 3)
+	set.Add(3, 0)
+	set.Add(3, -1)
+	if actualValue := set.Empty(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := set.Size(); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	if actualValue := set.Count(1); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue := set.Count(2); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := set.Count(3); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := len(set.Values()); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+}
+
+func TestSetContains(t *testing.T) {
+	set := New()
+	set.Add(3, 1)
+	set.Add(1, 2)
+	set.Add(2, 3)
+	if actualValue := set.Contains(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := set.Contains(1); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := set.Contains(1, 2, 3); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := set.Contains(1, 2, 3, 4); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestSetRemove(t *testing.T) {
+	set := New()
+	set.Add(1, 3)
+	set.Add(2, 2)
+	set.Remove(1, 0)
+	set.Remove(3, 1)
+	if actualValue := set.Size(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	set.Remove(1, 2)
+	if actualValue := set.Count(1); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue := set.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	set.Remove(1, 10)
+	if actualValue := set.Contains(1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	set.Remove(2, 2)
+	if actualValue := set.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := set.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestSetSetCount(t *testing.T) {
+	set := New()
+	set.Add("a", 2)
+	set.SetCount("a", 5)
+	set.SetCount("b", 3)
+	if actualValue := set.Count("a"); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := set.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	set.SetCount("a", 0)
+	if actualValue := set.Contains("a"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := set.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func TestSetElementSet(t *testing.T) {
+	set := New()
+	set.Add("a", 2)
+	set.Add("b", 1)
+	set.Add("c", 3)
+	elementSet := set.ElementSet()
+	if actualValue := elementSet.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue := elementSet.Contains("a", "b", "c"); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestSetClone(t *testing.T) {
+	set := New()
+	set.Add("a", 2)
+	set.Add("b", 1)
+
+	clone := set.Clone()
+	if actualValue := containers.Equal(clone, set, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	clone.Add("b", 1)
+	if actualValue := containers.Equal(clone, set, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(set.Clone(), set, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestSetSerialization(t *testing.T) {
+	set := New()
+	set.Add("a", 1)
+	set.Add("b", 2)
+	set.Add("c", 3)
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := set.Size(), 6; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := set.Count("c"), 3; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue := set.Contains("a", "b", "c"); actualValue != true {
+			t.Errorf("Got %v expected %v", actualValue, true)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := set.ToJSON()
+	assert()
+
+	err = set.FromJSON(json)
+	assert()
+}
+
+func TestSetMarshalJSON(t *testing.T) {
+	type document struct {
+		Set *Set `json:"set"`
+	}
+	set := New()
+	set.Add("a", 2)
+
+	data, err := json.Marshal(&document{Set: set})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := set.ToJSON()
+	if actualValue, expectedValue := string(data), `{"set":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Set: New()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Set.Size(), set.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(containers.GetSortedValues(doc.Set, utils.StringComparator)), fmt.Sprint(containers.GetSortedValues(set, utils.StringComparator)); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetBinarySerialization(t *testing.T) {
+	set := New()
+	set.Add(1, 2)
+
+	assert := func(restored *Set, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), set.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, value := range containers.GetSortedValues(set, utils.IntComparator) {
+			if actualValue, expectedValue := containers.GetSortedValues(restored, utils.IntComparator)[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := set.MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = set.MarshalBinaryWith(utils.IntCodec)
+	restored = New()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Set *Set
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Set: set})
+	doc := &document{Set: New()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Set, err)
+}
+
+func TestSetStreaming(t *testing.T) {
+	set := New()
+	set.Add("a", 2)
+	set.Add("b", 1)
+
+	assert := func(restored *Set, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(containers.GetSortedValues(restored, utils.StringComparator)), fmt.Sprint(containers.GetSortedValues(set, utils.StringComparator)); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := set.WriteJSON(buffer)
+	restored := New()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = set.WriteBinary(buffer)
+	restored = New()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
+func benchmarkAdd(b *testing.B, set *Set, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			set.Add(n, 1)
+		}
+	}
+}
+
+func benchmarkCount(b *testing.B, set *Set, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			set.Count(n)
+		}
+	}
+}
+
+func BenchmarkHashMultisetAdd100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	set := New()
+	b.StartTimer()
+	benchmarkAdd(b, set, size)
+}
+
+func BenchmarkHashMultisetAdd10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	set := New()
+	b.StartTimer()
+	benchmarkAdd(b, set, size)
+}
+
+func BenchmarkHashMultisetCount100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	set := New()
+	for n := 0; n < size; n++ {
+		set.Add(n, 1)
+	}
+	b.StartTimer()
+	benchmarkCount(b, set, size)
+}
+
+func BenchmarkHashMultisetCount10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	set := New()
+	for n := 0; n < size; n++ {
+		set.Add(n, 1)
+	}
+	b.StartTimer()
+	benchmarkCount(b, set, size)
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hashmultiset
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
+
+func assertSerializationImplementation() {
+	var _ containers.JSONSerializer = (*Set)(nil)
+	var _ containers.JSONDeserializer = (*Set)(nil)
+	var _ json.Marshaler = (*Set)(nil)
+	var _ json.Unmarshaler = (*Set)(nil)
+	var _ encoding.BinaryMarshaler = (*Set)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Set)(nil)
+	var _ gob.GobEncoder = (*Set)(nil)
+	var _ gob.GobDecoder = (*Set)(nil)
+}
+
+// ToJSON outputs the JSON representation of multiset's elements.
+func (set *Set) ToJSON() ([]byte, error) {
+	return json.Marshal(set.Values())
+}
+
+// FromJSON populates multiset's elements from the input JSON representation.
+func (set *Set) FromJSON(data []byte) error {
+	elements := []interface{}{}
+	err := json.Unmarshal(data, &elements)
+	if err == nil {
+		set.Clear()
+		for _, element := range elements {
+			set.Add(element, 1)
+		}
+	}
+	return err
+}
+
+// WriteJSON writes the JSON representation of multiset's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (set *Set) WriteJSON(w io.Writer) error {
+	elements := make([]interface{}, 0, len(set.items))
+	for element := range set.items {
+		elements = append(elements, element)
+	}
+	i, n := -1, 0
+	return utils.WriteJSONValues(w, func() (interface{}, bool) {
+		for n == 0 {
+			if i++; i == len(elements) {
+				return nil, false
+			}
+			n = set.items[elements[i]]
+		}
+		n--
+		return elements[i], true
+	})
+}
+
+// ReadJSON populates multiset's elements from the JSON representation read from the reader (see FromJSON).
+// Multiset is not modified if the input can not be decoded.
+func (set *Set) ReadJSON(r io.Reader) error {
+	loaded := New()
+	if err := utils.ReadJSONValues(r, nil, func(element interface{}) error {
+		loaded.Add(element, 1)
+		return nil
+	}); err != nil {
+		return err
+	}
+	set.items, set.size = loaded.items, loaded.size
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of multiset's elements (implements json.Marshaler).
+func (set *Set) MarshalJSON() ([]byte, error) {
+	return set.ToJSON()
+}
+
+// UnmarshalJSON populates multiset's elements from the input JSON representation (implements json.Unmarshaler).
+func (set *Set) UnmarshalJSON(data []byte) error {
+	return set.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of multiset's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (set *Set) MarshalBinary() ([]byte, error) {
+	return set.MarshalBinaryWith(nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of multiset's elements,
+// encoding elements with the given codec (nil for gob).
+func (set *Set) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := set.WriteBinaryWith(buffer, codec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates multiset's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (set *Set) UnmarshalBinary(data []byte) error {
+	return set.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates multiset's elements from the input binary representation,
+// decoding elements with the given codec, which must be the one they were encoded with.
+// Multiset is not modified if the input can not be decoded.
+func (set *Set) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return set.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of multiset's elements (see MarshalBinary) into the writer.
+func (set *Set) WriteBinary(w io.Writer) error {
+	return set.WriteBinaryWith(w, nil)
+}
+
+// WriteBinaryWith writes the binary representation of multiset's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (set *Set) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	elements := make([]interface{}, 0, len(set.items))
+	for element := range set.items {
+		elements = append(elements, element)
+	}
+	i := -1
+	return utils.WriteBinaryPairs(w, len(elements), func() (interface{}, interface{}) {
+		i++
+		return elements[i], set.items[elements[i]]
+	}, codec, utils.IntCodec)
+}
+
+// ReadBinary populates multiset's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (set *Set) ReadBinary(r io.Reader) error {
+	return set.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates multiset's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (set *Set) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	loaded := New()
+	if err := utils.ReadBinaryPairs(r, codec, utils.IntCodec, func(element interface{}, count interface{}) error {
+		loaded.Add(element, count.(int))
+		return nil
+	}); err != nil {
+		return err
+	}
+	set.items, set.size = loaded.items, loaded.size
+	return nil
+}
+
+// GobEncode outputs the binary representation of multiset's elements (implements gob.GobEncoder).
+func (set *Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode populates multiset's elements from the input binary representation (implements gob.GobDecoder).
+func (set *Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
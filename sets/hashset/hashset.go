@@ -4,6 +4,8 @@
 
 // Package hashset implements a set backed by a hash table.
 //
+// Elements are stored in a hashmap, hence a custom hasher and equaler can be used for elements that are not comparable (see NewWith).
+//
 // Structure is not thread safe.
 //
 // References: http://en.wikipedia.org/wiki/Set_%28abstract_data_type%29
@@ -11,7 +13,10 @@ package hashset
 
 import (
 	"fmt"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/maps/hashmap"
 	"github.com/emirpasic/gods/sets"
+	"github.com/emirpasic/gods/utils"
 	"strings"
 )
 
@@ -19,29 +24,43 @@ func assertSetImplementation() {
 	var _ sets.Set = (*Set)(nil)
 }
 
-// Set holds elements in go's native map
+// Set holds elements as keys of a hash map
 type Set struct {
-	items map[interface{}]struct{}
+	items   itemMap
+	hasher  utils.Hasher  // nil if elements are hashed by go's native map
+	equaler utils.Equaler // nil if elements are hashed by go's native map
+}
+
+// itemMap is the hash map holding the elements, a *hashmap.Map or a *hashmap.CustomMap if elements are hashed with a custom hasher
+type itemMap interface {
+	maps.Map
+	Iterator() hashmap.Iterator
 }
 
 var itemExists = struct{}{}
 
 // New instantiates a new empty set
 func New() *Set {
-	return &Set{items: make(map[interface{}]struct{})}
+	return &Set{items: hashmap.New()}
+}
+
+// NewWith instantiates a new empty set with the custom hasher and equaler.
+// Elements need not be comparable, but equal elements (with respect to the equaler) must have equal hashes.
+func NewWith(hasher utils.Hasher, equaler utils.Equaler) *Set {
+	return &Set{items: hashmap.NewWith(hasher, equaler), hasher: hasher, equaler: equaler}
 }
 
 // Add adds the items (one or more) to the set.
 func (set *Set) Add(items ...interface{}) {
 	for _, item := range items {
-		set.items[item] = itemExists
+		set.items.Put(item, itemExists)
 	}
 }
 
 // Remove removes the items (one or more) from the set.
 func (set *Set) Remove(items ...interface{}) {
 	for _, item := range items {
-		delete(set.items, item)
+		set.items.Remove(item)
 	}
 }
 
@@ -50,7 +69,7 @@ func (set *Set) Remove(items ...interface{}) {
 // Returns true if no arguments are passed at all, i.e. set is always superset of empty set.
 func (set *Set) Contains(items ...interface{}) bool {
 	for _, item := range items {
-		if _, contains := set.items[item]; !contains {
+		if _, contains := set.items.Get(item); !contains {
 			return false
 		}
 	}
@@ -64,30 +83,42 @@ func (set *Set) Empty() bool {
 
 // Size returns number of elements within the set.
 func (set *Set) Size() int {
-	return len(set.items)
+	return set.items.Size()
 }
 
 // Clear clears all values in the set.
 func (set *Set) Clear() {
-	set.items = make(map[interface{}]struct{})
+	set.items.Clear()
+}
+
+// Clone returns a shallow copy of the set with the same hasher and equaler (elements themselves are not copied).
+func (set *Set) Clone() *Set {
+	clone := set.newEmpty()
+	it := set.items.Iterator()
+	for it.Next() {
+		clone.items.Put(it.Key(), itemExists)
+	}
+	return clone
 }
 
 // Values returns all items in the set.
 func (set *Set) Values() []interface{} {
-	values := make([]interface{}, set.Size())
-	count := 0
-	for item := range set.items {
-		values[count] = item
-		count++
+	return set.items.Keys()
+}
+
+// newEmpty instantiates an empty set that hashes elements the same way as this set.
+func (set *Set) newEmpty() *Set {
+	if set.hasher != nil {
+		return NewWith(set.hasher, set.equaler)
 	}
-	return values
+	return New()
 }
 
 // String returns a string representation of container
 func (set *Set) String() string {
 	str := "HashSet\n"
 	items := []string{}
-	for k := range set.items {
+	for _, k := range set.items.Keys() {
 		items = append(items, fmt.Sprintf("%v", k))
 	}
 	str += strings.Join(items, ", ")
@@ -5,6 +5,14 @@
 package hashset
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/sets"
+	"github.com/emirpasic/gods/utils"
 	"testing"
 )
 
@@ -62,6 +70,148 @@ func TestSetRemove(t *testing.T) {
 	}
 }
 
+func TestSetNewWith(t *testing.T) {
+	set := NewWith(utils.CaseInsensitiveStringHasher, utils.CaseInsensitiveStringEqualer)
+	set.Add("a", "B", "c")
+	set.Add("A", "b")
+	if actualValue := set.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue := set.Contains("A", "b", "C"); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	set.Remove("C")
+	if actualValue := set.Contains("c"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := set.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+}
+
+func TestSetEach(t *testing.T) {
+	set := New()
+	set.Add("c", "a", "b")
+	values := []interface{}{}
+	set.Each(func(index int, value interface{}) {
+		if actualValue, expectedValue := index, len(values); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		values = append(values, value)
+	})
+	if actualValue, expectedValue := len(values), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetMap(t *testing.T) {
+	set := New()
+	set.Add("c", "a", "b")
+	mappedSet := set.Map(func(index int, value interface{}) interface{} {
+		return "mapped: " + value.(string)
+	})
+	if actualValue, expectedValue := mappedSet.Contains("mapped: a", "mapped: b", "mapped: c"), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := mappedSet.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetMapWithHasher(t *testing.T) {
+	set := NewWith(utils.BytesHasher, utils.BytesEqualer)
+	set.Add([]byte("a"), []byte("bc"))
+	mappedSet := set.Map(func(index int, value interface{}) interface{} {
+		return string(value.([]byte))
+	})
+	if actualValue, expectedValue := mappedSet.Contains("a", "bc"), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetSelect(t *testing.T) {
+	set := NewWith(utils.CaseInsensitiveStringHasher, utils.CaseInsensitiveStringEqualer)
+	set.Add("c", "a", "b")
+	selectedSet := set.Select(func(index int, value interface{}) bool {
+		return value.(string) >= "a" && value.(string) <= "b"
+	})
+	if actualValue, expectedValue := selectedSet.Contains("A", "B"), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := selectedSet.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetAnyAllFind(t *testing.T) {
+	set := New()
+	set.Add("c", "a", "b")
+	if actualValue := set.Any(func(index int, value interface{}) bool { return value.(string) == "c" }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := set.All(func(index int, value interface{}) bool { return value.(string) >= "b" }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	index, value := set.Find(func(index int, value interface{}) bool { return value.(string) == "b" })
+	if value != "b" || index < 0 || index > 2 {
+		t.Errorf("Got %v,%v expected %v", index, value, "b")
+	}
+	if index, value := set.Find(func(index int, value interface{}) bool { return value.(string) == "x" }); index != -1 || value != nil {
+		t.Errorf("Got %v,%v expected %v,%v", index, value, -1, nil)
+	}
+}
+
+func TestSetIteratorNextPrev(t *testing.T) {
+	set := New()
+	set.Add("c", "a", "b")
+	it := set.Iterator()
+	values := []interface{}{}
+	for it.Next() {
+		if actualValue, expectedValue := it.Index(), len(values); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		values = append(values, it.Value())
+	}
+	if actualValue, expectedValue := len(values), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		if actualValue, expectedValue := it.Value(), values[it.Index()]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := it.Index(), -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Index(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetClone(t *testing.T) {
+	set := New()
+	set.Add("c", "a", "b")
+
+	clone := set.Clone()
+	if actualValue := containers.Equal(clone, set, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), set.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Add("x")
+	if actualValue := containers.Equal(clone, set, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(set.Clone(), set, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestSetSerialization(t *testing.T) {
 	set := New()
 	set.Add("a", "b", "c")
@@ -88,6 +238,144 @@ func TestSetSerialization(t *testing.T) {
 	assert()
 }
 
+func TestSetMarshalJSON(t *testing.T) {
+	type document struct {
+		Set *Set `json:"set"`
+	}
+	set := New()
+	set.Add("a", "b", "c")
+
+	data, err := json.Marshal(&document{Set: set})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := set.ToJSON()
+	if actualValue, expectedValue := string(data), `{"set":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Set: New()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Set.Size(), set.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(containers.GetSortedValues(doc.Set, utils.StringComparator)), fmt.Sprint(containers.GetSortedValues(set, utils.StringComparator)); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	zeroDoc := &document{}
+	err = json.Unmarshal(data, zeroDoc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	zeroDoc.Set.Add("d")
+	if actualValue, expectedValue := zeroDoc.Set.Size(), set.Size()+1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err = json.Marshal(&document{Set: &Set{}})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"set":[]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	data, err = new(Set).MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := restored.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetBinarySerialization(t *testing.T) {
+	set := New()
+	set.Add(3, 1, 2)
+
+	assert := func(restored *Set, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), set.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, value := range set.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := set.MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = set.MarshalBinaryWith(utils.IntCodec)
+	restored = New()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Set *Set
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Set: set})
+	doc := &document{Set: New()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Set, err)
+}
+
+func TestSetStreaming(t *testing.T) {
+	set := New()
+	set.Add("c", "a", "b")
+
+	assert := func(restored *Set, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(set.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := set.WriteJSON(buffer)
+	restored := New()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = set.WriteBinary(buffer)
+	restored = New()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
 func benchmarkContains(b *testing.B, set *Set, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -112,6 +400,16 @@ func benchmarkRemove(b *testing.B, set *Set, size int) {
 	}
 }
 
+func TestSetConformance(t *testing.T) {
+	containertest.TestSet(t, func() sets.Set { return New() })
+	for _, values := range [][]interface{}{{}, {1}, {3, 1, 2}} {
+		set := New()
+		set.Add(values...)
+		it := set.Iterator()
+		containertest.TestReverseIteratorWithIndex(t, &it, set.Values())
+	}
+}
+
 func BenchmarkHashSetContains100(b *testing.B) {
 	b.StopTimer()
 	size := 100
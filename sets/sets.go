@@ -6,6 +6,8 @@
 //
 // In computer science, a set is an abstract data type that can store certain values and no repeated values. It is a computer implementation of the mathematical concept of a finite set. Unlike most other collection types, rather than retrieving a specific element from a set, one typically tests a value for membership in a set.
 //
+// A multiset (or bag) is a generalization of a set that allows multiple instances of its elements, keeping track of the number of occurrences (count) of each element.
+//
 // Reference: https://en.wikipedia.org/wiki/Set_%28abstract_data_type%29
 package sets
 
@@ -23,3 +25,17 @@ type Set interface {
 	// Clear()
 	// Values() []interface{}
 }
+
+// Multiset interface that all multisets (bags) implement
+type Multiset interface {
+	Add(element interface{}, n int)
+	Remove(element interface{}, n int)
+	Count(element interface{}) int
+	SetCount(element interface{}, count int)
+
+	containers.Container
+	// Empty() bool
+	// Size() int
+	// Clear()
+	// Values() []interface{}
+}
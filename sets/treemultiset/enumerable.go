@@ -0,0 +1,84 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package treemultiset
+
+import (
+	"github.com/emirpasic/gods/containers"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+)
+
+func assertEnumerableImplementation() {
+	var _ containers.EnumerableWithKey = (*Set)(nil)
+}
+
+// Each calls the given function once for each distinct element, passing that element and its count.
+func (set *Set) Each(f func(element interface{}, count interface{})) {
+	iterator := set.Iterator()
+	for iterator.Next() {
+		f(iterator.Key(), iterator.Value())
+	}
+}
+
+// Map invokes the given function once for each distinct element and returns a container
+// containing the elements and counts returned by the given function.
+// Counts of elements mapped to the same element are summed up, count should be of type int.
+func (set *Set) Map(f func(element1 interface{}, count1 interface{}) (interface{}, interface{})) *Set {
+	newSet := &Set{tree: rbt.NewWith(set.tree.Comparator)}
+	iterator := set.Iterator()
+	for iterator.Next() {
+		element2, count2 := f(iterator.Key(), iterator.Value())
+		newSet.Add(element2, count2.(int))
+	}
+	return newSet
+}
+
+// Select returns a new container containing all elements for which the given function returns a true value.
+func (set *Set) Select(f func(element interface{}, count interface{}) bool) *Set {
+	newSet := &Set{tree: rbt.NewWith(set.tree.Comparator)}
+	iterator := set.Iterator()
+	for iterator.Next() {
+		if f(iterator.Key(), iterator.Value()) {
+			newSet.Add(iterator.Key(), iterator.Value().(int))
+		}
+	}
+	return newSet
+}
+
+// Any passes each distinct element of the container to the given function and
+// returns true if the function ever returns true for any element.
+func (set *Set) Any(f func(element interface{}, count interface{}) bool) bool {
+	iterator := set.Iterator()
+	for iterator.Next() {
+		if f(iterator.Key(), iterator.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+// All passes each distinct element of the container to the given function and
+// returns true if the function returns true for all elements.
+func (set *Set) All(f func(element interface{}, count interface{}) bool) bool {
+	iterator := set.Iterator()
+	for iterator.Next() {
+		if !f(iterator.Key(), iterator.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find passes each distinct element of the container to the given function and returns
+// the first (element,count) for which the function is true or nil,nil otherwise if no element
+// matches the criteria.
+func (set *Set) Find(f func(element interface{}, count interface{}) bool) (interface{}, interface{}) {
+	iterator := set.Iterator()
+	for iterator.Next() {
+		if f(iterator.Key(), iterator.Value()) {
+			return iterator.Key(), iterator.Value()
+		}
+	}
+	return nil, nil
+}
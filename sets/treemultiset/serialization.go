@@ -0,0 +1,198 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package treemultiset
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"github.com/emirpasic/gods/containers"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
+
+func assertSerializationImplementation() {
+	var _ containers.JSONSerializer = (*Set)(nil)
+	var _ containers.JSONDeserializer = (*Set)(nil)
+	var _ json.Marshaler = (*Set)(nil)
+	var _ json.Unmarshaler = (*Set)(nil)
+	var _ encoding.BinaryMarshaler = (*Set)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Set)(nil)
+	var _ gob.GobEncoder = (*Set)(nil)
+	var _ gob.GobDecoder = (*Set)(nil)
+}
+
+// errNoComparator is returned when elements are decoded into a multiset that was not instantiated by a constructor,
+// e.g. the zero value of a field being unmarshalled, since the multiset can not order the elements without a comparator.
+var errNoComparator = errors.New("treemultiset: can not decode into a multiset without comparator, instantiate it with NewWith")
+
+// ToJSON outputs the JSON representation of multiset's elements.
+func (set *Set) ToJSON() ([]byte, error) {
+	set = set.orEmpty()
+	return json.Marshal(set.Values())
+}
+
+// FromJSON populates multiset's elements from the input JSON representation.
+func (set *Set) FromJSON(data []byte) error {
+	if set.tree == nil {
+		return errNoComparator
+	}
+	elements := []interface{}{}
+	err := json.Unmarshal(data, &elements)
+	if err != nil {
+		return err
+	}
+	loaded := NewWith(set.tree.Comparator)
+	for _, element := range elements {
+		if err := loaded.addChecked(element, 1); err != nil {
+			return err
+		}
+	}
+	set.tree, set.size = loaded.tree, loaded.size
+	return nil
+}
+
+// WriteJSON writes the JSON representation of multiset's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (set *Set) WriteJSON(w io.Writer) error {
+	set = set.orEmpty()
+	it := set.tree.Iterator()
+	n := 0
+	return utils.WriteJSONValues(w, func() (interface{}, bool) {
+		for n == 0 {
+			if !it.Next() {
+				return nil, false
+			}
+			n = it.Value().(int)
+		}
+		n--
+		return it.Key(), true
+	})
+}
+
+// ReadJSON populates multiset's elements from the JSON representation read from the reader (see FromJSON).
+// Multiset is not modified if the input can not be decoded.
+func (set *Set) ReadJSON(r io.Reader) error {
+	if set.tree == nil {
+		return errNoComparator
+	}
+	loaded := NewWith(set.tree.Comparator)
+	if err := utils.ReadJSONValues(r, nil, func(element interface{}) error {
+		return loaded.addChecked(element, 1)
+	}); err != nil {
+		return err
+	}
+	set.tree, set.size = loaded.tree, loaded.size
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of multiset's elements (implements json.Marshaler).
+func (set *Set) MarshalJSON() ([]byte, error) {
+	return set.ToJSON()
+}
+
+// UnmarshalJSON populates multiset's elements from the input JSON representation (implements json.Unmarshaler).
+func (set *Set) UnmarshalJSON(data []byte) error {
+	return set.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of multiset's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (set *Set) MarshalBinary() ([]byte, error) {
+	return set.MarshalBinaryWith(nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of multiset's elements,
+// encoding elements with the given codec (nil for gob).
+func (set *Set) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := set.WriteBinaryWith(buffer, codec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates multiset's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (set *Set) UnmarshalBinary(data []byte) error {
+	return set.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates multiset's elements from the input binary representation,
+// decoding elements with the given codec, which must be the one they were encoded with.
+// Multiset is not modified if the input can not be decoded.
+func (set *Set) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return set.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of multiset's elements (see MarshalBinary) into the writer.
+func (set *Set) WriteBinary(w io.Writer) error {
+	return set.WriteBinaryWith(w, nil)
+}
+
+// WriteBinaryWith writes the binary representation of multiset's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (set *Set) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	set = set.orEmpty()
+	it := set.tree.Iterator()
+	return utils.WriteBinaryPairs(w, set.tree.Size(), func() (interface{}, interface{}) {
+		it.Next()
+		return it.Key(), it.Value()
+	}, codec, utils.IntCodec)
+}
+
+// ReadBinary populates multiset's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (set *Set) ReadBinary(r io.Reader) error {
+	return set.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates multiset's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (set *Set) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	if set.tree == nil {
+		return errNoComparator
+	}
+	loaded := NewWith(set.tree.Comparator)
+	if err := utils.ReadBinaryPairs(r, codec, utils.IntCodec, func(element interface{}, count interface{}) error {
+		return loaded.addChecked(element, count.(int))
+	}); err != nil {
+		return err
+	}
+	set.tree, set.size = loaded.tree, loaded.size
+	return nil
+}
+
+// GobEncode outputs the binary representation of multiset's elements (implements gob.GobEncoder).
+func (set *Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode populates multiset's elements from the input binary representation (implements gob.GobDecoder).
+func (set *Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+// orEmpty returns the multiset, or an empty multiset in place of a zero value multiset (e.g. a field that was never instantiated),
+// so that writing a zero value multiset outputs no elements.
+func (set *Set) orEmpty() *Set {
+	if set.tree == nil {
+		return &Set{tree: rbt.NewWith(nil)}
+	}
+	return set
+}
+
+// addChecked adds n occurrences of the element like Add, but returns an error instead of panicking
+// if the comparator can not compare the element with the last element of the multiset.
+func (set *Set) addChecked(element interface{}, n int) error {
+	last := element
+	if right := set.tree.Right(); right != nil {
+		last = right.Key
+	}
+	if _, err := utils.CompareKeys(set.tree.Comparator, last, element); err != nil {
+		return err
+	}
+	set.Add(element, n)
+	return nil
+}
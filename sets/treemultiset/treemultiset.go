@@ -0,0 +1,168 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package treemultiset implements a multiset (bag) backed by a red-black tree.
+//
+// A multiset is a set that allows repeated elements, keeping track of the number of occurrences (count) of each element.
+//
+// Elements are ordered with respect to the comparator in the multiset.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/Multiset
+package treemultiset
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/sets"
+	"github.com/emirpasic/gods/sets/treeset"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/emirpasic/gods/utils"
+	"strings"
+)
+
+func assertMultisetImplementation() {
+	var _ sets.Multiset = (*Set)(nil)
+}
+
+// Set holds elements as keys and their counts as values in a red-black tree
+type Set struct {
+	tree *rbt.Tree
+	size int
+}
+
+// NewWith instantiates a new empty multiset with the custom comparator.
+func NewWith(comparator utils.Comparator) *Set {
+	return &Set{tree: rbt.NewWith(comparator)}
+}
+
+// NewWithIntComparator instantiates a new empty multiset with the IntComparator, i.e. elements are of type int.
+func NewWithIntComparator() *Set {
+	return &Set{tree: rbt.NewWithIntComparator()}
+}
+
+// NewWithStringComparator instantiates a new empty multiset with the StringComparator, i.e. elements are of type string.
+func NewWithStringComparator() *Set {
+	return &Set{tree: rbt.NewWithStringComparator()}
+}
+
+// Add adds n occurrences of the element to the multiset.
+// Does nothing if n is not positive.
+// Element should adhere to the comparator's type assertion, otherwise method panics.
+func (set *Set) Add(element interface{}, n int) {
+	if n <= 0 {
+		return
+	}
+	set.tree.Put(element, set.Count(element)+n)
+	set.size += n
+}
+
+// Remove removes up to n occurrences of the element from the multiset.
+// The element is removed altogether once its count drops to zero.
+// Does nothing if n is not positive.
+// Element should adhere to the comparator's type assertion, otherwise method panics.
+func (set *Set) Remove(element interface{}, n int) {
+	if n <= 0 {
+		return
+	}
+	count := set.Count(element)
+	if count == 0 {
+		return
+	}
+	if n >= count {
+		set.tree.Remove(element)
+		set.size -= count
+		return
+	}
+	set.tree.Put(element, count-n)
+	set.size -= n
+}
+
+// Count returns the number of occurrences of the element in the multiset (zero if not present).
+// Element should adhere to the comparator's type assertion, otherwise method panics.
+func (set *Set) Count(element interface{}) int {
+	if count, found := set.tree.Get(element); found {
+		return count.(int)
+	}
+	return 0
+}
+
+// SetCount sets the number of occurrences of the element in the multiset.
+// The element is removed if count is not positive.
+// Element should adhere to the comparator's type assertion, otherwise method panics.
+func (set *Set) SetCount(element interface{}, count int) {
+	set.size -= set.Count(element)
+	if count <= 0 {
+		set.tree.Remove(element)
+		return
+	}
+	set.tree.Put(element, count)
+	set.size += count
+}
+
+// Contains checks weather elements (one or more) are present in the multiset at least once.
+// All elements have to be present in the multiset for the method to return true.
+// Returns true if no arguments are passed at all, i.e. multiset is always superset of empty set.
+func (set *Set) Contains(elements ...interface{}) bool {
+	for _, element := range elements {
+		if _, contains := set.tree.Get(element); !contains {
+			return false
+		}
+	}
+	return true
+}
+
+// ElementSet returns an ordered set of the distinct elements in the multiset.
+func (set *Set) ElementSet() *treeset.Set {
+	elementSet := treeset.NewWith(set.tree.Comparator)
+	elementSet.Add(set.tree.Keys()...)
+	return elementSet
+}
+
+// Empty returns true if multiset does not contain any elements.
+func (set *Set) Empty() bool {
+	return set.Size() == 0
+}
+
+// Size returns the total number of elements within the multiset, counting all occurrences.
+func (set *Set) Size() int {
+	return set.size
+}
+
+// Clear clears all values in the multiset.
+func (set *Set) Clear() {
+	set.tree.Clear()
+	set.size = 0
+}
+
+// Clone returns a copy of the multiset with the same structure and comparator (elements themselves are not copied).
+func (set *Set) Clone() *Set {
+	return &Set{tree: set.tree.Clone(), size: set.size}
+}
+
+// Values returns all elements in the multiset in-order, each repeated as many times as it occurs.
+func (set *Set) Values() []interface{} {
+	values := make([]interface{}, set.Size())
+	index := 0
+	it := set.tree.Iterator()
+	for it.Next() {
+		for i := 0; i < it.Value().(int); i++ {
+			values[index] = it.Key()
+			index++
+		}
+	}
+	return values
+}
+
+// String returns a string representation of container
+func (set *Set) String() string {
+	str := "TreeMultiset\n"
+	items := []string{}
+	it := set.tree.Iterator()
+	for it.Next() {
+		items = append(items, fmt.Sprintf("%v:%v", it.Key(), it.Value()))
+	}
+	str += strings.Join(items, ", ")
+	return str
+}
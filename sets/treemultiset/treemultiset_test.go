@@ -0,0 +1,491 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package treemultiset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"testing"
+)
+
+func TestSetAdd(t *testing.T) {
+	set := NewWithIntComparator()
+	set.Add(3, 1)
+	set.Add(1, 2)
+	set.Add(1, 1)
+	set.Add(2, 0)
+	set.Add(2, -1)
+	if actualValue := set.Empty(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := set.Size(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%d%d%d%d", set.Values()...), "1113"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := set.Count(2); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestSetContains(t *testing.T) {
+	set := NewWithIntComparator()
+	set.Add(3, 1)
+	set.Add(1, 2)
+	set.Add(2, 3)
+	if actualValue := set.Contains(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := set.Contains(1, 2, 3); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := set.Contains(1, 2, 3, 4); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestSetRemove(t *testing.T) {
+	set := NewWithIntComparator()
+	set.Add(1, 3)
+	set.Add(2, 2)
+	set.Remove(1, 0)
+	set.Remove(3, 1)
+	if actualValue := set.Size(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	set.Remove(1, 2)
+	if actualValue := set.Count(1); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	set.Remove(1, 10)
+	if actualValue := set.Contains(1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	set.Remove(2, 2)
+	if actualValue := set.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := set.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestSetSetCount(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("a", 2)
+	set.SetCount("a", 5)
+	set.SetCount("b", 3)
+	if actualValue := set.Count("a"); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := set.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	set.SetCount("a", -1)
+	if actualValue := set.Contains("a"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := set.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func TestSetElementSet(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("c", 3)
+	set.Add("a", 2)
+	set.Add("b", 1)
+	if actualValue, expectedValue := fmt.Sprintf("%s%s%s", set.ElementSet().Values()...), "abc"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetEach(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("c", 3)
+	set.Add("a", 1)
+	set.Add("b", 2)
+	count := 0
+	set.Each(func(element interface{}, value interface{}) {
+		count++
+		if actualValue, expectedValue := value, count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		switch element {
+		case "a", "b", "c":
+		default:
+			t.Errorf("Too many")
+		}
+	})
+	if actualValue, expectedValue := count, 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetMap(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("a", 1)
+	set.Add("b", 2)
+	set.Add("c", 3)
+	mappedSet := set.Map(func(element interface{}, count interface{}) (interface{}, interface{}) {
+		return "x", count.(int) * 2
+	})
+	if actualValue, expectedValue := mappedSet.Count("x"), 12; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := mappedSet.Size(), 12; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetSelect(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("a", 1)
+	set.Add("b", 2)
+	set.Add("c", 3)
+	selectedSet := set.Select(func(element interface{}, count interface{}) bool {
+		return count.(int) >= 2
+	})
+	if actualValue, expectedValue := fmt.Sprintf("%s%s%s%s%s", selectedSet.Values()...), "bbccc"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetAnyAllFind(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("a", 1)
+	set.Add("b", 2)
+	set.Add("c", 3)
+	if actualValue := set.Any(func(element interface{}, count interface{}) bool { return count.(int) == 3 }); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := set.All(func(element interface{}, count interface{}) bool { return count.(int) > 1 }); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	element, count := set.Find(func(element interface{}, count interface{}) bool { return count.(int) > 1 })
+	if element != "b" || count != 2 {
+		t.Errorf("Got %v,%v expected %v,%v", element, count, "b", 2)
+	}
+	element, count = set.Find(func(element interface{}, count interface{}) bool { return count.(int) > 3 })
+	if element != nil || count != nil {
+		t.Errorf("Got %v,%v expected %v,%v", element, count, nil, nil)
+	}
+}
+
+func TestSetIteratorNextOnEmpty(t *testing.T) {
+	set := NewWithStringComparator()
+	it := set.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty set")
+	}
+}
+
+func TestSetIteratorNext(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("c", 3)
+	set.Add("a", 1)
+	set.Add("b", 2)
+
+	it := set.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Value(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := count, 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetIteratorPrev(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("c", 3)
+	set.Add("a", 1)
+	set.Add("b", 2)
+
+	it := set.Iterator()
+	it.End()
+	countDown := 3
+	for it.Prev() {
+		if actualValue, expectedValue := it.Value(), countDown; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		countDown--
+	}
+	if actualValue, expectedValue := countDown, 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetIteratorFirstLast(t *testing.T) {
+	set := NewWithIntComparator()
+	set.Add(3, 30)
+	set.Add(1, 10)
+	set.Add(2, 20)
+	it := set.Iterator()
+	if actualValue, expectedValue := it.First(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if element, count := it.Key(), it.Value(); element != 1 || count != 10 {
+		t.Errorf("Got %v,%v expected %v,%v", element, count, 1, 10)
+	}
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if element, count := it.Key(), it.Value(); element != 3 || count != 30 {
+		t.Errorf("Got %v,%v expected %v,%v", element, count, 3, 30)
+	}
+}
+
+func TestSetClone(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("a", 2)
+	set.Add("b", 1)
+
+	clone := set.Clone()
+	if actualValue := containers.Equal(clone, set, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), set.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Add("b", 1)
+	if actualValue := containers.Equal(clone, set, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(set.Clone(), set, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestSetSerialization(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("a", 1)
+	set.Add("b", 2)
+	set.Add("c", 3)
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := fmt.Sprintf("%s%s%s%s%s%s", set.Values()...), "abbccc"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := set.Size(), 6; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := set.ToJSON()
+	assert()
+
+	err = set.FromJSON(json)
+	assert()
+}
+
+func TestSetMarshalJSON(t *testing.T) {
+	type document struct {
+		Set *Set `json:"set"`
+	}
+	set := NewWithStringComparator()
+	set.Add("a", 2)
+	set.Add("b", 1)
+
+	data, err := json.Marshal(&document{Set: set})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := set.ToJSON()
+	if actualValue, expectedValue := string(data), `{"set":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Set: NewWithStringComparator()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Set.Size(), set.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Set.Values()), fmt.Sprint(set.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = json.Unmarshal(data, &document{})
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Set).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err = json.Marshal(&document{Set: &Set{}})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"set":[]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	data, err = new(Set).MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := restored.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetBinarySerialization(t *testing.T) {
+	set := NewWithIntComparator()
+	set.Add(1, 2)
+	set.Add(2, 1)
+
+	assert := func(restored *Set, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), set.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, value := range set.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := set.MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = set.MarshalBinaryWith(utils.IntCodec)
+	restored = NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Set *Set
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Set: set})
+	doc := &document{Set: NewWithIntComparator()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Set, err)
+}
+
+func TestSetStreaming(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("a", 2)
+	set.Add("b", 1)
+
+	assert := func(restored *Set, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(set.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := set.WriteJSON(buffer)
+	restored := NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = set.WriteBinary(buffer)
+	restored = NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
+func benchmarkAdd(b *testing.B, set *Set, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			set.Add(n, 1)
+		}
+	}
+}
+
+func benchmarkCount(b *testing.B, set *Set, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			set.Count(n)
+		}
+	}
+}
+
+func BenchmarkTreeMultisetAdd100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	set := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkAdd(b, set, size)
+}
+
+func BenchmarkTreeMultisetAdd10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	set := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkAdd(b, set, size)
+}
+
+func BenchmarkTreeMultisetCount100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	set := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		set.Add(n, 1)
+	}
+	b.StartTimer()
+	benchmarkCount(b, set, size)
+}
+
+func BenchmarkTreeMultisetCount10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	set := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		set.Add(n, 1)
+	}
+	b.StartTimer()
+	benchmarkCount(b, set, size)
+}
@@ -5,27 +5,181 @@
 package treeset
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"github.com/emirpasic/gods/containers"
+	rbt "github.com/emirpasic/gods/trees/redblacktree"
+	"github.com/emirpasic/gods/utils"
+	"io"
 )
 
 func assertSerializationImplementation() {
 	var _ containers.JSONSerializer = (*Set)(nil)
 	var _ containers.JSONDeserializer = (*Set)(nil)
+	var _ json.Marshaler = (*Set)(nil)
+	var _ json.Unmarshaler = (*Set)(nil)
+	var _ encoding.BinaryMarshaler = (*Set)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Set)(nil)
+	var _ gob.GobEncoder = (*Set)(nil)
+	var _ gob.GobDecoder = (*Set)(nil)
 }
 
-// ToJSON outputs the JSON representation of list's elements.
+// errNoComparator is returned when elements are decoded into a set that was not instantiated by a constructor,
+// e.g. the zero value of a field being unmarshalled, since the set can not order the elements without a comparator.
+var errNoComparator = errors.New("treeset: can not decode into a set without comparator, instantiate it with NewWith")
+
+// ToJSON outputs the JSON representation of set's elements as an array in sorted order.
 func (set *Set) ToJSON() ([]byte, error) {
+	set = set.orEmpty()
 	return json.Marshal(set.Values())
 }
 
-// FromJSON populates list's elements from the input JSON representation.
+// FromJSON populates set's elements from the input JSON representation.
+// Elements are decoded with the set's decoder (see SetDecoder and FromJSONWith for other decoders).
 func (set *Set) FromJSON(data []byte) error {
-	elements := []interface{}{}
-	err := json.Unmarshal(data, &elements)
-	if err == nil {
-		set.Clear()
-		set.Add(elements...)
+	return set.FromJSONWith(data, set.decoder)
+}
+
+// FromJSONWith populates set's elements from the input JSON representation,
+// decoding elements with the given decoder (nil for Go's default JSON decoding).
+// Set is not modified if the input can not be decoded.
+func (set *Set) FromJSONWith(data []byte, elementDecoder utils.Decoder) error {
+	return set.ReadJSONWith(bytes.NewReader(data), elementDecoder)
+}
+
+// SetDecoder sets the decoder of elements used by FromJSON, ReadJSON and UnmarshalJSON (nil for Go's default JSON decoding),
+// e.g. for unmarshalling a field holding the set with json.Unmarshal.
+// Sets instantiated with NewWithIntComparator or NewWithStringComparator decode elements with the IntDecoder or StringDecoder.
+func (set *Set) SetDecoder(elementDecoder utils.Decoder) {
+	set.decoder = elementDecoder
+}
+
+// WriteJSON writes the JSON representation of set's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (set *Set) WriteJSON(w io.Writer) error {
+	set = set.orEmpty()
+	it := set.Iterator()
+	return utils.WriteJSONValues(w, func() (interface{}, bool) {
+		if !it.Next() {
+			return nil, false
+		}
+		return it.Value(), true
+	})
+}
+
+// ReadJSON populates set's elements from the JSON representation read from the reader (see FromJSON).
+func (set *Set) ReadJSON(r io.Reader) error {
+	return set.ReadJSONWith(r, set.decoder)
+}
+
+// ReadJSONWith populates set's elements from the JSON representation read from the reader,
+// decoding elements with the given decoder (nil for Go's default JSON decoding).
+// Set is not modified if the input can not be decoded.
+func (set *Set) ReadJSONWith(r io.Reader, elementDecoder utils.Decoder) error {
+	if set.tree == nil {
+		return errNoComparator
+	}
+	loader := set.tree.Loader()
+	if err := utils.ReadJSONValues(r, elementDecoder, func(item interface{}) error {
+		return loader.Add(item, itemExists)
+	}); err != nil {
+		return err
+	}
+	loader.Load()
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of set's elements (implements json.Marshaler).
+func (set *Set) MarshalJSON() ([]byte, error) {
+	return set.ToJSON()
+}
+
+// UnmarshalJSON populates set's elements from the input JSON representation (implements json.Unmarshaler).
+func (set *Set) UnmarshalJSON(data []byte) error {
+	return set.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of set's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (set *Set) MarshalBinary() ([]byte, error) {
+	return set.MarshalBinaryWith(nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of set's elements,
+// encoding each element with the given codec (nil for gob).
+func (set *Set) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := set.WriteBinaryWith(buffer, codec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates set's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (set *Set) UnmarshalBinary(data []byte) error {
+	return set.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates set's elements from the input binary representation,
+// decoding each element with the given codec, which must be the one the elements were encoded with.
+// Set is not modified if the input can not be decoded.
+func (set *Set) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return set.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of set's elements (see MarshalBinary) into the writer.
+func (set *Set) WriteBinary(w io.Writer) error {
+	return set.WriteBinaryWith(w, nil)
+}
+
+// WriteBinaryWith writes the binary representation of set's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (set *Set) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	set = set.orEmpty()
+	it := set.Iterator()
+	return utils.WriteBinaryValues(w, set.Size(), func() interface{} {
+		it.Next()
+		return it.Value()
+	}, codec)
+}
+
+// ReadBinary populates set's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (set *Set) ReadBinary(r io.Reader) error {
+	return set.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates set's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (set *Set) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	if set.tree == nil {
+		return errNoComparator
+	}
+	loader := set.tree.Loader()
+	if err := utils.ReadBinaryValues(r, codec, func(item interface{}) error {
+		return loader.Add(item, itemExists)
+	}); err != nil {
+		return err
+	}
+	loader.Load()
+	return nil
+}
+
+// GobEncode outputs the binary representation of set's elements (implements gob.GobEncoder).
+func (set *Set) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+// GobDecode populates set's elements from the input binary representation (implements gob.GobDecoder).
+func (set *Set) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+// orEmpty returns the set, or an empty set in place of a zero value set (e.g. a field that was never instantiated),
+// so that writing a zero value set outputs no elements.
+func (set *Set) orEmpty() *Set {
+	if set.tree == nil {
+		return &Set{tree: rbt.NewWith(nil)}
 	}
-	return err
+	return set
 }
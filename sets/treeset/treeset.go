@@ -23,7 +23,8 @@ func assertSetImplementation() {
 
 // Set holds elements in a red-black tree
 type Set struct {
-	tree *rbt.Tree
+	tree    *rbt.Tree
+	decoder utils.Decoder // decodes elements from JSON, nil for Go's default JSON decoding (see SetDecoder)
 }
 
 var itemExists = struct{}{}
@@ -35,12 +36,12 @@ func NewWith(comparator utils.Comparator) *Set {
 
 // NewWithIntComparator instantiates a new empty set with the IntComparator, i.e. keys are of type int.
 func NewWithIntComparator() *Set {
-	return &Set{tree: rbt.NewWithIntComparator()}
+	return &Set{tree: rbt.NewWithIntComparator(), decoder: utils.IntDecoder}
 }
 
 // NewWithStringComparator instantiates a new empty set with the StringComparator, i.e. keys are of type string.
 func NewWithStringComparator() *Set {
-	return &Set{tree: rbt.NewWithStringComparator()}
+	return &Set{tree: rbt.NewWithStringComparator(), decoder: utils.StringDecoder}
 }
 
 // Add adds the items (one or more) to the set.
@@ -84,6 +85,11 @@ func (set *Set) Clear() {
 	set.tree.Clear()
 }
 
+// Clone returns a copy of the set with the same structure and comparator (elements themselves are not copied).
+func (set *Set) Clone() *Set {
+	return &Set{tree: set.tree.Clone(), decoder: set.decoder}
+}
+
 // Values returns all items in the set.
 func (set *Set) Values() []interface{} {
 	return set.tree.Keys()
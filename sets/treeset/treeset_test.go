@@ -5,7 +5,14 @@
 package treeset
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/sets"
+	"github.com/emirpasic/gods/utils"
 	"testing"
 )
 
@@ -327,6 +334,27 @@ func TestSetIteratorLast(t *testing.T) {
 	}
 }
 
+func TestSetClone(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("c", "a", "b")
+
+	clone := set.Clone()
+	if actualValue := containers.Equal(clone, set, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), set.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Add("x")
+	if actualValue := containers.Equal(clone, set, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(set.Clone(), set, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestSetSerialization(t *testing.T) {
 	set := NewWithStringComparator()
 	set.Add("a", "b", "c")
@@ -353,6 +381,189 @@ func TestSetSerialization(t *testing.T) {
 	assert()
 }
 
+func TestSetMarshalJSON(t *testing.T) {
+	type document struct {
+		Set *Set `json:"set"`
+	}
+	set := NewWithStringComparator()
+	set.Add("a", "b", "c")
+
+	data, err := json.Marshal(&document{Set: set})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := set.ToJSON()
+	if actualValue, expectedValue := string(data), `{"set":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Set: NewWithStringComparator()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Set.Size(), set.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Set.Values()), fmt.Sprint(set.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = json.Unmarshal(data, &document{})
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Set).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err = json.Marshal(&document{Set: &Set{}})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"set":[]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	data, err = new(Set).MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := restored.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSetBinarySerialization(t *testing.T) {
+	set := NewWithIntComparator()
+	set.Add(3, 1, 2)
+
+	assert := func(restored *Set, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), set.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, value := range set.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := set.MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = set.MarshalBinaryWith(utils.IntCodec)
+	restored = NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Set *Set
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Set: set})
+	doc := &document{Set: NewWithIntComparator()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Set, err)
+}
+
+func TestSetStreaming(t *testing.T) {
+	set := NewWithStringComparator()
+	set.Add("c", "a", "b")
+
+	assert := func(restored *Set, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(set.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := set.WriteJSON(buffer)
+	restored := NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = set.WriteBinary(buffer)
+	restored = NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
+func TestSetSerializationWithDecoder(t *testing.T) {
+	set := NewWithIntComparator()
+	set.Add(3, 1, 2)
+
+	json, err := set.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(json), "[1,2,3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	set.Clear()
+	err = set.FromJSONWith(json, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := set.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := set.Contains(1, 2, 3); !actualValue {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	err = set.FromJSONWith([]byte(`[4,"x"]`), utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped element")
+	}
+	if actualValue, expectedValue := set.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = set.FromJSON(json)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	set.SetDecoder(nil)
+	err = set.FromJSON(json)
+	if err == nil {
+		t.Errorf("Got no error for elements decoded as float64")
+	}
+	if actualValue, expectedValue := set.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func benchmarkContains(b *testing.B, set *Set, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -377,6 +588,16 @@ func benchmarkRemove(b *testing.B, set *Set, size int) {
 	}
 }
 
+func TestSetConformance(t *testing.T) {
+	containertest.TestSet(t, func() sets.Set { return NewWithIntComparator() })
+	for _, values := range [][]interface{}{{}, {1}, {1, 2, 3}} {
+		set := NewWithIntComparator()
+		set.Add(values...)
+		it := set.Iterator()
+		containertest.TestIteratorWithIndex(t, &it, values)
+	}
+}
+
 func BenchmarkTreeSetContains100(b *testing.B) {
 	b.StopTimer()
 	size := 100
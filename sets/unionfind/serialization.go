@@ -0,0 +1,166 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unionfind
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
+
+func assertSerializationImplementation() {
+	var _ containers.JSONSerializer = (*UnionFind)(nil)
+	var _ containers.JSONDeserializer = (*UnionFind)(nil)
+	var _ json.Marshaler = (*UnionFind)(nil)
+	var _ json.Unmarshaler = (*UnionFind)(nil)
+	var _ encoding.BinaryMarshaler = (*UnionFind)(nil)
+	var _ encoding.BinaryUnmarshaler = (*UnionFind)(nil)
+	var _ gob.GobEncoder = (*UnionFind)(nil)
+	var _ gob.GobDecoder = (*UnionFind)(nil)
+}
+
+// ToJSON outputs the JSON representation of union-find's elements as an array of its sets (see Sets).
+func (uf *UnionFind) ToJSON() ([]byte, error) {
+	return json.Marshal(uf.Sets())
+}
+
+// FromJSON populates union-find's elements from the input JSON representation.
+// Elements are decoded with the union-find's decoder (see SetDecoder and FromJSONWith for other decoders).
+func (uf *UnionFind) FromJSON(data []byte) error {
+	return uf.FromJSONWith(data, uf.decoder)
+}
+
+// FromJSONWith populates union-find's elements from the input JSON representation,
+// decoding elements with the given decoder (nil for Go's default JSON decoding).
+// Union-find is not modified if the input can not be decoded.
+func (uf *UnionFind) FromJSONWith(data []byte, elementDecoder utils.Decoder) error {
+	sets := [][]json.RawMessage{}
+	if err := json.Unmarshal(data, &sets); err != nil {
+		return err
+	}
+	loaded := uf.newEmpty()
+	for _, set := range sets {
+		var first interface{} // the first element of each set represents it
+		for i, raw := range set {
+			element, err := utils.Decode(raw, elementDecoder)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				first = element
+			}
+			loaded.MakeSet(element)
+			loaded.Union(element, first)
+		}
+	}
+	uf.replace(loaded)
+	return nil
+}
+
+// SetDecoder sets the decoder of elements used by FromJSON and UnmarshalJSON (nil for Go's default JSON decoding),
+// e.g. for unmarshalling a field holding the union-find with json.Unmarshal.
+func (uf *UnionFind) SetDecoder(elementDecoder utils.Decoder) {
+	uf.decoder = elementDecoder
+}
+
+// MarshalJSON outputs the JSON representation of union-find's elements (implements json.Marshaler).
+func (uf *UnionFind) MarshalJSON() ([]byte, error) {
+	return uf.ToJSON()
+}
+
+// UnmarshalJSON populates union-find's elements from the input JSON representation (implements json.Unmarshaler).
+func (uf *UnionFind) UnmarshalJSON(data []byte) error {
+	return uf.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of union-find's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (uf *UnionFind) MarshalBinary() ([]byte, error) {
+	return uf.MarshalBinaryWith(nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of union-find's elements,
+// encoding each element with the given codec (nil for gob).
+func (uf *UnionFind) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := uf.WriteBinaryWith(buffer, codec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates union-find's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (uf *UnionFind) UnmarshalBinary(data []byte) error {
+	return uf.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates union-find's elements from the input binary representation,
+// decoding each element with the given codec, which must be the one the elements were encoded with.
+// Union-find is not modified if the input can not be decoded.
+func (uf *UnionFind) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return uf.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of union-find's elements (see MarshalBinary) into the writer.
+func (uf *UnionFind) WriteBinary(w io.Writer) error {
+	return uf.WriteBinaryWith(w, nil)
+}
+
+// WriteBinaryWith writes the binary representation of union-find's elements (see MarshalBinaryWith) into the writer
+// as pairs of elements and the representatives of their sets, encoding elements in small chunks.
+func (uf *UnionFind) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	i := -1
+	return utils.WriteBinaryPairs(w, uf.Size(), func() (interface{}, interface{}) {
+		i++
+		return uf.elements[i], uf.elements[uf.root(i)]
+	}, codec, codec)
+}
+
+// ReadBinary populates union-find's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (uf *UnionFind) ReadBinary(r io.Reader) error {
+	return uf.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates union-find's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+// Elements are added as they are decoded, only elements preceding their representatives are kept aside
+// until the representatives were added, so that elements keep their order.
+func (uf *UnionFind) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	loaded := uf.newEmpty()
+	var pending [][2]interface{} // elements and their representatives that were not added yet
+	if err := utils.ReadBinaryPairs(r, codec, codec, func(element interface{}, representative interface{}) error {
+		loaded.MakeSet(element)
+		if loaded.Contains(representative) {
+			loaded.Union(element, representative)
+		} else {
+			pending = append(pending, [2]interface{}{element, representative})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, pair := range pending {
+		loaded.Union(pair[0], pair[1])
+	}
+	uf.replace(loaded)
+	return nil
+}
+
+// GobEncode outputs the binary representation of union-find's elements (implements gob.GobEncoder).
+func (uf *UnionFind) GobEncode() ([]byte, error) {
+	return uf.MarshalBinary()
+}
+
+// GobDecode populates union-find's elements from the input binary representation (implements gob.GobDecoder).
+func (uf *UnionFind) GobDecode(data []byte) error {
+	return uf.UnmarshalBinary(data)
+}
+
+// replace replaces union-find's elements with the elements of the loaded union-find.
+func (uf *UnionFind) replace(loaded *UnionFind) {
+	uf.indexes, uf.elements, uf.parents, uf.sizes, uf.sets = loaded.indexes, loaded.elements, loaded.parents, loaded.sizes, loaded.sets
+}
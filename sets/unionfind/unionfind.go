@@ -0,0 +1,224 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package unionfind implements a disjoint-set forest (union-find), a partition of elements into disjoint sets.
+//
+// Each set is a tree of its elements, represented by its root. Find follows the path from an element to the root of
+// its set, halving the path on the way (path compression), and Union attaches the root of the smaller tree to the root
+// of the larger one (union by size), so that both take nearly constant amortized time.
+//
+// Elements are looked up in a hashmap, hence a custom hasher and equaler can be used for elements that are not comparable (see NewWith).
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Disjoint-set_data_structure
+package unionfind
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/maps/hashmap"
+	"github.com/emirpasic/gods/utils"
+	"strings"
+)
+
+func assertContainerImplementation() {
+	var _ containers.Container = (*UnionFind)(nil)
+}
+
+// UnionFind holds the elements and the forest of their sets
+type UnionFind struct {
+	indexes  maps.Map      // element -> index of the element in elements, parents and sizes (a hashmap.Map or hashmap.CustomMap)
+	elements []interface{} // elements in order of insertion
+	parents  []int         // parents[i] is the index of the parent of element i, roots are their own parents
+	sizes    []int         // sizes[i] is the size of the set of element i if it is a root
+	sets     int           // number of disjoint sets
+	hasher   utils.Hasher  // nil if elements are hashed by go's native map
+	equaler  utils.Equaler // nil if elements are hashed by go's native map
+	decoder  utils.Decoder // decodes elements from JSON, nil for Go's default JSON decoding (see SetDecoder)
+}
+
+// New instantiates a new empty union-find
+func New() *UnionFind {
+	return &UnionFind{indexes: hashmap.New()}
+}
+
+// NewWith instantiates a new empty union-find with the custom hasher and equaler.
+// Elements need not be comparable, but equal elements (with respect to the equaler) must have equal hashes.
+func NewWith(hasher utils.Hasher, equaler utils.Equaler) *UnionFind {
+	return &UnionFind{indexes: hashmap.NewWith(hasher, equaler), hasher: hasher, equaler: equaler}
+}
+
+// MakeSet adds each of the elements (one or more) as a set of its own.
+// Elements already in the union-find are left in their sets.
+func (uf *UnionFind) MakeSet(elements ...interface{}) {
+	for _, element := range elements {
+		uf.index(element)
+	}
+}
+
+// Find returns the representative of the set of the element, which is the same for all elements of the set.
+// Second return parameter is true if the element was found, otherwise false.
+func (uf *UnionFind) Find(element interface{}) (representative interface{}, found bool) {
+	index, found := uf.indexes.Get(element)
+	if !found {
+		return nil, false
+	}
+	return uf.elements[uf.root(index.(int))], true
+}
+
+// Union merges the sets of the elements, adding elements that are not in the union-find as sets of their own first.
+// Returns true if the elements were in different sets.
+func (uf *UnionFind) Union(a interface{}, b interface{}) bool {
+	rootA, rootB := uf.root(uf.index(a)), uf.root(uf.index(b))
+	if rootA == rootB {
+		return false
+	}
+	if uf.sizes[rootA] < uf.sizes[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	uf.parents[rootB] = rootA
+	uf.sizes[rootA] += uf.sizes[rootB]
+	uf.sets--
+	return true
+}
+
+// Connected returns true if the elements are in the same set.
+// Returns false if any of the elements is not in the union-find.
+func (uf *UnionFind) Connected(a interface{}, b interface{}) bool {
+	indexA, foundA := uf.indexes.Get(a)
+	indexB, foundB := uf.indexes.Get(b)
+	return foundA && foundB && uf.root(indexA.(int)) == uf.root(indexB.(int))
+}
+
+// SetSize returns the number of elements in the set of the element, or zero if the element is not in the union-find.
+func (uf *UnionFind) SetSize(element interface{}) int {
+	index, found := uf.indexes.Get(element)
+	if !found {
+		return 0
+	}
+	return uf.sizes[uf.root(index.(int))]
+}
+
+// SetCount returns the number of disjoint sets.
+func (uf *UnionFind) SetCount() int {
+	return uf.sets
+}
+
+// Sets returns the disjoint sets, each holding its elements in order of insertion.
+// Sets are ordered by their first inserted elements.
+func (uf *UnionFind) Sets() [][]interface{} {
+	sets := make([][]interface{}, 0, uf.sets)
+	positions := make(map[int]int, uf.sets) // root -> position of its set in sets
+	for index, element := range uf.elements {
+		root := uf.root(index)
+		position, found := positions[root]
+		if !found {
+			position = len(sets)
+			positions[root] = position
+			sets = append(sets, make([]interface{}, 0, uf.sizes[root]))
+		}
+		sets[position] = append(sets[position], element)
+	}
+	return sets
+}
+
+// Contains check if elements (one or more) are present in the union-find.
+// All elements have to be present in the union-find for the method to return true.
+// Returns true if no arguments are passed at all.
+func (uf *UnionFind) Contains(elements ...interface{}) bool {
+	for _, element := range elements {
+		if _, contains := uf.indexes.Get(element); !contains {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty returns true if union-find does not contain any elements.
+func (uf *UnionFind) Empty() bool {
+	return uf.Size() == 0
+}
+
+// Size returns number of elements within the union-find.
+func (uf *UnionFind) Size() int {
+	return len(uf.elements)
+}
+
+// Clear removes all elements from the union-find.
+func (uf *UnionFind) Clear() {
+	uf.indexes.Clear()
+	uf.elements = nil
+	uf.parents = nil
+	uf.sizes = nil
+	uf.sets = 0
+}
+
+// Values returns all elements in order of insertion.
+func (uf *UnionFind) Values() []interface{} {
+	values := make([]interface{}, len(uf.elements))
+	copy(values, uf.elements)
+	return values
+}
+
+// Clone returns a shallow copy of the union-find with the same hasher and equaler (elements themselves are not copied).
+func (uf *UnionFind) Clone() *UnionFind {
+	clone := uf.newEmpty()
+	for index, element := range uf.elements {
+		clone.indexes.Put(element, index)
+	}
+	clone.sets = uf.sets
+	clone.decoder = uf.decoder
+	clone.elements = append([]interface{}(nil), uf.elements...)
+	clone.parents = append([]int(nil), uf.parents...)
+	clone.sizes = append([]int(nil), uf.sizes...)
+	return clone
+}
+
+// String returns a string representation of container
+func (uf *UnionFind) String() string {
+	str := "UnionFind\n"
+	sets := []string{}
+	for _, set := range uf.Sets() {
+		elements := []string{}
+		for _, element := range set {
+			elements = append(elements, fmt.Sprintf("%v", element))
+		}
+		sets = append(sets, "{"+strings.Join(elements, ", ")+"}")
+	}
+	str += strings.Join(sets, ", ")
+	return str
+}
+
+// newEmpty instantiates an empty union-find that hashes elements the same way as this union-find.
+func (uf *UnionFind) newEmpty() *UnionFind {
+	if uf.hasher != nil {
+		return NewWith(uf.hasher, uf.equaler)
+	}
+	return New()
+}
+
+// index returns the index of the element, adding it as a set of its own if it is not in the union-find.
+func (uf *UnionFind) index(element interface{}) int {
+	if index, found := uf.indexes.Get(element); found {
+		return index.(int)
+	}
+	index := len(uf.elements)
+	uf.indexes.Put(element, index)
+	uf.elements = append(uf.elements, element)
+	uf.parents = append(uf.parents, index)
+	uf.sizes = append(uf.sizes, 1)
+	uf.sets++
+	return index
+}
+
+// root returns the index of the root of the element's tree, halving the path to it.
+func (uf *UnionFind) root(index int) int {
+	for uf.parents[index] != index {
+		uf.parents[index] = uf.parents[uf.parents[index]]
+		index = uf.parents[index]
+	}
+	return index
+}
@@ -0,0 +1,337 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unionfind
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/utils"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestUnionFindMakeSet(t *testing.T) {
+	uf := New()
+	uf.MakeSet("a", "b", "c")
+	uf.MakeSet("a") // already present
+
+	if actualValue, expectedValue := uf.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := uf.SetCount(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(uf.Values()), "[a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := uf.Find("b"); actualValue != "b" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+	if actualValue, found := uf.Find("x"); actualValue != nil || found {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, expectedValue := uf.Contains("a", "c"), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := uf.Contains("a", "x"), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestUnionFindUnion(t *testing.T) {
+	uf := New()
+	uf.MakeSet(1, 2, 3, 4, 5, 6)
+
+	if actualValue, expectedValue := uf.Union(1, 2), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	uf.Union(3, 4)
+	uf.Union(4, 5)
+	if actualValue, expectedValue := uf.Union(5, 3), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	uf.Union(7, 8) // added as sets of their own first
+
+	tests := [][]interface{}{
+		{1, 2, true},
+		{3, 5, true},
+		{1, 3, false},
+		{6, 6, true},
+		{7, 8, true},
+		{1, 9, false},
+		{9, 9, false},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := uf.Connected(test[0], test[1]), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for Connected(%v, %v)", actualValue, expectedValue, test[0], test[1])
+		}
+	}
+
+	if actualValue, expectedValue := uf.SetSize(4), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := uf.SetSize(6), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := uf.SetSize(9), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := uf.SetCount(), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(uf.Sets()), "[[1 2] [3 4 5] [6] [7 8]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := uf.String(), "UnionFind\n{1, 2}, {3, 4, 5}, {6}, {7, 8}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	representative, _ := uf.Find(3)
+	for _, element := range []interface{}{4, 5} {
+		if actualValue, _ := uf.Find(element); actualValue != representative {
+			t.Errorf("Got %v expected %v", actualValue, representative)
+		}
+	}
+
+	uf.Clear()
+	if actualValue, expectedValue := uf.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := uf.SetCount(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := uf.Connected(1, 2), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestUnionFindRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	uf := New()
+	components := map[int]int{} // element -> component, naively relabelled on union
+	for i := 0; i < 2000; i++ {
+		a, b := random.Intn(200), random.Intn(200)
+		uf.Union(a, b)
+		for _, element := range []int{a, b} {
+			if _, found := components[element]; !found {
+				components[element] = element
+			}
+		}
+		if from, to := components[b], components[a]; from != to {
+			for element, component := range components {
+				if component == from {
+					components[element] = to
+				}
+			}
+		}
+
+		c, d := random.Intn(200), random.Intn(200)
+		componentC, foundC := components[c]
+		componentD, foundD := components[d]
+		if actualValue, expectedValue := uf.Connected(c, d), foundC && foundD && componentC == componentD; actualValue != expectedValue {
+			t.Fatalf("Got %v expected %v for Connected(%v, %v) after operation %v", actualValue, expectedValue, c, d, i)
+		}
+		size := 0
+		for _, component := range components {
+			if foundC && component == componentC {
+				size++
+			}
+		}
+		if actualValue, expectedValue := uf.SetSize(c), size; actualValue != expectedValue {
+			t.Fatalf("Got %v expected %v for SetSize(%v) after operation %v", actualValue, expectedValue, c, i)
+		}
+	}
+
+	distinct := map[int]bool{}
+	for _, component := range components {
+		distinct[component] = true
+	}
+	if actualValue, expectedValue := uf.SetCount(), len(distinct); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := len(uf.Sets()), len(distinct); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestUnionFindNewWith(t *testing.T) {
+	hasher := func(a interface{}) uint64 { return utils.StringHasher(strings.ToLower(a.(string))) }
+	equaler := func(a, b interface{}) bool { return strings.EqualFold(a.(string), b.(string)) }
+	uf := NewWith(hasher, equaler)
+	uf.Union("a", "B")
+	uf.Union("b", "C")
+
+	if actualValue, expectedValue := uf.Connected("A", "c"), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(uf.Sets()), "[[a B C]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone := uf.Clone()
+	clone.Union("x", "c")
+	if actualValue, expectedValue := uf.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := clone.Connected("X", "a"), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestUnionFindClone(t *testing.T) {
+	uf := New()
+	uf.Union(1, 2)
+	uf.MakeSet(3)
+
+	clone := uf.Clone()
+	clone.Union(2, 3)
+	if actualValue, expectedValue := fmt.Sprint(uf.Sets()), "[[1 2] [3]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(clone.Sets()), "[[1 2 3]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestUnionFindSerialization(t *testing.T) {
+	uf := New()
+	uf.Union("a", "b")
+	uf.Union("c", "d")
+	uf.Union("d", "e")
+	uf.MakeSet("f")
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := fmt.Sprint(uf.Sets()), "[[a b] [c d e] [f]]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := uf.SetCount(), 3; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := uf.ToJSON()
+	assert()
+	if actualValue, expectedValue := string(json), `[["a","b"],["c","d","e"],["f"]]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = uf.FromJSON(json)
+	assert()
+
+	data, err := uf.MarshalBinary()
+	assert()
+	err = uf.UnmarshalBinary(data)
+	assert()
+
+	err = uf.FromJSON([]byte(`[["a"],"b"]`))
+	if err == nil {
+		t.Errorf("Got no error for malformed input")
+	}
+	err = nil
+	assert()
+}
+
+func TestUnionFindSerializationWithDecoders(t *testing.T) {
+	uf := New()
+	uf.Union(1, 2)
+	uf.MakeSet(3)
+
+	data, err := json.Marshal(uf)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded := New()
+	if err := decoded.FromJSONWith(data, utils.IntDecoder); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := decoded.Connected(2, 1), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := decoded.FromJSONWith([]byte(`[[1,"x"]]`), utils.IntDecoder); err == nil {
+		t.Errorf("Got no error for mistyped element")
+	}
+	if actualValue, expectedValue := decoded.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err = uf.MarshalBinaryWith(utils.IntCodec)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded = New()
+	if err := decoded.UnmarshalBinaryWith(data, utils.IntCodec); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(decoded.Sets()), "[[1 2] [3]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(uf); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded = New()
+	if err := gob.NewDecoder(&buffer).Decode(decoded); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := decoded.Connected(1, 2), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	decoded = &UnionFind{}
+	if err := decoded.UnmarshalBinaryWith(data, utils.IntCodec); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := decoded.Connected(1, 2), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkUnion(b *testing.B, uf *UnionFind, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 1; n < size; n++ {
+			uf.Union(n, n/2)
+		}
+	}
+}
+
+func benchmarkFind(b *testing.B, uf *UnionFind, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			uf.Find(n)
+		}
+	}
+}
+
+func BenchmarkUnionFindUnion100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	uf := New()
+	for n := 0; n < size; n++ {
+		uf.MakeSet(n)
+	}
+	b.StartTimer()
+	benchmarkUnion(b, uf, size)
+}
+
+func BenchmarkUnionFindFind100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	uf := New()
+	for n := 1; n < size; n++ {
+		uf.Union(n, n/2)
+	}
+	b.StartTimer()
+	benchmarkFind(b, uf, size)
+}
@@ -5,7 +5,14 @@
 package arraystack
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/stacks"
+	"github.com/emirpasic/gods/utils"
 	"testing"
 )
 
@@ -232,6 +239,28 @@ func TestStackIteratorLast(t *testing.T) {
 	}
 }
 
+func TestStackClone(t *testing.T) {
+	stack := New()
+	stack.Push("a")
+	stack.Push("b")
+
+	clone := stack.Clone()
+	if actualValue := containers.Equal(clone, stack, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), stack.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Push("x")
+	if actualValue := containers.Equal(clone, stack, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(stack.Clone(), stack, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestStackSerialization(t *testing.T) {
 	stack := New()
 	stack.Push("a")
@@ -260,6 +289,147 @@ func TestStackSerialization(t *testing.T) {
 	assert()
 }
 
+func TestStackMarshalJSON(t *testing.T) {
+	type document struct {
+		Stack *Stack `json:"stack"`
+	}
+	stack := New()
+	stack.Push("a")
+	stack.Push("b")
+
+	data, err := json.Marshal(&document{Stack: stack})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := stack.ToJSON()
+	if actualValue, expectedValue := string(data), `{"stack":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Stack: New()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Stack.Size(), stack.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Stack.Values()), fmt.Sprint(stack.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	zeroDoc := &document{}
+	err = json.Unmarshal(data, zeroDoc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	zeroDoc.Stack.Push("c")
+	if actualValue, expectedValue := zeroDoc.Stack.Size(), stack.Size()+1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err = json.Marshal(&document{Stack: &Stack{}})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"stack":[]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	data, err = new(Stack).MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := restored.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestStackBinarySerialization(t *testing.T) {
+	stack := New()
+	stack.Push(1)
+	stack.Push(2)
+
+	assert := func(restored *Stack, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), stack.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, value := range stack.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := stack.MarshalBinary()
+	restored := New()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = stack.MarshalBinaryWith(utils.IntCodec)
+	restored = New()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Stack *Stack
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Stack: stack})
+	doc := &document{Stack: New()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Stack, err)
+}
+
+func TestStackStreaming(t *testing.T) {
+	stack := New()
+	stack.Push("a")
+	stack.Push("b")
+
+	assert := func(restored *Stack, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(stack.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := stack.WriteJSON(buffer)
+	restored := New()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = stack.WriteBinary(buffer)
+	restored = New()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
 func benchmarkPush(b *testing.B, stack *Stack, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -276,6 +446,18 @@ func benchmarkPop(b *testing.B, stack *Stack, size int) {
 	}
 }
 
+func TestStackConformance(t *testing.T) {
+	containertest.TestStack(t, func() stacks.Stack { return New() })
+	for _, values := range [][]interface{}{{}, {1}, {3, 1, 2}} {
+		stack := New()
+		for _, value := range values {
+			stack.Push(value)
+		}
+		it := stack.Iterator()
+		containertest.TestReverseIteratorWithIndex(t, &it, stack.Values())
+	}
+}
+
 func BenchmarkArrayStackPop100(b *testing.B) {
 	b.StopTimer()
 	size := 100
@@ -64,6 +64,11 @@ func (stack *Stack) Clear() {
 	stack.list.Clear()
 }
 
+// Clone returns a shallow copy of the stack (elements themselves are not copied).
+func (stack *Stack) Clone() *Stack {
+	return &Stack{list: stack.list.Clone()}
+}
+
 // Values returns all elements in the stack (LIFO order).
 func (stack *Stack) Values() []interface{} {
 	return stack.list.Values()
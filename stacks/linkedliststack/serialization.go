@@ -4,19 +4,127 @@
 
 package linkedliststack
 
-import "github.com/emirpasic/gods/containers"
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/lists/singlylinkedlist"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
 
 func assertSerializationImplementation() {
 	var _ containers.JSONSerializer = (*Stack)(nil)
 	var _ containers.JSONDeserializer = (*Stack)(nil)
+	var _ json.Marshaler = (*Stack)(nil)
+	var _ json.Unmarshaler = (*Stack)(nil)
+	var _ encoding.BinaryMarshaler = (*Stack)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Stack)(nil)
+	var _ gob.GobEncoder = (*Stack)(nil)
+	var _ gob.GobDecoder = (*Stack)(nil)
 }
 
 // ToJSON outputs the JSON representation of list's elements.
 func (stack *Stack) ToJSON() ([]byte, error) {
-	return stack.list.ToJSON()
+	return stack.orEmpty().list.ToJSON()
 }
 
 // FromJSON populates list's elements from the input JSON representation.
 func (stack *Stack) FromJSON(data []byte) error {
+	stack.lazyInit()
 	return stack.list.FromJSON(data)
 }
+
+// WriteJSON writes the JSON representation of stack's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (stack *Stack) WriteJSON(w io.Writer) error {
+	return stack.orEmpty().list.WriteJSON(w)
+}
+
+// ReadJSON populates stack's elements from the JSON representation read from the reader (see FromJSON).
+func (stack *Stack) ReadJSON(r io.Reader) error {
+	stack.lazyInit()
+	return stack.list.ReadJSON(r)
+}
+
+// MarshalJSON outputs the JSON representation of stack's elements (implements json.Marshaler).
+func (stack *Stack) MarshalJSON() ([]byte, error) {
+	return stack.ToJSON()
+}
+
+// UnmarshalJSON populates stack's elements from the input JSON representation (implements json.Unmarshaler).
+func (stack *Stack) UnmarshalJSON(data []byte) error {
+	return stack.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of stack's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (stack *Stack) MarshalBinary() ([]byte, error) {
+	return stack.orEmpty().list.MarshalBinary()
+}
+
+// MarshalBinaryWith outputs the binary representation of stack's elements,
+// encoding each element with the given codec (nil for gob).
+func (stack *Stack) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	return stack.orEmpty().list.MarshalBinaryWith(codec)
+}
+
+// UnmarshalBinary populates stack's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (stack *Stack) UnmarshalBinary(data []byte) error {
+	return stack.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates stack's elements from the input binary representation,
+// decoding each element with the given codec, which must be the one the elements were encoded with.
+func (stack *Stack) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return stack.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of stack's elements (see MarshalBinary) into the writer.
+func (stack *Stack) WriteBinary(w io.Writer) error {
+	return stack.orEmpty().list.WriteBinary(w)
+}
+
+// WriteBinaryWith writes the binary representation of stack's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (stack *Stack) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	return stack.orEmpty().list.WriteBinaryWith(w, codec)
+}
+
+// ReadBinary populates stack's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (stack *Stack) ReadBinary(r io.Reader) error {
+	return stack.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates stack's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (stack *Stack) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	stack.lazyInit()
+	return stack.list.ReadBinaryWith(r, codec)
+}
+
+// GobEncode outputs the binary representation of stack's elements (implements gob.GobEncoder).
+func (stack *Stack) GobEncode() ([]byte, error) {
+	return stack.MarshalBinary()
+}
+
+// GobDecode populates stack's elements from the input binary representation (implements gob.GobDecoder).
+func (stack *Stack) GobDecode(data []byte) error {
+	return stack.UnmarshalBinary(data)
+}
+
+// lazyInit instantiates the list of a zero value stack (e.g. a field being unmarshalled) the same way as New.
+func (stack *Stack) lazyInit() {
+	if stack.list == nil {
+		stack.list = &singlylinkedlist.List{}
+	}
+}
+
+// orEmpty returns the stack, or an empty stack in place of a zero value stack (e.g. a field that was never instantiated),
+// so that writing a zero value stack outputs no elements.
+func (stack *Stack) orEmpty() *Stack {
+	if stack.list == nil {
+		return New()
+	}
+	return stack
+}
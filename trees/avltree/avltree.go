@@ -21,9 +21,11 @@ func assertTreeImplementation() {
 
 // Tree holds elements of the AVL tree.
 type Tree struct {
-	Root       *Node            // Root node
-	Comparator utils.Comparator // Key comparator
-	size       int              // Total number of keys in the tree
+	Root         *Node            // Root node
+	Comparator   utils.Comparator // Key comparator
+	keyDecoder   utils.Decoder    // decodes keys from JSON, nil for Go's default JSON decoding (see SetDecoders)
+	valueDecoder utils.Decoder    // decodes values from JSON, nil for Go's default JSON decoding
+	size         int              // Total number of keys in the tree
 }
 
 // Node is a single element within the tree
@@ -42,12 +44,12 @@ func NewWith(comparator utils.Comparator) *Tree {
 
 // NewWithIntComparator instantiates an AVL tree with the IntComparator, i.e. keys are of type int.
 func NewWithIntComparator() *Tree {
-	return &Tree{Comparator: utils.IntComparator}
+	return &Tree{Comparator: utils.IntComparator, keyDecoder: utils.IntDecoder}
 }
 
 // NewWithStringComparator instantiates an AVL tree with the StringComparator, i.e. keys are of type string.
 func NewWithStringComparator() *Tree {
-	return &Tree{Comparator: utils.StringComparator}
+	return &Tree{Comparator: utils.StringComparator, keyDecoder: utils.StringDecoder}
 }
 
 // Put inserts node into the tree.
@@ -181,12 +183,31 @@ func (t *Tree) Ceiling(key interface{}) (floor *Node, found bool) {
 	return nil, false
 }
 
+// BulkLoad replaces tree's elements with the given keys and their values (slices of the same length).
+// While keys are sorted in strictly ascending order, the balanced tree is built directly in linear time,
+// elements from the first key out of order on are inserted one by one (see Loader).
+// Panics if the comparator can not compare the keys.
+func (t *Tree) BulkLoad(keys []interface{}, values []interface{}) {
+	loader := t.Loader()
+	for i := range keys {
+		if err := loader.Add(keys[i], values[i]); err != nil {
+			panic(err)
+		}
+	}
+	loader.Load()
+}
+
 // Clear removes all nodes from the tree.
 func (t *Tree) Clear() {
 	t.Root = nil
 	t.size = 0
 }
 
+// Clone returns a copy of the tree with the same structure and comparator (keys and values themselves are not copied).
+func (t *Tree) Clone() *Tree {
+	return &Tree{Root: t.Root.clone(nil), Comparator: t.Comparator, size: t.size, keyDecoder: t.keyDecoder, valueDecoder: t.valueDecoder}
+}
+
 // String returns a string representation of container
 func (t *Tree) String() string {
 	str := "AVLTree\n"
@@ -283,6 +304,17 @@ func removeMin(qp **Node, minKey *interface{}, minVal *interface{}) bool {
 	return false
 }
 
+// clone copies the subtree rooted at the node, attaching it to p.
+func (n *Node) clone(p *Node) *Node {
+	if n == nil {
+		return nil
+	}
+	q := &Node{Key: n.Key, Value: n.Value, Parent: p, b: n.b}
+	q.Children[0] = n.Children[0].clone(q)
+	q.Children[1] = n.Children[1].clone(q)
+	return q
+}
+
 func putFix(c int8, t **Node) bool {
 	s := *t
 	if s.b == 0 {
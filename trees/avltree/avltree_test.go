@@ -4,7 +4,16 @@
 package avltree
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/trees"
+	"github.com/emirpasic/gods/utils"
+	"math/rand"
 	"testing"
 )
 
@@ -556,6 +565,250 @@ func TestAVLTreeIteratorLast(t *testing.T) {
 	}
 }
 
+func TestAVLTreeBulkLoad(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		keys := make([]interface{}, n)
+		values := make([]interface{}, n)
+		for i := range keys {
+			keys[i], values[i] = i, -i
+		}
+		tree := NewWithIntComparator()
+		tree.Put(-1, 1)
+		tree.BulkLoad(keys, values)
+		if actualValue, expectedValue := tree.Size(), n; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got invalid tree of size %v: %v", n, err)
+		}
+		for i := 0; i < n; i++ {
+			if actualValue, found := tree.Get(i); actualValue != -i || !found {
+				t.Errorf("Got %v expected %v", actualValue, -i)
+			}
+		}
+		tree.Put(n, n)
+		tree.Remove(0)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got invalid tree after modifying bulk loaded tree of size %v: %v", n, err)
+		}
+	}
+
+	tree := NewWithIntComparator()
+	tree.BulkLoad([]interface{}{3, 1, 2, 1}, []interface{}{"c", "a", "b", "x"})
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[x b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestAVLTreeLoader(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree.Put(0, 0)
+	loader := tree.Loader()
+	for _, key := range []int{1, 2, 3, 5, 4, 6} {
+		if err := loader.Add(key, -key); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	if err := loader.Add("7", 7); err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[0]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	loader.Load()
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got invalid tree: %v", err)
+	}
+}
+
+func TestAVLTreeClone(t *testing.T) {
+	tree := NewWithStringComparator()
+	for _, key := range []string{"d", "b", "f", "a", "c", "e", "g"} {
+		tree.Put(key, key)
+	}
+
+	clone := tree.Clone()
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), tree.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Put("a", "x")
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(tree.Clone(), tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestAVLTreeSplitAndJoin(t *testing.T) {
+	for n := 0; n < 50; n++ {
+		for _, key := range []int{-1, 0, n / 3, n / 2, n - 1, n} {
+			tree := NewWithIntComparator()
+			for i := 0; i < n; i++ {
+				tree.Put(i, i)
+			}
+			left, right := tree.Split(key)
+			if actualValue, expectedValue := tree.Empty(), true; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			for _, half := range []*Tree{left, right} {
+				if err := half.Validate(); err != nil {
+					t.Errorf("Got error %v after splitting %v elements at %v", err, n, key)
+				}
+			}
+			expectedLeft := key
+			if expectedLeft < 0 {
+				expectedLeft = 0
+			} else if expectedLeft > n {
+				expectedLeft = n
+			}
+			if actualValue, expectedValue := left.Size(), expectedLeft; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			if actualValue, expectedValue := right.Size(), n-expectedLeft; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			if left.Size() > 0 && left.Right().Key.(int) >= key {
+				t.Errorf("Got %v expected less than %v", left.Right().Key, key)
+			}
+			if right.Size() > 0 && right.Left().Key.(int) < key {
+				t.Errorf("Got %v expected at least %v", right.Left().Key, key)
+			}
+
+			joined := Join(left, right)
+			if err := joined.Validate(); err != nil {
+				t.Errorf("Got error %v after joining %v elements split at %v", err, n, key)
+			}
+			if actualValue, expectedValue := joined.Size(), n; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			if actualValue, expectedValue := left.Empty() && right.Empty(), true; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			for i, key := range joined.Keys() {
+				if key != i {
+					t.Errorf("Got %v expected %v", key, i)
+				}
+			}
+		}
+	}
+
+	// trees of very different heights
+	for _, sizes := range [][2]int{{1, 1000}, {1000, 1}, {3, 500}, {500, 3}, {0, 10}, {10, 0}} {
+		left, right := NewWithIntComparator(), NewWithIntComparator()
+		for i := 0; i < sizes[0]; i++ {
+			left.Put(i, i)
+		}
+		for i := 0; i < sizes[1]; i++ {
+			right.Put(sizes[0]+i, i)
+		}
+		joined := Join(left, right)
+		if err := joined.Validate(); err != nil {
+			t.Errorf("Got error %v after joining trees of sizes %v", err, sizes)
+		}
+		if actualValue, expectedValue := joined.Size(), sizes[0]+sizes[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	// overlapping keys
+	left, right := NewWithIntComparator(), NewWithIntComparator()
+	left.Put(1, "a")
+	left.Put(3, "c")
+	right.Put(2, "b")
+	right.Put(3, "x")
+	right.Put(4, "d")
+	joined := Join(left, right)
+	if err := joined.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(joined.Keys()), "[1 2 3 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(joined.Values()), "[a b x d]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := left.Size()+right.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestAVLTreeSetOperations(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		first, second := NewWithIntComparator(), NewWithIntComparator()
+		firstKeys, secondKeys := make(map[int]bool), make(map[int]bool)
+		// sizes and key ranges vary to get trees of very different heights and overlaps
+		for n, max := random.Intn(300), 1+random.Intn(500); n > 0; n-- {
+			key := random.Intn(max)
+			first.Put(key, "first")
+			firstKeys[key] = true
+		}
+		for n, max := random.Intn(300), 1+random.Intn(500); n > 0; n-- {
+			key := random.Intn(max)
+			second.Put(key, "second")
+			secondKeys[key] = true
+		}
+
+		check := func(operation string, tree *Tree, expected func(key int) (string, bool)) {
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got error %v after %v", err, operation)
+			}
+			size := 0
+			for key := 0; key < 500; key++ {
+				expectedValue, expectedFound := expected(key)
+				if expectedFound {
+					size++
+				}
+				if actualValue, actualFound := tree.Get(key); actualFound != expectedFound || (expectedFound && actualValue != expectedValue) {
+					t.Errorf("Got %v,%v expected %v,%v for key %v after %v", actualValue, actualFound, expectedValue, expectedFound, key, operation)
+				}
+			}
+			if actualValue, expectedValue := tree.Size(), size; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v after %v", actualValue, expectedValue, operation)
+			}
+		}
+
+		check("union", Union(first.Clone(), second.Clone()), func(key int) (string, bool) {
+			if secondKeys[key] {
+				return "second", true
+			}
+			return "first", firstKeys[key]
+		})
+		check("intersection", Intersection(first.Clone(), second.Clone()), func(key int) (string, bool) {
+			return "second", firstKeys[key] && secondKeys[key]
+		})
+		check("difference", Difference(first.Clone(), second.Clone()), func(key int) (string, bool) {
+			return "first", firstKeys[key] && !secondKeys[key]
+		})
+
+		key := random.Intn(500)
+		left, right := first.Split(key)
+		if err := left.Validate(); err != nil {
+			t.Errorf("Got error %v after split", err)
+		}
+		if err := right.Validate(); err != nil {
+			t.Errorf("Got error %v after split", err)
+		}
+		check("split and join", Join(left, right), func(key int) (string, bool) {
+			return "first", firstKeys[key]
+		})
+		if actualValue, expectedValue := first.Size()+second.Size()-second.Size(), 0; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
 func TestAVLTreeSerialization(t *testing.T) {
 	tree := NewWithStringComparator()
 	tree.Put("c", "3")
@@ -587,6 +840,194 @@ func TestAVLTreeSerialization(t *testing.T) {
 	assert()
 }
 
+func TestAVLTreeMarshalJSON(t *testing.T) {
+	type document struct {
+		Tree *Tree `json:"tree"`
+	}
+	tree := NewWithStringComparator()
+	tree.Put("a", "1")
+	tree.Put("b", "2")
+
+	data, err := json.Marshal(&document{Tree: tree})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := tree.ToJSON()
+	if actualValue, expectedValue := string(data), `{"tree":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Tree: NewWithStringComparator()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Tree.Size(), tree.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Tree.Values()), fmt.Sprint(tree.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = json.Unmarshal(data, &document{})
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Tree).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestAVLTreeBinarySerialization(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+
+	assert := func(restored *Tree, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), tree.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, key := range tree.Keys() {
+			if actualValue, expectedValue := restored.Keys()[i], key; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+		for i, value := range tree.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := tree.MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = tree.MarshalBinaryWith(utils.IntCodec, utils.StringCodec)
+	restored = NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec, utils.StringCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Tree *Tree
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Tree: tree})
+	doc := &document{Tree: NewWithIntComparator()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Tree, err)
+}
+
+func TestAVLTreeStreaming(t *testing.T) {
+	tree := NewWithStringComparator()
+	tree.Put("b", "2")
+	tree.Put("a", "1")
+	tree.Put("c", "3")
+
+	assert := func(restored *Tree, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(tree.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := tree.WriteJSON(buffer)
+	restored := NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = tree.WriteBinary(buffer)
+	restored = NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
+func TestAVLTreeSerializationWithDecoders(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree.Put(3, 30)
+	tree.Put(1, 10)
+	tree.Put(2, 20)
+
+	json, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(json), "[[1,10],[2,20],[3,30]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Clear()
+	err = tree.FromJSONWith(json, utils.IntDecoder, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := tree.Get(2); actualValue != 20 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 20)
+	}
+
+	err = tree.FromJSONWith([]byte(`[[4,40],[5]]`), utils.IntDecoder, utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for malformed input")
+	}
+	err = tree.FromJSONWith([]byte(`[["x",40]]`), utils.IntDecoder, utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = tree.FromJSON(json)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	tree.SetDecoders(nil, nil)
+	err = tree.FromJSON(json)
+	if err == nil {
+		t.Errorf("Got no error for keys decoded as float64")
+	}
+	if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// object of earlier versions
+	err = tree.FromJSONWith([]byte(`{"4":40,"5":50}`), utils.IntDecoder, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := tree.Get(5); actualValue != 50 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 50)
+	}
+}
+
 func benchmarkGet(b *testing.B, tree *Tree, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -611,6 +1052,110 @@ func benchmarkRemove(b *testing.B, tree *Tree, size int) {
 	}
 }
 
+func TestAVLTreeToDOT(t *testing.T) {
+	tree := NewWithIntComparator()
+	for i := 1; i <= 4; i++ {
+		tree.Put(i, "x")
+	}
+	var buffer bytes.Buffer
+	if err := tree.ToDOT(&buffer, nil); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `digraph "tree" {
+	ordering=out;
+	n0 [label="2\n1"];
+	n1 [label="1\n0"];
+	n0 -> n1;
+	n2 [label="3\n1"];
+	n3 [style=invis];
+	n2 -> n3 [style=invis];
+	n4 [label="4\n0"];
+	n2 -> n4;
+	n0 -> n2;
+}
+`
+	if actualValue := buffer.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	tree = NewWithStringComparator()
+	tree.Put(`k"1"`, "v\n1")
+	buffer.Reset()
+	options := &trees.DOTOptions{Name: `a "b"`, Values: true, Format: func(element interface{}) string { return element.(string) }}
+	if err := tree.ToDOT(&buffer, options); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected = `digraph "a \"b\"" {
+	ordering=out;
+	n0 [label="k\"1\": v\n1\n0"];
+}
+`
+	if actualValue := buffer.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	tree.Clear()
+	buffer.Reset()
+	if err := tree.ToDOT(&buffer, nil); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected = `digraph "tree" {
+	ordering=out;
+}
+`
+	if actualValue := buffer.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+}
+
+func TestAVLTreeValidate(t *testing.T) {
+	tree := NewWithIntComparator()
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		tree.Put(i*37%101, i)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v after putting %v", err, i*37%101)
+		}
+	}
+	for i := 0; i < 100; i += 2 {
+		tree.Remove(i * 37 % 101)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v after removing %v", err, i*37%101)
+		}
+	}
+
+	corruptions := []func(tree *Tree){
+		func(tree *Tree) { tree.Root.Parent = tree.Root.Children[0] },
+		func(tree *Tree) { tree.Root.Children[0].Parent = nil },
+		func(tree *Tree) { tree.Root.Children[0].Key = tree.Root.Children[1].Key },
+		func(tree *Tree) { tree.Root.b++ },
+		func(tree *Tree) { tree.Root.Children[0], tree.Root.b = nil, 2 },
+		func(tree *Tree) { tree.size-- },
+	}
+	for i, corrupt := range corruptions {
+		clone := tree.Clone()
+		corrupt(clone)
+		if err := clone.Validate(); err == nil {
+			t.Errorf("Got no error for corruption %v", i)
+		}
+	}
+}
+
+func TestAVLTreeConformance(t *testing.T) {
+	containertest.TestMap(t, func() maps.Map { return NewWithIntComparator() })
+	for _, keys := range [][]interface{}{{}, {1}, {1, 2, 3}} {
+		tree := NewWithIntComparator()
+		values := []interface{}{}
+		for _, key := range keys {
+			tree.Put(key, -key.(int))
+			values = append(values, -key.(int))
+		}
+		containertest.TestReverseIteratorWithKey(t, tree.Iterator(), keys, values)
+	}
+}
+
 func BenchmarkAVLTreeGet100(b *testing.B) {
 	b.StopTimer()
 	size := 100
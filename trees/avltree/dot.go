@@ -0,0 +1,71 @@
+// Copyright (c) 2017, Benjamin Scher Purcell. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"io"
+	"strings"
+)
+
+// ToDOT writes the tree into the writer as a Graphviz DOT graph with nodes labeled by their balance factors
+// (height of the right subtree minus height of the left one).
+// If a node has only one child, the missing one is drawn as an invisible node to keep left and right children apart.
+func (t *Tree) ToDOT(w io.Writer, options *trees.DOTOptions) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "digraph %s {\n", dotGraphName(options))
+	fmt.Fprintln(writer, "\tordering=out;")
+	if t.Root != nil {
+		id := 0
+		outputDOT(writer, t.Root, &id, options)
+	}
+	fmt.Fprintln(writer, "}")
+	return writer.Flush()
+}
+
+// outputDOT writes the subtree rooted at the node, numbering nodes in pre-order starting from id, and returns the node's number.
+func outputDOT(writer *bufio.Writer, node *Node, id *int, options *trees.DOTOptions) int {
+	n := *id
+	*id++
+	fmt.Fprintf(writer, "\tn%d [label=\"%s\\n%d\"];\n", n, dotLabel(options, node.Key, node.Value), node.b)
+	if node.Children[0] == nil && node.Children[1] == nil {
+		return n
+	}
+	for _, child := range node.Children {
+		if child != nil {
+			fmt.Fprintf(writer, "\tn%d -> n%d;\n", n, outputDOT(writer, child, id, options))
+		} else {
+			fmt.Fprintf(writer, "\tn%d [style=invis];\n\tn%d -> n%d [style=invis];\n", *id, n, *id)
+			*id++
+		}
+	}
+	return n
+}
+
+// dotEscaper escapes characters with special meaning in quoted DOT strings
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", "")
+
+// dotGraphName returns the quoted name of the graph, "tree" if the options name none.
+func dotGraphName(options *trees.DOTOptions) string {
+	if options == nil || options.Name == "" {
+		return `"tree"`
+	}
+	return `"` + dotEscaper.Replace(options.Name) + `"`
+}
+
+// dotLabel returns the text of the label of a node with the key and value, escaped to be used within a quoted DOT string.
+func dotLabel(options *trees.DOTOptions, key interface{}, value interface{}) string {
+	format := func(element interface{}) string { return fmt.Sprint(element) }
+	if options != nil && options.Format != nil {
+		format = options.Format
+	}
+	label := format(key)
+	if options != nil && options.Values {
+		label += ": " + format(value)
+	}
+	return dotEscaper.Replace(label)
+}
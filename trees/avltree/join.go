@@ -0,0 +1,304 @@
+// Copyright (c) 2017, Benjamin Scher Purcell. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "github.com/emirpasic/gods/utils"
+
+// Split moves the nodes with keys smaller than the key into the left tree and the remaining ones into the right tree,
+// leaving the tree empty. Both trees have the comparator of the tree.
+// The tree is split in O(log n) time, counting the elements of the smaller tree takes time linear in its size.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (t *Tree) Split(key interface{}) (left *Tree, right *Tree) {
+	l, _, equal, r, rh := t.split(t.Root, height(t.Root), key)
+	if equal != nil {
+		r, _ = join(nil, 0, equal, r, rh)
+	}
+	left, right = newTree(t.Comparator, l, 0), newTree(t.Comparator, r, 0)
+	count(left, right, t.size)
+	t.Clear()
+	return left, right
+}
+
+// Join moves the nodes of both trees into a new tree with the comparator of the left tree, leaving them empty.
+// If all keys of the left tree are smaller than the keys of the right tree, the trees are joined in O(log n) time,
+// otherwise the elements of the right tree are put into the left tree one by one, replacing values of equal keys.
+func Join(left *Tree, right *Tree) *Tree {
+	var t *Tree
+	if left.Empty() || right.Empty() || left.Comparator(left.Right().Key, right.Left().Key) < 0 {
+		root, _ := join2(left.Root, height(left.Root), right.Root, height(right.Root))
+		t = newTree(left.Comparator, root, left.size+right.size)
+	} else {
+		t = newTree(left.Comparator, left.Root, left.size)
+		it := right.Iterator()
+		for it.Next() {
+			t.Put(it.Key(), it.Value())
+		}
+	}
+	left.Clear()
+	right.Clear()
+	return t
+}
+
+// Union moves the elements of both trees into a new tree with the comparator of the first tree, leaving them empty.
+// Keys found in both trees are taken with their values from the second tree.
+// Subtrees are merged by splitting and joining them, so that merging trees of m and n ≥ m elements takes
+// O(m log(n/m + 1)) joins, which is much faster than inserting the elements of one tree into the other.
+func Union(first *Tree, second *Tree) *Tree {
+	root, _, common := first.union(first.Root, height(first.Root), second.Root, height(second.Root))
+	t := newTree(first.Comparator, root, first.size+second.size-common)
+	first.Clear()
+	second.Clear()
+	return t
+}
+
+// Intersection moves the elements of the first tree whose keys are found in the second tree into a new tree
+// with the comparator of the first tree, leaving both trees empty.
+// Keys are taken with their values from the second tree. Subtrees are merged by splitting and joining them (see Union).
+func Intersection(first *Tree, second *Tree) *Tree {
+	root, _, size := first.intersection(first.Root, height(first.Root), second.Root, height(second.Root))
+	t := newTree(first.Comparator, root, size)
+	first.Clear()
+	second.Clear()
+	return t
+}
+
+// Difference moves the elements of the first tree whose keys are not found in the second tree into a new tree
+// with the comparator of the first tree, leaving both trees empty.
+// Subtrees are merged by splitting and joining them (see Union).
+func Difference(first *Tree, second *Tree) *Tree {
+	root, _, removed := first.difference(first.Root, height(first.Root), second.Root, height(second.Root))
+	t := newTree(first.Comparator, root, first.size-removed)
+	first.Clear()
+	second.Clear()
+	return t
+}
+
+// split splits the subtree of height h rooted at n into the subtree with keys smaller than the key,
+// the node with the key, if any, and the subtree with keys larger than the key, returning the subtrees with their heights.
+func (t *Tree) split(n *Node, h int, key interface{}) (*Node, int, *Node, *Node, int) {
+	if n == nil {
+		return nil, 0, nil, nil, 0
+	}
+	lh, rh := n.childHeights(h)
+	l, r := n.detach()
+	c := t.Comparator(key, n.Key)
+	switch {
+	case c == 0:
+		return l, lh, n, r, rh
+	case c < 0:
+		ll, llh, equal, lr, lrh := t.split(l, lh, key)
+		r, rh = join(lr, lrh, n, r, rh)
+		return ll, llh, equal, r, rh
+	default:
+		rl, rlh, equal, rr, rrh := t.split(r, rh, key)
+		l, lh = join(l, lh, n, rl, rlh)
+		return l, lh, equal, rr, rrh
+	}
+}
+
+// union merges the subtrees of heights h1 and h2 and returns the merged subtree with its height and the number of common keys.
+func (t *Tree) union(n1 *Node, h1 int, n2 *Node, h2 int) (*Node, int, int) {
+	if n1 == nil {
+		return n2, h2, 0
+	}
+	if n2 == nil {
+		return n1, h1, 0
+	}
+	lh1, rh1 := n1.childHeights(h1)
+	l1, r1 := n1.detach()
+	l2, lh2, equal, r2, rh2 := t.split(n2, h2, n1.Key)
+	common := 0
+	if equal != nil {
+		n1.Key, n1.Value, common = equal.Key, equal.Value, 1
+	}
+	l, lh, lc := t.union(l1, lh1, l2, lh2)
+	r, rh, rc := t.union(r1, rh1, r2, rh2)
+	n, h := join(l, lh, n1, r, rh)
+	return n, h, common + lc + rc
+}
+
+// intersection merges the subtrees of heights h1 and h2 and returns the subtree of their common keys with its height and size.
+func (t *Tree) intersection(n1 *Node, h1 int, n2 *Node, h2 int) (*Node, int, int) {
+	if n1 == nil || n2 == nil {
+		return nil, 0, 0
+	}
+	lh1, rh1 := n1.childHeights(h1)
+	l1, r1 := n1.detach()
+	l2, lh2, equal, r2, rh2 := t.split(n2, h2, n1.Key)
+	l, lh, ls := t.intersection(l1, lh1, l2, lh2)
+	r, rh, rs := t.intersection(r1, rh1, r2, rh2)
+	if equal == nil {
+		n, h := join2(l, lh, r, rh)
+		return n, h, ls + rs
+	}
+	n1.Key, n1.Value = equal.Key, equal.Value
+	n, h := join(l, lh, n1, r, rh)
+	return n, h, ls + rs + 1
+}
+
+// difference merges the subtrees of heights h1 and h2 and returns the subtree of the keys of the first one
+// that are not found in the second one with its height and the number of removed keys.
+func (t *Tree) difference(n1 *Node, h1 int, n2 *Node, h2 int) (*Node, int, int) {
+	if n1 == nil {
+		return nil, 0, 0
+	}
+	if n2 == nil {
+		return n1, h1, 0
+	}
+	lh2, rh2 := n2.childHeights(h2)
+	l2, r2 := n2.detach()
+	l1, lh1, equal, r1, rh1 := t.split(n1, h1, n2.Key)
+	l, lh, lr := t.difference(l1, lh1, l2, lh2)
+	r, rh, rr := t.difference(r1, rh1, r2, rh2)
+	removed := lr + rr
+	if equal != nil {
+		removed++
+	}
+	n, h := join2(l, lh, r, rh)
+	return n, h, removed
+}
+
+// join joins the subtrees l and r of heights lh and rh through the node n, whose key is between the keys of the subtrees,
+// and returns the joined subtree with its height.
+func join(l *Node, lh int, n *Node, r *Node, rh int) (*Node, int) {
+	n.Parent = nil
+	switch {
+	case lh > rh+1:
+		return joinSide(1, l, lh, n, r, rh)
+	case rh > lh+1:
+		return joinSide(0, r, rh, n, l, lh)
+	}
+	n.Children[0], n.Children[1] = l, r
+	n.attach()
+	n.b = int8(rh - lh)
+	if lh > rh {
+		return n, lh + 1
+	}
+	return n, rh + 1
+}
+
+// joinSide joins the shorter subtree s of height sh through the node n into the taller subtree q of height qh,
+// descending the spine of q in direction a (1 for right) to the first subtree of height at most sh+1.
+// The subtree is replaced by n with the subtree and s as its children, which grows its height by one,
+// and the tree is rebalanced upwards as after an insertion.
+func joinSide(a int, q *Node, qh int, n *Node, s *Node, sh int) (*Node, int) {
+	c := int8(2*a - 1)
+	var parent *Node
+	p, h := q, qh
+	for h > sh+1 {
+		if p.b == -c {
+			h -= 2
+		} else {
+			h--
+		}
+		parent, p = p, p.Children[a]
+	}
+	n.Children[a^1], n.Children[a] = p, s
+	n.attach()
+	n.b = c * int8(sh-h)
+	parent.Children[a], n.Parent = n, parent
+
+	root := q
+	for child := n; child.Parent != nil; {
+		qp := &root
+		if grandparent := child.Parent.Parent; grandparent != nil {
+			qp = &grandparent.Children[a]
+		}
+		if !putFix(c, qp) {
+			return root, qh
+		}
+		child = *qp
+	}
+	return root, qh + 1
+}
+
+// join2 joins the subtrees l and r of heights lh and rh, whose keys are all smaller in l than in r,
+// through the minimum of r and returns the joined subtree with its height.
+func join2(l *Node, lh int, r *Node, rh int) (*Node, int) {
+	if r == nil {
+		return l, lh
+	}
+	r, rh, min := splitMin(r, rh)
+	return join(l, lh, min, r, rh)
+}
+
+// splitMin removes the minimum from the subtree of height h rooted at n
+// and returns the rest of the subtree with its height and the minimum.
+func splitMin(n *Node, h int) (*Node, int, *Node) {
+	lh, rh := n.childHeights(h)
+	l, r := n.detach()
+	if l == nil {
+		return r, rh, n
+	}
+	l, lh, min := splitMin(l, lh)
+	n, h = join(l, lh, n, r, rh)
+	return n, h, min
+}
+
+// height returns the height of the subtree rooted at n, following its taller children.
+func height(n *Node) int {
+	h := 0
+	for ; n != nil; h++ {
+		if n.b < 0 {
+			n = n.Children[0]
+		} else {
+			n = n.Children[1]
+		}
+	}
+	return h
+}
+
+// childHeights returns the heights of the children of the node of height h.
+func (n *Node) childHeights(h int) (int, int) {
+	switch {
+	case n.b < 0:
+		return h - 1, h - 2
+	case n.b > 0:
+		return h - 2, h - 1
+	}
+	return h - 1, h - 1
+}
+
+// detach removes the children of the node and returns them as roots of their subtrees.
+func (n *Node) detach() (*Node, *Node) {
+	l, r := n.Children[0], n.Children[1]
+	n.Children[0], n.Children[1] = nil, nil
+	for _, c := range []*Node{l, r} {
+		if c != nil {
+			c.Parent = nil
+		}
+	}
+	return l, r
+}
+
+// attach makes the node the parent of its children.
+func (n *Node) attach() {
+	for _, c := range n.Children {
+		if c != nil {
+			c.Parent = n
+		}
+	}
+}
+
+// newTree returns a tree of the size rooted at the node.
+func newTree(comparator utils.Comparator, root *Node, size int) *Tree {
+	return &Tree{Root: root, Comparator: comparator, size: size}
+}
+
+// count sets the sizes of the trees, which have the size elements together, by counting the elements of the smaller one.
+func count(left *Tree, right *Tree, size int) {
+	li, ri := left.Iterator(), right.Iterator()
+	ri.End()
+	for n := 0; ; n++ {
+		if !li.Next() {
+			left.size, right.size = n, size-n
+			return
+		}
+		if !ri.Prev() {
+			left.size, right.size = size-n, n
+			return
+		}
+	}
+}
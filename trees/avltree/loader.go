@@ -0,0 +1,96 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import "github.com/emirpasic/gods/utils"
+
+// Loader replaces the elements of a tree with elements added one at a time, e.g. while they are decoded,
+// leaving the tree unchanged until Load is called.
+//
+// While keys are added in strictly ascending order, their nodes are only linked in a list, from which the balanced
+// tree is built in linear time. From the first key that is out of order on, elements are inserted one by one.
+type Loader struct {
+	tree   *Tree       // tree whose elements are replaced
+	loaded *Tree       // tree of the added elements, once a key was out of order
+	first  *Node       // first node of the list, linked to the next ones by their right children
+	last   *Node       // last node of the list
+	size   int         // number of nodes in the list
+	key    interface{} // key of the last added element
+	sorted bool        // true while keys are added in strictly ascending order
+}
+
+// Loader returns a loader replacing tree's elements (see BulkLoad and ReadJSON).
+func (t *Tree) Loader() *Loader {
+	return &Loader{tree: t, loaded: &Tree{Comparator: t.Comparator}, sorted: true}
+}
+
+// Add adds the element to the elements being loaded.
+// Returns an error if the comparator can not compare the key with the key of the previously added element.
+func (l *Loader) Add(key interface{}, value interface{}) error {
+	previous := key
+	if l.last != nil || !l.sorted {
+		previous = l.key
+	}
+	cmp, err := utils.CompareKeys(l.loaded.Comparator, previous, key)
+	if err != nil {
+		return err
+	}
+	l.key = key
+	if l.sorted && (l.last == nil || cmp < 0) {
+		n := &Node{Key: key, Value: value}
+		if l.last == nil {
+			l.first = n
+		} else {
+			l.last.Children[1] = n
+		}
+		l.last = n
+		l.size++
+		return nil
+	}
+	if l.sorted {
+		l.build()
+	}
+	l.loaded.Put(key, value)
+	return nil
+}
+
+// Load replaces tree's elements with the added elements.
+func (l *Loader) Load() {
+	if l.sorted {
+		l.build()
+	}
+	l.tree.Root = l.loaded.Root
+	l.tree.size = l.loaded.size
+}
+
+// build builds the balanced tree from the list.
+func (l *Loader) build() {
+	l.loaded.Root, _ = l.subtree(l.size)
+	l.loaded.size = l.size
+	l.first, l.last, l.size = nil, nil, 0
+	l.sorted = false
+}
+
+// subtree builds the balanced subtree of the next size nodes of the list and returns it with its height.
+func (l *Loader) subtree(size int) (*Node, int) {
+	if size == 0 {
+		return nil, 0
+	}
+	left, lh := l.subtree(size / 2)
+	q := l.first
+	l.first = q.Children[1]
+	right, rh := l.subtree(size - size/2 - 1)
+	q.Children[0], q.Children[1] = left, right
+	for _, c := range q.Children {
+		if c != nil {
+			c.Parent = q
+		}
+	}
+	q.b = int8(rh - lh)
+	if lh > rh {
+		return q, lh + 1
+	}
+	return q, rh + 1
+}
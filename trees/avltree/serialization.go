@@ -5,35 +5,170 @@
 package avltree
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"github.com/emirpasic/gods/containers"
 	"github.com/emirpasic/gods/utils"
+	"io"
 )
 
 func assertSerializationImplementation() {
 	var _ containers.JSONSerializer = (*Tree)(nil)
 	var _ containers.JSONDeserializer = (*Tree)(nil)
+	var _ json.Marshaler = (*Tree)(nil)
+	var _ json.Unmarshaler = (*Tree)(nil)
+	var _ encoding.BinaryMarshaler = (*Tree)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Tree)(nil)
+	var _ gob.GobEncoder = (*Tree)(nil)
+	var _ gob.GobDecoder = (*Tree)(nil)
 }
 
-// ToJSON outputs the JSON representation of list's elements.
+// errNoComparator is returned when elements are decoded into a tree that was not instantiated by a constructor,
+// e.g. the zero value of a field being unmarshalled, since the tree can not order the keys without a comparator.
+var errNoComparator = errors.New("avltree: can not decode into a tree without comparator, instantiate it with NewWith")
+
+// ToJSON outputs the JSON representation of tree's elements as an array of [key, value] pairs in key order.
 func (tree *Tree) ToJSON() ([]byte, error) {
-	elements := make(map[string]interface{})
+	elements := make([][2]interface{}, 0, tree.Size())
 	it := tree.Iterator()
 	for it.Next() {
-		elements[utils.ToString(it.Key())] = it.Value()
+		elements = append(elements, [2]interface{}{it.Key(), it.Value()})
 	}
 	return json.Marshal(&elements)
 }
 
-// FromJSON populates list's elements from the input JSON representation.
+// FromJSON populates tree's elements from the input JSON representation.
+// Keys and values are decoded with the tree's decoders (see SetDecoders and FromJSONWith for other decoders).
 func (tree *Tree) FromJSON(data []byte) error {
-	elements := make(map[string]interface{})
-	err := json.Unmarshal(data, &elements)
-	if err == nil {
-		tree.Clear()
-		for key, value := range elements {
-			tree.Put(key, value)
+	return tree.FromJSONWith(data, tree.keyDecoder, tree.valueDecoder)
+}
+
+// FromJSONWith populates tree's elements from the input JSON representation,
+// decoding keys and values with the given decoders (nil for Go's default JSON decoding).
+// Tree is not modified if the input can not be decoded.
+func (tree *Tree) FromJSONWith(data []byte, keyDecoder utils.Decoder, valueDecoder utils.Decoder) error {
+	return tree.ReadJSONWith(bytes.NewReader(data), keyDecoder, valueDecoder)
+}
+
+// SetDecoders sets the decoders of keys and values used by FromJSON, ReadJSON and UnmarshalJSON (nil for Go's default JSON decoding),
+// e.g. for unmarshalling a field holding the tree with json.Unmarshal.
+// Trees instantiated with NewWithIntComparator or NewWithStringComparator decode keys with the IntDecoder or StringDecoder.
+func (tree *Tree) SetDecoders(keyDecoder utils.Decoder, valueDecoder utils.Decoder) {
+	tree.keyDecoder = keyDecoder
+	tree.valueDecoder = valueDecoder
+}
+
+// WriteJSON writes the JSON representation of tree's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (tree *Tree) WriteJSON(w io.Writer) error {
+	it := tree.Iterator()
+	return utils.WriteJSONPairs(w, func() (interface{}, interface{}, bool) {
+		if !it.Next() {
+			return nil, nil, false
 		}
+		return it.Key(), it.Value(), true
+	})
+}
+
+// ReadJSON populates tree's elements from the JSON representation read from the reader (see FromJSON).
+func (tree *Tree) ReadJSON(r io.Reader) error {
+	return tree.ReadJSONWith(r, tree.keyDecoder, tree.valueDecoder)
+}
+
+// ReadJSONWith populates tree's elements from the JSON representation read from the reader (see FromJSONWith).
+// Sorted input is loaded without rebalancing (see BulkLoad).
+func (tree *Tree) ReadJSONWith(r io.Reader, keyDecoder utils.Decoder, valueDecoder utils.Decoder) error {
+	if tree.Comparator == nil {
+		return errNoComparator
+	}
+	loader := tree.Loader()
+	if err := utils.ReadJSONPairs(r, keyDecoder, valueDecoder, loader.Add); err != nil {
+		return err
 	}
-	return err
+	loader.Load()
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of tree's elements (implements json.Marshaler).
+func (tree *Tree) MarshalJSON() ([]byte, error) {
+	return tree.ToJSON()
+}
+
+// UnmarshalJSON populates tree's elements from the input JSON representation (implements json.Unmarshaler).
+func (tree *Tree) UnmarshalJSON(data []byte) error {
+	return tree.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of tree's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (tree *Tree) MarshalBinary() ([]byte, error) {
+	return tree.MarshalBinaryWith(nil, nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of tree's elements,
+// encoding keys and values with the given codecs (nil for gob).
+func (tree *Tree) MarshalBinaryWith(keyCodec utils.Codec, valueCodec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := tree.WriteBinaryWith(buffer, keyCodec, valueCodec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates tree's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (tree *Tree) UnmarshalBinary(data []byte) error {
+	return tree.UnmarshalBinaryWith(data, nil, nil)
+}
+
+// UnmarshalBinaryWith populates tree's elements from the input binary representation,
+// decoding keys and values with the given codecs, which must be the ones the elements were encoded with.
+// Tree is not modified if the input can not be decoded.
+func (tree *Tree) UnmarshalBinaryWith(data []byte, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	return tree.ReadBinaryWith(bytes.NewReader(data), keyCodec, valueCodec)
+}
+
+// WriteBinary writes the binary representation of tree's elements (see MarshalBinary) into the writer.
+func (tree *Tree) WriteBinary(w io.Writer) error {
+	return tree.WriteBinaryWith(w, nil, nil)
+}
+
+// WriteBinaryWith writes the binary representation of tree's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (tree *Tree) WriteBinaryWith(w io.Writer, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	it := tree.Iterator()
+	return utils.WriteBinaryPairs(w, tree.Size(), func() (interface{}, interface{}) {
+		it.Next()
+		return it.Key(), it.Value()
+	}, keyCodec, valueCodec)
+}
+
+// ReadBinary populates tree's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (tree *Tree) ReadBinary(r io.Reader) error {
+	return tree.ReadBinaryWith(r, nil, nil)
+}
+
+// ReadBinaryWith populates tree's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+// Sorted input is loaded without rebalancing (see BulkLoad).
+func (tree *Tree) ReadBinaryWith(r io.Reader, keyCodec utils.Codec, valueCodec utils.Codec) error {
+	if tree.Comparator == nil {
+		return errNoComparator
+	}
+	loader := tree.Loader()
+	if err := utils.ReadBinaryPairs(r, keyCodec, valueCodec, loader.Add); err != nil {
+		return err
+	}
+	loader.Load()
+	return nil
+}
+
+// GobEncode outputs the binary representation of tree's elements (implements gob.GobEncoder).
+func (tree *Tree) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
+}
+
+// GobDecode populates tree's elements from the input binary representation (implements gob.GobDecoder).
+func (tree *Tree) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
 }
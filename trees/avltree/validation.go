@@ -0,0 +1,61 @@
+// Copyright (c) 2017, Benjamin Scher Purcell. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package avltree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the structural invariants of the tree and returns an error describing the first violation found, if any.
+// It checks that keys are in strictly ascending order, that parent pointers are consistent, that balance factors
+// of nodes match the heights of their subtrees and are within [-1, 1], and that the size of the tree matches the number of its nodes.
+func (t *Tree) Validate() error {
+	if t.Root != nil && t.Root.Parent != nil {
+		return errors.New("avltree: root has a parent")
+	}
+	var previous *Node
+	count := 0
+	if _, err := t.validate(t.Root, nil, &previous, &count); err != nil {
+		return err
+	}
+	if count != t.size {
+		return fmt.Errorf("avltree: size is %d, but tree has %d nodes", t.size, count)
+	}
+	return nil
+}
+
+// validate checks the subtree rooted at the node attached to the parent and returns its height.
+// Nodes are visited in-order, previous is the last visited node and count the number of visited nodes.
+func (t *Tree) validate(n *Node, p *Node, previous **Node, count *int) (int, error) {
+	if n == nil {
+		return 0, nil
+	}
+	if n.Parent != p {
+		return 0, fmt.Errorf("avltree: node %v has a wrong parent", n.Key)
+	}
+	lh, err := t.validate(n.Children[0], n, previous, count)
+	if err != nil {
+		return 0, err
+	}
+	if *previous != nil && t.Comparator((*previous).Key, n.Key) >= 0 {
+		return 0, fmt.Errorf("avltree: keys %v and %v are out of order", (*previous).Key, n.Key)
+	}
+	*previous = n
+	*count++
+	rh, err := t.validate(n.Children[1], n, previous, count)
+	if err != nil {
+		return 0, err
+	}
+	if b := rh - lh; b < -1 || b > 1 {
+		return 0, fmt.Errorf("avltree: node %v is unbalanced with subtree heights %d on the left and %d on the right", n.Key, lh, rh)
+	} else if int(n.b) != b {
+		return 0, fmt.Errorf("avltree: node %v has balance factor %d, expected %d", n.Key, n.b, b)
+	}
+	if lh > rh {
+		return lh + 1, nil
+	}
+	return rh + 1, nil
+}
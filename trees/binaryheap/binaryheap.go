@@ -96,6 +96,11 @@ func (heap *Heap) Clear() {
 	heap.list.Clear()
 }
 
+// Clone returns a shallow copy of the heap with the same comparator (elements themselves are not copied).
+func (heap *Heap) Clone() *Heap {
+	return &Heap{list: heap.list.Clone(), Comparator: heap.Comparator}
+}
+
 // Values returns all elements in the heap.
 func (heap *Heap) Values() []interface{} {
 	return heap.list.Values()
@@ -5,6 +5,13 @@
 package binaryheap
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/utils"
 	"math/rand"
 	"testing"
 )
@@ -259,6 +266,27 @@ func TestBinaryHeapIteratorLast(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapClone(t *testing.T) {
+	heap := NewWithStringComparator()
+	heap.Push("c", "a", "b")
+
+	clone := heap.Clone()
+	if actualValue := containers.Equal(clone, heap, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), heap.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Push("x")
+	if actualValue := containers.Equal(clone, heap, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(heap.Clone(), heap, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestBinaryHeapSerialization(t *testing.T) {
 	heap := NewWithStringComparator()
 
@@ -291,6 +319,143 @@ func TestBinaryHeapSerialization(t *testing.T) {
 	assert()
 }
 
+func TestBinaryHeapMarshalJSON(t *testing.T) {
+	type document struct {
+		Heap *Heap `json:"heap"`
+	}
+	heap := NewWithStringComparator()
+	heap.Push("a", "b", "c")
+
+	data, err := json.Marshal(&document{Heap: heap})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := heap.ToJSON()
+	if actualValue, expectedValue := string(data), `{"heap":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Heap: NewWithStringComparator()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Heap.Size(), heap.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Heap.Values()), fmt.Sprint(heap.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = json.Unmarshal(data, &document{})
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Heap).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err = json.Marshal(&document{Heap: &Heap{}})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"heap":[]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	data, err = new(Heap).MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := restored.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBinaryHeapBinarySerialization(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(3, 1, 2)
+
+	assert := func(restored *Heap, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), heap.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, value := range heap.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := heap.MarshalBinary()
+	restored := NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = heap.MarshalBinaryWith(utils.IntCodec)
+	restored = NewWithIntComparator()
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Heap *Heap
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Heap: heap})
+	doc := &document{Heap: NewWithIntComparator()}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Heap, err)
+}
+
+func TestBinaryHeapStreaming(t *testing.T) {
+	heap := NewWithStringComparator()
+	heap.Push("c", "a", "b")
+
+	assert := func(restored *Heap, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(heap.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := heap.WriteJSON(buffer)
+	restored := NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = heap.WriteBinary(buffer)
+	restored = NewWithStringComparator()
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
 func benchmarkPush(b *testing.B, heap *Heap, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -307,6 +472,41 @@ func benchmarkPop(b *testing.B, heap *Heap, size int) {
 	}
 }
 
+func TestBinaryHeapValidate(t *testing.T) {
+	heap := NewWithIntComparator()
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		heap.Push(i * 37 % 101)
+		if err := heap.Validate(); err != nil {
+			t.Errorf("Got error %v after pushing %v", err, i*37%101)
+		}
+	}
+	heap.Push(5, 1, 3)
+	for !heap.Empty() {
+		heap.Pop()
+		if err := heap.Validate(); err != nil {
+			t.Errorf("Got error %v after popping", err)
+		}
+	}
+
+	heap.Push(1, 2, 3)
+	heap.list.Swap(0, 2)
+	if err := heap.Validate(); err == nil {
+		t.Errorf("Got no error for corrupted heap %v", heap.Values())
+	}
+}
+
+func TestBinaryHeapConformance(t *testing.T) {
+	for _, values := range [][]interface{}{{}, {1}, {3, 1, 2}} {
+		heap := NewWithIntComparator()
+		heap.Push(values...)
+		it := heap.Iterator()
+		containertest.TestReverseIteratorWithIndex(t, &it, heap.Values())
+	}
+}
+
 func BenchmarkBinaryHeapPop100(b *testing.B) {
 	b.StopTimer()
 	size := 100
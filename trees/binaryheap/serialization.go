@@ -4,19 +4,131 @@
 
 package binaryheap
 
-import "github.com/emirpasic/gods/containers"
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/lists/arraylist"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
 
 func assertSerializationImplementation() {
 	var _ containers.JSONSerializer = (*Heap)(nil)
 	var _ containers.JSONDeserializer = (*Heap)(nil)
+	var _ json.Marshaler = (*Heap)(nil)
+	var _ json.Unmarshaler = (*Heap)(nil)
+	var _ encoding.BinaryMarshaler = (*Heap)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Heap)(nil)
+	var _ gob.GobEncoder = (*Heap)(nil)
+	var _ gob.GobDecoder = (*Heap)(nil)
 }
 
+// errNoComparator is returned when elements are decoded into a heap that was not instantiated by a constructor,
+// e.g. the zero value of a field being unmarshalled, since the heap can not order the elements without a comparator.
+var errNoComparator = errors.New("binaryheap: can not decode into a heap without comparator, instantiate it with NewWith")
+
 // ToJSON outputs the JSON representation of list's elements.
 func (heap *Heap) ToJSON() ([]byte, error) {
-	return heap.list.ToJSON()
+	return heap.orEmpty().list.ToJSON()
 }
 
 // FromJSON populates list's elements from the input JSON representation.
 func (heap *Heap) FromJSON(data []byte) error {
+	if heap.list == nil {
+		return errNoComparator
+	}
 	return heap.list.FromJSON(data)
 }
+
+// WriteJSON writes the JSON representation of heap's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (heap *Heap) WriteJSON(w io.Writer) error {
+	return heap.orEmpty().list.WriteJSON(w)
+}
+
+// ReadJSON populates heap's elements from the JSON representation read from the reader (see FromJSON).
+func (heap *Heap) ReadJSON(r io.Reader) error {
+	if heap.list == nil {
+		return errNoComparator
+	}
+	return heap.list.ReadJSON(r)
+}
+
+// MarshalJSON outputs the JSON representation of heap's elements (implements json.Marshaler).
+func (heap *Heap) MarshalJSON() ([]byte, error) {
+	return heap.ToJSON()
+}
+
+// UnmarshalJSON populates heap's elements from the input JSON representation (implements json.Unmarshaler).
+func (heap *Heap) UnmarshalJSON(data []byte) error {
+	return heap.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of heap's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (heap *Heap) MarshalBinary() ([]byte, error) {
+	return heap.orEmpty().list.MarshalBinary()
+}
+
+// MarshalBinaryWith outputs the binary representation of heap's elements,
+// encoding each element with the given codec (nil for gob).
+func (heap *Heap) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	return heap.orEmpty().list.MarshalBinaryWith(codec)
+}
+
+// UnmarshalBinary populates heap's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (heap *Heap) UnmarshalBinary(data []byte) error {
+	return heap.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates heap's elements from the input binary representation,
+// decoding each element with the given codec, which must be the one the elements were encoded with.
+func (heap *Heap) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return heap.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of heap's elements (see MarshalBinary) into the writer.
+func (heap *Heap) WriteBinary(w io.Writer) error {
+	return heap.orEmpty().list.WriteBinary(w)
+}
+
+// WriteBinaryWith writes the binary representation of heap's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (heap *Heap) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	return heap.orEmpty().list.WriteBinaryWith(w, codec)
+}
+
+// ReadBinary populates heap's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (heap *Heap) ReadBinary(r io.Reader) error {
+	return heap.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates heap's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (heap *Heap) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	if heap.list == nil {
+		return errNoComparator
+	}
+	return heap.list.ReadBinaryWith(r, codec)
+}
+
+// GobEncode outputs the binary representation of heap's elements (implements gob.GobEncoder).
+func (heap *Heap) GobEncode() ([]byte, error) {
+	return heap.MarshalBinary()
+}
+
+// GobDecode populates heap's elements from the input binary representation (implements gob.GobDecoder).
+func (heap *Heap) GobDecode(data []byte) error {
+	return heap.UnmarshalBinary(data)
+}
+
+// orEmpty returns the heap, or an empty heap in place of a zero value heap (e.g. a field that was never instantiated),
+// so that writing a zero value heap outputs no elements.
+func (heap *Heap) orEmpty() *Heap {
+	if heap.list == nil {
+		return &Heap{list: arraylist.New()}
+	}
+	return heap
+}
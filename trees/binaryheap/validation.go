@@ -0,0 +1,21 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package binaryheap
+
+import "fmt"
+
+// Validate checks the heap property and returns an error describing the first violation found, if any,
+// i.e. an element that the comparator orders before its parent.
+func (heap *Heap) Validate() error {
+	for index := 1; index < heap.list.Size(); index++ {
+		parentIndex := (index - 1) >> 1
+		value, _ := heap.list.Get(index)
+		parent, _ := heap.list.Get(parentIndex)
+		if heap.Comparator(parent, value) > 0 {
+			return fmt.Errorf("binaryheap: element %v at index %d is ordered before its parent %v at index %d", value, index, parent, parentIndex)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,593 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bplustree implements a B+ tree.
+//
+// A B+ tree is a B-tree that keeps all entries in its leaves, while internal nodes only hold separator keys guiding
+// the search to the leaves. Leaves are linked to their left and right neighbours, so that entries are iterated in
+// order without climbing the tree, one leaf after another (e.g. for range scans).
+//
+// A B+ tree of order m satisfies the following properties:
+// - Every internal node has at most m children and every leaf at most m-1 entries.
+// - Every internal node (except root) has at least ⌈m/2⌉ children and every leaf (except root) at least ⌊m/2⌋ entries.
+// - The root has at least two children if it is not a leaf.
+// - An internal node with k children contains k−1 separator keys, the i-th of which is greater than all keys in
+// the i-th subtree and less than or equal to all keys in the (i+1)-th subtree.
+// - All leaves appear in the same level.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/B%2B_tree
+package bplustree
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"github.com/emirpasic/gods/utils"
+	"sort"
+	"strings"
+)
+
+func assertTreeImplementation() {
+	var _ trees.Tree = (*Tree)(nil)
+}
+
+// Tree holds elements of the B+ tree
+type Tree struct {
+	Comparator    utils.Comparator // Key comparator
+	keyDecoder    utils.Decoder    // decodes keys from JSON, nil for Go's default JSON decoding (see SetDecoders)
+	valueDecoder  utils.Decoder    // decodes values from JSON, nil for Go's default JSON decoding
+	root          *node
+	first         *node // left-most leaf
+	last          *node // right-most leaf
+	size          int   // Total number of entries in the tree
+	m             int   // order (maximum number of children)
+	modifications int   // Number of structural modifications, used by iterators to fail fast
+}
+
+// Entry represents the key-value pair contained within leaves
+type Entry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// node is an internal node with separator keys and children or a leaf with entries
+type node struct {
+	parent   *node
+	keys     []interface{} // separator keys of an internal node, keys[i] is the least key of the subtree children[i+1]
+	children []*node       // children of an internal node, nil for leaves
+	entries  []*Entry      // entries of a leaf in key order
+	prev     *node         // left neighbour of a leaf
+	next     *node         // right neighbour of a leaf
+}
+
+// NewWith instantiates a B+ tree with the order (maximum number of children) and a custom key comparator.
+func NewWith(order int, comparator utils.Comparator) *Tree {
+	if order < 3 {
+		panic("Invalid order, should be at least 3")
+	}
+	return &Tree{m: order, Comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a B+ tree with the order (maximum number of children) and the IntComparator, i.e. keys are of type int.
+func NewWithIntComparator(order int) *Tree {
+	tree := NewWith(order, utils.IntComparator)
+	tree.keyDecoder = utils.IntDecoder
+	return tree
+}
+
+// NewWithStringComparator instantiates a B+ tree with the order (maximum number of children) and the StringComparator, i.e. keys are of type string.
+func NewWithStringComparator(order int) *Tree {
+	tree := NewWith(order, utils.StringComparator)
+	tree.keyDecoder = utils.StringDecoder
+	return tree
+}
+
+// Put inserts key-value pair into the tree.
+// If key already exists, then its value is updated with the new value.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Put(key interface{}, value interface{}) {
+	if tree.root == nil {
+		tree.root = &node{entries: []*Entry{{Key: key, Value: value}}}
+		tree.first, tree.last = tree.root, tree.root
+		tree.size++
+		tree.modifications++
+		return
+	}
+	leaf := tree.leaf(key)
+	index, found := tree.search(leaf, key)
+	if found {
+		leaf.entries[index].Value = value
+		return
+	}
+	leaf.entries = append(leaf.entries, nil)
+	copy(leaf.entries[index+1:], leaf.entries[index:])
+	leaf.entries[index] = &Entry{Key: key, Value: value}
+	tree.size++
+	tree.modifications++
+	if len(leaf.entries) > tree.maxEntries() {
+		tree.splitLeaf(leaf)
+	}
+}
+
+// Get searches the entry in the tree by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Get(key interface{}) (value interface{}, found bool) {
+	if tree.root == nil {
+		return nil, false
+	}
+	leaf := tree.leaf(key)
+	if index, found := tree.search(leaf, key); found {
+		return leaf.entries[index].Value, true
+	}
+	return nil, false
+}
+
+// Remove removes the entry from the tree by key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Remove(key interface{}) {
+	if tree.root == nil {
+		return
+	}
+	leaf := tree.leaf(key)
+	index, found := tree.search(leaf, key)
+	if !found {
+		return
+	}
+	leaf.entries = append(leaf.entries[:index], leaf.entries[index+1:]...)
+	tree.size--
+	tree.modifications++
+	if leaf == tree.root {
+		if len(leaf.entries) == 0 {
+			tree.Clear()
+		}
+		return
+	}
+	if len(leaf.entries) < tree.minEntries() {
+		tree.rebalanceLeaf(leaf)
+	}
+}
+
+// Empty returns true if tree does not contain any entries
+func (tree *Tree) Empty() bool {
+	return tree.size == 0
+}
+
+// Size returns number of entries in the tree.
+func (tree *Tree) Size() int {
+	return tree.size
+}
+
+// Keys returns all keys in-order
+func (tree *Tree) Keys() []interface{} {
+	keys := make([]interface{}, 0, tree.size)
+	for leaf := tree.first; leaf != nil; leaf = leaf.next {
+		for _, entry := range leaf.entries {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
+// Values returns all values in-order based on the key.
+func (tree *Tree) Values() []interface{} {
+	values := make([]interface{}, 0, tree.size)
+	for leaf := tree.first; leaf != nil; leaf = leaf.next {
+		for _, entry := range leaf.entries {
+			values = append(values, entry.Value)
+		}
+	}
+	return values
+}
+
+// Clear removes all entries from the tree.
+func (tree *Tree) Clear() {
+	tree.root, tree.first, tree.last = nil, nil, nil
+	tree.size = 0
+	tree.modifications++
+}
+
+// Clone returns a copy of the tree with the same structure, order and comparator (keys and values themselves are not copied).
+func (tree *Tree) Clone() *Tree {
+	clone := &Tree{Comparator: tree.Comparator, size: tree.size, m: tree.m, keyDecoder: tree.keyDecoder, valueDecoder: tree.valueDecoder}
+	var previous *node
+	clone.root = tree.root.clone(nil, &previous)
+	clone.last = previous
+	for clone.first = clone.root; clone.first != nil && clone.first.children != nil; {
+		clone.first = clone.first.children[0]
+	}
+	return clone
+}
+
+// Height returns the height of the tree (number of levels).
+func (tree *Tree) Height() int {
+	height := 0
+	for n := tree.root; n != nil; height++ {
+		if n.children == nil {
+			n = nil
+		} else {
+			n = n.children[0]
+		}
+	}
+	return height
+}
+
+// Left returns the left-most (min) entry or nil if tree is empty.
+func (tree *Tree) Left() *Entry {
+	if tree.first == nil {
+		return nil
+	}
+	return tree.first.entries[0]
+}
+
+// Right returns the right-most (max) entry or nil if tree is empty.
+func (tree *Tree) Right() *Entry {
+	if tree.last == nil {
+		return nil
+	}
+	return tree.last.entries[len(tree.last.entries)-1]
+}
+
+// Floor finds the floor entry of the input key, return the floor entry or nil if no floor is found.
+// Second return parameter is true if floor was found, otherwise false.
+//
+// Floor entry is defined as the entry with the largest key that is smaller than or equal to the given key.
+// A floor entry may not be found, either because the tree is empty, or because
+// all keys in the tree are larger than the given key.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Floor(key interface{}) (floor *Entry, found bool) {
+	leaf, index := tree.floor(key, true)
+	if leaf == nil {
+		return nil, false
+	}
+	return leaf.entries[index], true
+}
+
+// Ceiling finds the ceiling entry of the input key, return the ceiling entry or nil if no ceiling is found.
+// Second return parameter is true if ceiling was found, otherwise false.
+//
+// Ceiling entry is defined as the entry with the smallest key that is larger than or equal to the given key.
+// A ceiling entry may not be found, either because the tree is empty, or because
+// all keys in the tree are smaller than the given key.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Ceiling(key interface{}) (ceiling *Entry, found bool) {
+	leaf, index := tree.ceiling(key)
+	if leaf == nil {
+		return nil, false
+	}
+	return leaf.entries[index], true
+}
+
+// Range calls the function for each entry with a key greater than or equal to from and less than to, in key order,
+// walking the linked leaves. Iteration stops as soon as the function returns false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Range(from interface{}, to interface{}, f func(key interface{}, value interface{}) bool) {
+	for leaf, index := tree.ceiling(from); leaf != nil; leaf, index = leaf.next, 0 {
+		for ; index < len(leaf.entries); index++ {
+			entry := leaf.entries[index]
+			if tree.Comparator(entry.Key, to) >= 0 || !f(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// BulkLoad replaces tree's elements with the given keys and their values (slices of the same length).
+// While keys are sorted in strictly ascending order, the tree is built bottom-up in linear time with leaves as full as possible,
+// elements from the first key out of order on are inserted one by one (see Loader).
+// Panics if the comparator can not compare the keys.
+func (tree *Tree) BulkLoad(keys []interface{}, values []interface{}) {
+	loader := tree.Loader()
+	for i := range keys {
+		if err := loader.Add(keys[i], values[i]); err != nil {
+			panic(err)
+		}
+	}
+	loader.Load()
+}
+
+// String returns a string representation of container (for debugging purposes)
+func (tree *Tree) String() string {
+	str := "BPlusTree\n"
+	if tree.root != nil {
+		str += tree.root.String(0)
+	}
+	return str
+}
+
+// String returns a string representation of the subtree rooted at the node, indented by the level,
+// with separator keys between the subtrees they separate.
+func (n *node) String(level int) string {
+	indent := strings.Repeat("    ", level)
+	if n.children == nil {
+		keys := make([]string, len(n.entries))
+		for i, entry := range n.entries {
+			keys[i] = fmt.Sprintf("%v", entry.Key)
+		}
+		return indent + strings.Join(keys, " ") + "\n"
+	}
+	str := ""
+	for i, child := range n.children {
+		if i > 0 {
+			str += fmt.Sprintf("%s%v\n", indent, n.keys[i-1])
+		}
+		str += child.String(level + 1)
+	}
+	return str
+}
+
+func (entry *Entry) String() string {
+	return fmt.Sprintf("%v", entry.Key)
+}
+
+// clone copies the subtree rooted at the node, including its entries, attaching it to the parent and linking its
+// leaves to the previous leaf, which is updated to the last leaf of the subtree.
+func (n *node) clone(parent *node, previous **node) *node {
+	if n == nil {
+		return nil
+	}
+	clone := &node{parent: parent}
+	if n.children == nil {
+		clone.entries = make([]*Entry, len(n.entries))
+		for i, entry := range n.entries {
+			clone.entries[i] = &Entry{Key: entry.Key, Value: entry.Value}
+		}
+		clone.prev = *previous
+		if *previous != nil {
+			(*previous).next = clone
+		}
+		*previous = clone
+		return clone
+	}
+	clone.keys = append([]interface{}(nil), n.keys...)
+	clone.children = make([]*node, len(n.children))
+	for i, child := range n.children {
+		clone.children[i] = child.clone(clone, previous)
+	}
+	return clone
+}
+
+func (tree *Tree) maxChildren() int {
+	return tree.m
+}
+
+func (tree *Tree) minChildren() int {
+	return (tree.m + 1) / 2 // ceil(m/2)
+}
+
+func (tree *Tree) maxEntries() int {
+	return tree.m - 1
+}
+
+func (tree *Tree) minEntries() int {
+	return tree.m / 2 // floor(m/2), so that a split leaf with m entries leaves both halves with enough entries
+}
+
+// leaf returns the leaf the key belongs to. Tree must not be empty.
+func (tree *Tree) leaf(key interface{}) *node {
+	n := tree.root
+	for n.children != nil {
+		n = n.children[tree.child(n, key)]
+	}
+	return n
+}
+
+// child returns the index of the child of the internal node whose subtree the key belongs to,
+// i.e. the number of separator keys that are less than or equal to the key.
+func (tree *Tree) child(n *node, key interface{}) int {
+	return sort.Search(len(n.keys), func(i int) bool {
+		return tree.Comparator(n.keys[i], key) > 0
+	})
+}
+
+// search returns the index of the first entry of the leaf with a key greater than or equal to the key,
+// second return parameter is true if the key of the entry equals the key.
+func (tree *Tree) search(leaf *node, key interface{}) (index int, found bool) {
+	index = sort.Search(len(leaf.entries), func(i int) bool {
+		return tree.Comparator(leaf.entries[i].Key, key) >= 0
+	})
+	return index, index < len(leaf.entries) && tree.Comparator(leaf.entries[index].Key, key) == 0
+}
+
+// ceiling returns the leaf and the index of the entry with the smallest key greater than or equal to the key,
+// or nil if there is none.
+func (tree *Tree) ceiling(key interface{}) (*node, int) {
+	if tree.root == nil {
+		return nil, 0
+	}
+	leaf := tree.leaf(key)
+	index, _ := tree.search(leaf, key)
+	if index == len(leaf.entries) {
+		// all keys of the leaf are less than the key, so the least key of the next leaf is greater
+		return leaf.next, 0
+	}
+	return leaf, index
+}
+
+// floor returns the leaf and the index of the entry with the largest key less than (or equal to, if inclusive) the key,
+// or nil if there is none.
+func (tree *Tree) floor(key interface{}, inclusive bool) (*node, int) {
+	if tree.root == nil {
+		return nil, 0
+	}
+	leaf := tree.leaf(key)
+	index, found := tree.search(leaf, key)
+	if found && inclusive {
+		return leaf, index
+	}
+	if index == 0 {
+		// all keys of the leaf are greater than (or equal to) the key, so the greatest key of the previous leaf is less
+		if leaf.prev == nil {
+			return nil, 0
+		}
+		return leaf.prev, len(leaf.prev.entries) - 1
+	}
+	return leaf, index - 1
+}
+
+// splitLeaf splits the overflowing leaf into two leaves, adding the new right one to the parent.
+func (tree *Tree) splitLeaf(leaf *node) {
+	middle := len(leaf.entries) / 2
+	right := &node{parent: leaf.parent, entries: append([]*Entry(nil), leaf.entries[middle:]...), prev: leaf, next: leaf.next}
+	leaf.entries = append([]*Entry(nil), leaf.entries[:middle]...)
+	if leaf.next != nil {
+		leaf.next.prev = right
+	} else {
+		tree.last = right
+	}
+	leaf.next = right
+	tree.insertIntoParent(leaf, right.entries[0].Key, right)
+}
+
+// splitInternal splits the overflowing internal node into two nodes, moving the middle separator key up to the parent.
+func (tree *Tree) splitInternal(n *node) {
+	middle := len(n.children) / 2
+	right := &node{
+		parent:   n.parent,
+		keys:     append([]interface{}(nil), n.keys[middle:]...),
+		children: append([]*node(nil), n.children[middle:]...),
+	}
+	setParent(right.children, right)
+	key := n.keys[middle-1]
+	n.keys = append([]interface{}(nil), n.keys[:middle-1]...)
+	n.children = append([]*node(nil), n.children[:middle]...)
+	tree.insertIntoParent(n, key, right)
+}
+
+// insertIntoParent inserts the new right node with its separator key after the left node into their parent,
+// growing a new root if the left node is the root.
+func (tree *Tree) insertIntoParent(left *node, key interface{}, right *node) {
+	parent := left.parent
+	if parent == nil {
+		tree.root = &node{keys: []interface{}{key}, children: []*node{left, right}}
+		setParent(tree.root.children, tree.root)
+		return
+	}
+	index := childIndex(parent, left)
+	parent.keys = append(parent.keys, nil)
+	copy(parent.keys[index+1:], parent.keys[index:])
+	parent.keys[index] = key
+	parent.children = append(parent.children, nil)
+	copy(parent.children[index+2:], parent.children[index+1:])
+	parent.children[index+1] = right
+	right.parent = parent
+	if len(parent.children) > tree.maxChildren() {
+		tree.splitInternal(parent)
+	}
+}
+
+// rebalanceLeaf refills the underflowing leaf by borrowing an entry from a sibling, or merges it with a sibling.
+func (tree *Tree) rebalanceLeaf(leaf *node) {
+	parent := leaf.parent
+	index := childIndex(parent, leaf)
+	// borrow from the left sibling
+	if index > 0 {
+		if left := parent.children[index-1]; len(left.entries) > tree.minEntries() {
+			borrowed := left.entries[len(left.entries)-1]
+			left.entries = left.entries[:len(left.entries)-1]
+			leaf.entries = append([]*Entry{borrowed}, leaf.entries...)
+			parent.keys[index-1] = borrowed.Key
+			return
+		}
+	}
+	// borrow from the right sibling
+	if index < len(parent.children)-1 {
+		if right := parent.children[index+1]; len(right.entries) > tree.minEntries() {
+			leaf.entries = append(leaf.entries, right.entries[0])
+			right.entries = append([]*Entry(nil), right.entries[1:]...)
+			parent.keys[index] = right.entries[0].Key
+			return
+		}
+	}
+	// merge with a sibling, the right one of the two merged leaves is removed
+	if index > 0 {
+		index--
+	}
+	left, right := parent.children[index], parent.children[index+1]
+	left.entries = append(left.entries, right.entries...)
+	left.next = right.next
+	if right.next != nil {
+		right.next.prev = left
+	} else {
+		tree.last = left
+	}
+	tree.removeChild(parent, index)
+}
+
+// rebalanceInternal refills the underflowing internal node by borrowing a child from a sibling, or merges it with a sibling.
+func (tree *Tree) rebalanceInternal(n *node) {
+	if n == tree.root {
+		if len(n.children) == 1 {
+			tree.root = n.children[0]
+			tree.root.parent = nil
+		}
+		return
+	}
+	if len(n.children) >= tree.minChildren() {
+		return
+	}
+	parent := n.parent
+	index := childIndex(parent, n)
+	// borrow from the left sibling, rotating the separator keys through the parent
+	if index > 0 {
+		if left := parent.children[index-1]; len(left.children) > tree.minChildren() {
+			borrowed := left.children[len(left.children)-1]
+			n.keys = append([]interface{}{parent.keys[index-1]}, n.keys...)
+			n.children = append([]*node{borrowed}, n.children...)
+			borrowed.parent = n
+			parent.keys[index-1] = left.keys[len(left.keys)-1]
+			left.keys = left.keys[:len(left.keys)-1]
+			left.children = left.children[:len(left.children)-1]
+			return
+		}
+	}
+	// borrow from the right sibling, rotating the separator keys through the parent
+	if index < len(parent.children)-1 {
+		if right := parent.children[index+1]; len(right.children) > tree.minChildren() {
+			borrowed := right.children[0]
+			n.keys = append(n.keys, parent.keys[index])
+			n.children = append(n.children, borrowed)
+			borrowed.parent = n
+			parent.keys[index] = right.keys[0]
+			right.keys = append([]interface{}(nil), right.keys[1:]...)
+			right.children = append([]*node(nil), right.children[1:]...)
+			return
+		}
+	}
+	// merge with a sibling, pulling their separator key down from the parent
+	if index > 0 {
+		index--
+	}
+	left, right := parent.children[index], parent.children[index+1]
+	left.keys = append(append(left.keys, parent.keys[index]), right.keys...)
+	left.children = append(left.children, right.children...)
+	setParent(right.children, left)
+	tree.removeChild(parent, index)
+}
+
+// removeChild removes the child right of the separator key at the index from the internal node and rebalances it.
+func (tree *Tree) removeChild(n *node, index int) {
+	n.keys = append(n.keys[:index], n.keys[index+1:]...)
+	n.children = append(n.children[:index+1], n.children[index+2:]...)
+	tree.rebalanceInternal(n)
+}
+
+// childIndex returns the index of the child in the children of the parent.
+func childIndex(parent *node, child *node) int {
+	for i, c := range parent.children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+func setParent(nodes []*node, parent *node) {
+	for _, n := range nodes {
+		n.parent = parent
+	}
+}
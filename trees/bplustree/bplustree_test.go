@@ -0,0 +1,909 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/utils"
+	"math/rand"
+	"testing"
+)
+
+func TestBPlusTreeGet(t *testing.T) {
+	tree := NewWithIntComparator(3)
+
+	if actualValue := tree.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue, found := tree.Get(1); actualValue != nil || found {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(5, "e")
+	tree.Put(6, "f")
+	tree.Put(7, "g")
+
+	tests := [][]interface{}{
+		{0, nil, false},
+		{1, "a", true},
+		{2, "b", true},
+		{3, "c", true},
+		{4, "d", true},
+		{5, "e", true},
+		{6, "f", true},
+		{7, "g", true},
+		{8, nil, false},
+	}
+
+	for _, test := range tests {
+		if value, found := tree.Get(test[0]); value != test[1] || found != test[2] {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, test[1], test[2])
+		}
+	}
+}
+
+func TestBPlusTreePut(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	assertValidTree(t, tree, 0)
+
+	tree.Put(1, 0)
+	assertValidTree(t, tree, 1)
+	if actualValue, expectedValue := tree.String(), "BPlusTree\n1\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Put(2, 1)
+	tree.Put(3, 2)
+	assertValidTree(t, tree, 3)
+	if actualValue, expectedValue := tree.String(), "BPlusTree\n    1\n2\n    2 3\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Put(4, 2)
+	tree.Put(5, 2)
+	assertValidTree(t, tree, 5)
+	if actualValue, expectedValue := tree.Height(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	expected := "BPlusTree\n" +
+		"        1\n" +
+		"    2\n" +
+		"        2\n" +
+		"3\n" +
+		"        3\n" +
+		"    4\n" +
+		"        4 5\n"
+	if actualValue := tree.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	tree.Put(3, "x") // update
+	assertValidTree(t, tree, 5)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[0 1 x 2 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeRemove(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	tree.Remove(1)
+	assertValidTree(t, tree, 0)
+
+	for i := 1; i <= 10; i++ {
+		tree.Put(i, i)
+	}
+	tree.Remove(11) // not found
+	assertValidTree(t, tree, 10)
+
+	for _, key := range []int{5, 1, 10, 7, 3} {
+		tree.Remove(key)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v after removing %v", err, key)
+		}
+	}
+	assertValidTree(t, tree, 5)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[2 4 6 8 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for _, key := range []int{9, 2, 8, 4, 6} {
+		tree.Remove(key)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v after removing %v", err, key)
+		}
+	}
+	assertValidTree(t, tree, 0)
+	if actualValue, expectedValue := tree.Height(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Left(), (*Entry)(nil); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeRandomOperations(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, order := range []int{3, 4, 5, 8} {
+		tree := NewWithIntComparator(order)
+		model := map[int]int{}
+		for i := 0; i < 2000; i++ {
+			key := random.Intn(200)
+			if random.Intn(3) == 0 {
+				tree.Remove(key)
+				delete(model, key)
+			} else {
+				tree.Put(key, i)
+				model[key] = i
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("Got error %v after operation %v on tree of order %v", err, i, order)
+			}
+		}
+		if actualValue, expectedValue := tree.Size(), len(model); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for key, value := range model {
+			if actualValue, found := tree.Get(key); actualValue != value || !found {
+				t.Errorf("Got %v expected %v for %v", actualValue, value, key)
+			}
+		}
+	}
+}
+
+func TestBPlusTreeLeftAndRight(t *testing.T) {
+	tree := NewWithIntComparator(3)
+
+	if actualValue := tree.Left(); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue := tree.Right(); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	tree.Put(1, "a")
+	tree.Put(5, "e")
+	tree.Put(6, "f")
+	tree.Put(7, "g")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(1, "x") // overwrite
+	tree.Put(2, "b")
+
+	if actualValue, expectedValue := tree.Left().Key, 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Left().Value, "x"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Right().Key, 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Right().Value, "g"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeFloorAndCeiling(t *testing.T) {
+	tree := NewWithIntComparator(3)
+
+	if node, found := tree.Floor(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	if node, found := tree.Ceiling(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+
+	for _, key := range []int{5, 10, 15, 20, 25, 30, 35, 40} {
+		tree.Put(key, key)
+	}
+
+	tests := [][]interface{}{
+		{0, nil, 5},
+		{5, 5, 5},
+		{7, 5, 10},
+		{10, 10, 10},
+		{14, 10, 15},
+		{21, 20, 25},
+		{40, 40, 40},
+		{41, 40, nil},
+	}
+	for _, test := range tests {
+		floor, found := tree.Floor(test[0])
+		if test[1] == nil && (floor != nil || found) || test[1] != nil && (!found || floor.Key != test[1]) {
+			t.Errorf("Got %v expected %v for floor of %v", floor, test[1], test[0])
+		}
+		ceiling, found := tree.Ceiling(test[0])
+		if test[2] == nil && (ceiling != nil || found) || test[2] != nil && (!found || ceiling.Key != test[2]) {
+			t.Errorf("Got %v expected %v for ceiling of %v", ceiling, test[2], test[0])
+		}
+	}
+}
+
+func TestBPlusTreeRange(t *testing.T) {
+	tree := NewWithIntComparator(4)
+	for i := 0; i < 50; i += 2 {
+		tree.Put(i, i*i)
+	}
+
+	collect := func(from, to int, limit int) string {
+		keys := []interface{}{}
+		tree.Range(from, to, func(key interface{}, value interface{}) bool {
+			if value != key.(int)*key.(int) {
+				t.Errorf("Got %v expected %v", value, key.(int)*key.(int))
+			}
+			keys = append(keys, key)
+			return len(keys) < limit
+		})
+		return fmt.Sprint(keys)
+	}
+
+	tests := []struct {
+		from, to, limit int
+		expected        string
+	}{
+		{10, 20, 100, "[10 12 14 16 18]"},
+		{9, 21, 100, "[10 12 14 16 18 20]"},
+		{-5, 3, 100, "[0 2]"},
+		{45, 100, 100, "[46 48]"},
+		{49, 100, 100, "[]"},
+		{20, 20, 100, "[]"},
+		{30, 10, 100, "[]"},
+		{0, 100, 3, "[0 2 4]"},
+	}
+	for _, test := range tests {
+		if actualValue := collect(test.from, test.to, test.limit); actualValue != test.expected {
+			t.Errorf("Got %v expected %v for [%v, %v)", actualValue, test.expected, test.from, test.to)
+		}
+	}
+
+	NewWithIntComparator(3).Range(0, 10, func(key interface{}, value interface{}) bool {
+		t.Errorf("Got %v in empty tree", key)
+		return true
+	})
+}
+
+func TestBPlusTreeBulkLoad(t *testing.T) {
+	for _, order := range []int{3, 4, 5, 7} {
+		for _, size := range []int{0, 1, 2, 3, 7, 10, 33, 100, 257} {
+			keys, values := make([]interface{}, size), make([]interface{}, size)
+			for i := range keys {
+				keys[i], values[i] = i*2, i
+			}
+			tree := NewWithIntComparator(order)
+			tree.Put(-1, -1) // replaced
+			tree.BulkLoad(keys, values)
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got error %v for %v keys in tree of order %v", err, size, order)
+			}
+			if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(keys); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			if actualValue, expectedValue := fmt.Sprint(tree.Values()), fmt.Sprint(values); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			// the loaded tree remains a valid tree for further modifications
+			for i := 0; i < size; i += 3 {
+				tree.Remove(i * 2)
+				tree.Put(i*2+1, i)
+			}
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got error %v after modifying %v keys in tree of order %v", err, size, order)
+			}
+		}
+	}
+
+	tree := NewWithIntComparator(3)
+	tree.BulkLoad([]interface{}{3, 1, 2, 1}, []interface{}{"c", "a", "b", "x"})
+	assertValidTree(t, tree, 3)
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[x b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeLoader(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	tree.Put(0, 0)
+	loader := tree.Loader()
+	for _, key := range []int{1, 2, 3, 5, 4, 6} {
+		if err := loader.Add(key, -key); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	if err := loader.Add("7", 7); err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[0]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	loader.Load()
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got invalid tree: %v", err)
+	}
+}
+
+func TestBPlusTreeIteratorNextOnEmpty(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	it := tree.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+}
+
+func TestBPlusTreeIteratorPrevOnEmpty(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	it := tree.Iterator()
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+}
+
+func TestBPlusTreeIteratorNext(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for _, key := range []int{5, 6, 7, 3, 4, 1, 2} {
+		tree.Put(key, fmt.Sprint(key))
+	}
+	it := tree.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := it.Value(), fmt.Sprint(count); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := count, tree.Size(); actualValue != expectedValue {
+		t.Errorf("Size different. Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeIteratorPrev(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for _, key := range []int{5, 6, 7, 3, 4, 1, 2} {
+		tree.Put(key, fmt.Sprint(key))
+	}
+	it := tree.Iterator()
+	for it.Next() {
+	}
+	countDown := tree.Size()
+	for it.Prev() {
+		if actualValue, expectedValue := it.Key(), countDown; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		countDown--
+	}
+	if actualValue, expectedValue := countDown, 0; actualValue != expectedValue {
+		t.Errorf("Size different. Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeIteratorBeginEndFirstLast(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	it := tree.Iterator()
+	if actualValue, expectedValue := it.First(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Last(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Put(3, "c")
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	it.Begin()
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.End()
+	if actualValue, expectedValue := it.Next(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.First(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Value(), "a"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Prev(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Value(), "c"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeIteratorFrom(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for i := 10; i <= 100; i += 10 {
+		tree.Put(i, i/10)
+	}
+
+	it := tree.IteratorFrom(35)
+	keys := []interface{}{}
+	for it.Next() && it.Key().(int) < 70 {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[40 50 60]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := []struct {
+		from       int
+		next, prev interface{}
+	}{
+		{40, 40, 30},
+		{5, 10, nil},
+		{10, 10, nil},
+		{100, 100, 90},
+		{105, nil, 100},
+	}
+	for _, test := range tests {
+		it = tree.IteratorFrom(test.from)
+		if found := it.Next(); found != (test.next != nil) || found && it.Key() != test.next {
+			t.Errorf("Got %v expected %v after %v", found, test.next, test.from)
+		}
+		it = tree.IteratorFrom(test.from)
+		if found := it.Prev(); found != (test.prev != nil) || found && it.Key() != test.prev {
+			t.Errorf("Got %v expected %v before %v", found, test.prev, test.from)
+		}
+	}
+
+	// a detached iterator survives modifications of the tree
+	it = tree.IteratorFrom(45)
+	tree.Remove(50)
+	tree.Put(47, 0)
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 47; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeIteratorConcurrentModification(t *testing.T) {
+	expectPanic := func(f func() bool) {
+		defer func() {
+			if r := recover(); r != containers.ErrConcurrentModification {
+				t.Errorf("Got %v expected %v", r, containers.ErrConcurrentModification)
+			}
+		}()
+		f()
+	}
+
+	tree := NewWithIntComparator(3)
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	tree.Put(3, "c")
+	it := tree.Iterator()
+	it.Next()
+	tree.Put(1, "x") // not a structural modification
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Put(4, "d")
+	expectPanic(it.Next)
+	expectPanic(it.Prev)
+
+	it.End()
+	tree.Remove(4)
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Clear()
+	expectPanic(it.Prev)
+}
+
+func TestBPlusTreeIteratorRemove(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for _, key := range []int{11, 5, 17, 2, 8, 14, 20, 1, 3, 6, 9, 12, 15, 18, 4, 7, 10, 13, 16, 19} {
+		tree.Put(key, key)
+	}
+	it := tree.Iterator()
+	it.Remove() // not positioned on an element
+	for it.Next() {
+		if it.Key().(int)%2 == 0 {
+			it.Remove()
+			it.Remove() // already removed
+		}
+	}
+	assertValidTree(t, tree, 10)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 3 5 7 9 11 13 15 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.End(); it.Prev(); {
+		if it.Key().(int)%3 == 0 {
+			it.Remove()
+		}
+	}
+	assertValidTree(t, tree, 7)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 5 7 11 13 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.Begin(); it.Next(); {
+		if it.Key() == 11 {
+			break
+		}
+	}
+	it.Remove()
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Next()
+	if actualValue, expectedValue := it.Key(), 13; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.SetValue("x")
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[1 5 7 x 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.Begin(); it.Next(); {
+		it.Remove()
+	}
+	assertValidTree(t, tree, 0)
+	if actualValue, expectedValue := it.Prev(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeClone(t *testing.T) {
+	tree := NewWithStringComparator(3)
+	for _, key := range []string{"d", "b", "f", "a", "c", "e", "g"} {
+		tree.Put(key, key)
+	}
+
+	clone := tree.Clone()
+	if err := clone.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), tree.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Put("a", "x")
+	clone.Remove("g")
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	assertValidTree(t, tree, 7)
+	if err := NewWithIntComparator(3).Clone().Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+}
+
+func TestBPlusTreeValidate(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for i := 0; i < 20; i++ {
+		tree.Put(i, i)
+	}
+	corruptions := []func(tree *Tree){
+		func(tree *Tree) { tree.root.parent = tree.root.children[0] },
+		func(tree *Tree) { tree.root.children[0].parent = nil },
+		func(tree *Tree) { tree.root.keys[0] = -1 },
+		func(tree *Tree) { tree.root.children = tree.root.children[1:] },
+		func(tree *Tree) { tree.first.entries = tree.first.entries[:0] },
+		func(tree *Tree) {
+			tree.last.entries[0], tree.last.entries[1] = tree.last.entries[1], tree.last.entries[0]
+		},
+		func(tree *Tree) { tree.first.next = tree.first.next.next },
+		func(tree *Tree) { tree.last = tree.last.prev },
+		func(tree *Tree) { tree.size++ },
+	}
+	for i, corrupt := range corruptions {
+		clone := tree.Clone()
+		corrupt(clone)
+		if err := clone.Validate(); err == nil {
+			t.Errorf("Got no error for corruption %v", i)
+		}
+	}
+}
+
+func TestBPlusTreeSerialization(t *testing.T) {
+	tree := NewWithStringComparator(3)
+	tree.Put("c", "3")
+	tree.Put("b", "2")
+	tree.Put("a", "1")
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[a b c]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[1 2 3]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := tree.ToJSON()
+	assert()
+
+	err = tree.FromJSON(json)
+	assert()
+}
+
+func TestBPlusTreeMarshalJSON(t *testing.T) {
+	type document struct {
+		Tree *Tree `json:"tree"`
+	}
+	tree := NewWithStringComparator(3)
+	for i := 0; i < 10; i++ {
+		tree.Put(fmt.Sprint(i), fmt.Sprint(i))
+	}
+
+	data, err := json.Marshal(&document{Tree: tree})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := tree.ToJSON()
+	if actualValue, expectedValue := string(data), `{"tree":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Tree: NewWithStringComparator(3)}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if err := doc.Tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Tree.Values()), fmt.Sprint(tree.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = json.Unmarshal(data, &document{})
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Tree).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeBinarySerialization(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for i := 0; i < 10; i++ {
+		tree.Put(i, fmt.Sprint(i))
+	}
+
+	assert := func(restored *Tree, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if err := restored.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Keys()), fmt.Sprint(tree.Keys()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(tree.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	data, err := tree.MarshalBinary()
+	restored := NewWithIntComparator(3)
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = tree.MarshalBinaryWith(utils.IntCodec, utils.StringCodec)
+	restored = NewWithIntComparator(3)
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec, utils.StringCodec)
+	}
+	assert(restored, err)
+
+	buffer := new(bytes.Buffer)
+	err = tree.WriteJSON(buffer)
+	restored = NewWithIntComparator(3)
+	if err == nil {
+		err = restored.ReadJSONWith(buffer, utils.IntDecoder, nil)
+	}
+	assert(restored, err)
+
+	type document struct {
+		Tree *Tree
+	}
+	buffer.Reset()
+	err = gob.NewEncoder(buffer).Encode(&document{Tree: tree})
+	doc := &document{Tree: NewWithIntComparator(3)}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Tree, err)
+}
+
+func TestBPlusTreeConformance(t *testing.T) {
+	containertest.TestMap(t, func() maps.Map { return NewWithIntComparator(3) })
+	for _, keys := range [][]interface{}{{}, {1}, {1, 2, 3}, {1, 2, 3, 4, 5, 6, 7}} {
+		tree := NewWithIntComparator(3)
+		values := []interface{}{}
+		for _, key := range keys {
+			tree.Put(key, -key.(int))
+			values = append(values, -key.(int))
+		}
+		it := tree.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+	}
+}
+
+// assertValidTree checks the invariants of the tree and its size
+func assertValidTree(t *testing.T, tree *Tree, expectedSize int) {
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := tree.Size(), expectedSize; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := len(tree.Keys()), expectedSize; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkGet(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Get(n)
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Put(n, struct{}{})
+		}
+	}
+}
+
+func benchmarkRemove(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Remove(n)
+		}
+	}
+}
+
+func benchmarkRange(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		tree.Range(0, size, func(key interface{}, value interface{}) bool { return true })
+	}
+}
+
+func BenchmarkBPlusTreeGet1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, size)
+}
+
+func BenchmarkBPlusTreeGet100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, size)
+}
+
+func BenchmarkBPlusTreePut1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator(128)
+	b.StartTimer()
+	benchmarkPut(b, tree, size)
+}
+
+func BenchmarkBPlusTreePut100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator(128)
+	b.StartTimer()
+	benchmarkPut(b, tree, size)
+}
+
+func BenchmarkBPlusTreeRemove1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, tree, size)
+}
+
+func BenchmarkBPlusTreeRemove100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, tree, size)
+}
+
+func BenchmarkBPlusTreeRange1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRange(b, tree, size)
+}
+
+func BenchmarkBPlusTreeRange100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRange(b, tree, size)
+}
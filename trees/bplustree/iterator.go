@@ -0,0 +1,187 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import "github.com/emirpasic/gods/containers"
+
+func assertIteratorImplementation() {
+	var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
+}
+
+// Iterator holding the iterator's state
+type Iterator struct {
+	tree          *Tree
+	leaf          *node
+	index         int // index of the current entry in the leaf
+	position      position
+	detached      bool        // iterator is between the entries around key, not on an entry (after Remove or IteratorFrom)
+	key           interface{} // key the detached iterator is positioned at
+	modifications int         // tree's modifications when the iterator moved onto the current entry
+}
+
+type position byte
+
+const (
+	begin, between, end position = 0, 1, 2
+)
+
+// Iterator returns a stateful iterator whose elements are key/value pairs.
+// The iterator is fail-fast: Next() and Prev() panic with containers.ErrConcurrentModification
+// if the tree has been structurally modified since the iterator moved onto its current element.
+func (tree *Tree) Iterator() Iterator {
+	return Iterator{tree: tree, position: begin}
+}
+
+// IteratorFrom returns a stateful iterator positioned just before the element with the smallest key greater than or
+// equal to the key, i.e. Next() moves to that element and Prev() to the element with the largest smaller key.
+// Together with the linked leaves this allows scanning a range of keys without searching the tree for each key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) IteratorFrom(key interface{}) Iterator {
+	return Iterator{tree: tree, position: between, detached: true, key: key}
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator) Next() bool {
+	iterator.checkModifications()
+	switch {
+	case iterator.detached:
+		iterator.leaf, iterator.index = iterator.tree.ceiling(iterator.key)
+	case iterator.position == end:
+		iterator.leaf = nil
+	case iterator.position == begin:
+		iterator.leaf, iterator.index = iterator.tree.first, 0
+	case iterator.index+1 < len(iterator.leaf.entries):
+		iterator.index++
+	default:
+		iterator.leaf, iterator.index = iterator.leaf.next, 0
+	}
+	if iterator.leaf == nil {
+		iterator.End()
+		return false
+	}
+	iterator.moved()
+	return true
+}
+
+// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
+// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator) Prev() bool {
+	iterator.checkModifications()
+	switch {
+	case iterator.detached:
+		iterator.leaf, iterator.index = iterator.tree.floor(iterator.key, false)
+	case iterator.position == begin:
+		iterator.leaf = nil
+	case iterator.position == end:
+		iterator.leaf = iterator.tree.last
+		if iterator.leaf != nil {
+			iterator.index = len(iterator.leaf.entries) - 1
+		}
+	case iterator.index > 0:
+		iterator.index--
+	default:
+		iterator.leaf = iterator.leaf.prev
+		if iterator.leaf != nil {
+			iterator.index = len(iterator.leaf.entries) - 1
+		}
+	}
+	if iterator.leaf == nil {
+		iterator.Begin()
+		return false
+	}
+	iterator.moved()
+	return true
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *Iterator) Value() interface{} {
+	return iterator.leaf.entries[iterator.index].Value
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *Iterator) Key() interface{} {
+	return iterator.leaf.entries[iterator.index].Key
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator) Begin() {
+	iterator.leaf = nil
+	iterator.position = begin
+	iterator.detached = false
+	iterator.key = nil
+}
+
+// End moves the iterator past the last element (one-past-the-end).
+// Call Prev() to fetch the last element if any.
+func (iterator *Iterator) End() {
+	iterator.leaf = nil
+	iterator.position = end
+	iterator.detached = false
+	iterator.key = nil
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator
+func (iterator *Iterator) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// Last moves the iterator to the last element and returns true if there was a last element in the container.
+// If Last() returns true, then last element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator) Last() bool {
+	iterator.End()
+	return iterator.Prev()
+}
+
+// Remove removes the current element from the tree.
+// Afterwards the iterator is positioned between the neighbours of the removed element,
+// i.e. Next() moves to the element after it and Prev() to the element before it.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) Remove() {
+	if iterator.position != between || iterator.detached {
+		return
+	}
+	iterator.checkModifications()
+	iterator.key = iterator.Key()
+	iterator.tree.Remove(iterator.key)
+	iterator.leaf = nil
+	iterator.detached = true
+}
+
+// SetValue replaces the current element's value.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) SetValue(value interface{}) {
+	if iterator.position != between || iterator.detached {
+		return
+	}
+	iterator.checkModifications()
+	iterator.leaf.entries[iterator.index].Value = value
+}
+
+// moved records that the iterator moved onto the current entry.
+func (iterator *Iterator) moved() {
+	iterator.position = between
+	iterator.detached = false
+	iterator.key = nil
+	iterator.modifications = iterator.tree.modifications
+}
+
+// checkModifications panics if the iterator is positioned on an entry and the tree has been structurally modified since it moved there.
+// Detached iterators only hold a key, which is looked up again when they move.
+func (iterator *Iterator) checkModifications() {
+	if iterator.position == between && !iterator.detached && iterator.modifications != iterator.tree.modifications {
+		panic(containers.ErrConcurrentModification)
+	}
+}
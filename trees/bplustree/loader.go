@@ -0,0 +1,147 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import "github.com/emirpasic/gods/utils"
+
+// Loader replaces the elements of a tree with elements added one at a time, e.g. while they are decoded,
+// leaving the tree unchanged until Load is called.
+//
+// While keys are added in strictly ascending order, the tree is built bottom-up in linear time: entries fill the last
+// leaf, an entry added to a full leaf starts a new leaf, whose key separates it in the level above, where a full node
+// starts a new node the same way. The last nodes of the levels are filled up from their left siblings once all
+// elements were added. From the first key that is out of order on, elements are inserted one by one.
+type Loader struct {
+	tree   *Tree       // tree whose elements are replaced
+	loaded *Tree       // tree of the added elements
+	spine  []*node     // last node of each level, from the leaves up to the root, while keys are in order
+	key    interface{} // key of the last added element
+	sorted bool        // true while keys are added in strictly ascending order
+}
+
+// Loader returns a loader replacing tree's elements (see BulkLoad and ReadJSON).
+func (tree *Tree) Loader() *Loader {
+	return &Loader{tree: tree, loaded: &Tree{Comparator: tree.Comparator, m: tree.m}, sorted: true}
+}
+
+// Add adds the element to the elements being loaded.
+// Returns an error if the comparator can not compare the key with the key of the previously added element.
+func (loader *Loader) Add(key interface{}, value interface{}) error {
+	previous := key
+	if loader.loaded.size > 0 {
+		previous = loader.key
+	}
+	compare, err := utils.CompareKeys(loader.loaded.Comparator, previous, key)
+	if err != nil {
+		return err
+	}
+	loader.key = key
+	if loader.sorted && (loader.loaded.size == 0 || compare < 0) {
+		loader.append(&Entry{Key: key, Value: value})
+		loader.loaded.size++
+		return nil
+	}
+	if loader.sorted {
+		loader.build()
+	}
+	loader.loaded.Put(key, value)
+	return nil
+}
+
+// Load replaces tree's elements with the added elements.
+func (loader *Loader) Load() {
+	if loader.sorted {
+		loader.build()
+	}
+	loader.tree.root = loader.loaded.root
+	loader.tree.first, loader.tree.last = loader.loaded.first, loader.loaded.last
+	loader.tree.size = loader.loaded.size
+	loader.tree.modifications++
+}
+
+// append adds the entry, whose key follows all keys added so far, to the last leaf or,
+// if the leaf is full, to a new leaf, which is added to the level above with the key as its separator.
+func (loader *Loader) append(entry *Entry) {
+	if len(loader.spine) == 0 {
+		leaf := &node{entries: []*Entry{entry}}
+		loader.spine = append(loader.spine, leaf)
+		loader.loaded.first, loader.loaded.last = leaf, leaf
+		return
+	}
+	if leaf := loader.spine[0]; len(leaf.entries) < loader.loaded.maxEntries() {
+		leaf.entries = append(leaf.entries, entry)
+		return
+	}
+	leaf := &node{entries: []*Entry{entry}, prev: loader.spine[0]}
+	leaf.prev.next = leaf
+	loader.loaded.last = leaf
+	n := leaf
+	for level := 1; ; level++ {
+		if level == len(loader.spine) {
+			root := &node{keys: []interface{}{entry.Key}, children: []*node{loader.spine[level-1], n}}
+			setParent(root.children, root)
+			loader.spine[level-1] = n
+			loader.spine = append(loader.spine, root)
+			return
+		}
+		loader.spine[level-1] = n
+		if parent := loader.spine[level]; len(parent.children) < loader.loaded.maxChildren() {
+			parent.keys = append(parent.keys, entry.Key)
+			parent.children = append(parent.children, n)
+			n.parent = parent
+			return
+		}
+		// the parent is full as well, the key separates it from a new parent of the node
+		parent := &node{children: []*node{n}}
+		n.parent = parent
+		n = parent
+	}
+}
+
+// build fills up the last node of each level below the root from its left sibling, which is full,
+// so that all nodes hold at least the minimum number of entries or children.
+func (loader *Loader) build() {
+	if len(loader.spine) > 0 {
+		for level := len(loader.spine) - 2; level > 0; level-- {
+			if n := loader.spine[level]; len(n.children) < loader.loaded.minChildren() {
+				balanceLastInternal(n)
+			}
+		}
+		if leaf := loader.spine[0]; leaf.parent != nil && len(leaf.entries) < loader.loaded.minEntries() {
+			balanceLastLeaf(leaf)
+		}
+		loader.loaded.root = loader.spine[len(loader.spine)-1]
+	}
+	loader.spine = nil
+	loader.sorted = false
+}
+
+// balanceLastLeaf distributes the entries of the leaf, which is the last child of its parent,
+// and of its left sibling evenly among the two.
+func balanceLastLeaf(leaf *node) {
+	parent := leaf.parent
+	index := len(parent.children) - 1
+	sibling := parent.children[index-1]
+	entries := append(append([]*Entry{}, sibling.entries...), leaf.entries...)
+	middle := len(entries) / 2
+	sibling.entries, leaf.entries = entries[:middle:middle], entries[middle:]
+	parent.keys[index-1] = leaf.entries[0].Key
+}
+
+// balanceLastInternal distributes the separator keys and children of the internal node, which is the last child of its
+// parent, and of its left sibling evenly among the two, rotating the separator keys through the parent.
+func balanceLastInternal(n *node) {
+	parent := n.parent
+	index := len(parent.children) - 1
+	sibling := parent.children[index-1]
+	keys := make([]interface{}, 0, len(sibling.keys)+1+len(n.keys))
+	keys = append(append(append(keys, sibling.keys...), parent.keys[index-1]), n.keys...)
+	children := append(append([]*node{}, sibling.children...), n.children...)
+	middle := len(children) / 2
+	sibling.keys, parent.keys[index-1], n.keys = keys[:middle-1:middle-1], keys[middle-1], keys[middle:]
+	sibling.children, n.children = children[:middle:middle], children[middle:]
+	setParent(sibling.children, sibling)
+	setParent(n.children, n)
+}
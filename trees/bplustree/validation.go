@@ -0,0 +1,124 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bplustree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the structural invariants of the tree and returns an error describing the first violation found, if any.
+// It checks that keys are in strictly ascending order and within the bounds of the separator keys above them, that
+// parent pointers are consistent, that internal nodes hold between ⌈m/2⌉ (except the root) and m children and one
+// separator key less, that leaves hold between ⌊m/2⌋ (except the root) and m-1 entries, that all leaves appear on the
+// same level and are linked in order, and that the size of the tree matches the number of its entries.
+func (tree *Tree) Validate() error {
+	if tree.root != nil && tree.root.parent != nil {
+		return errors.New("bplustree: root has a parent")
+	}
+	v := &validator{tree: tree, leafDepth: -1}
+	if tree.root != nil {
+		if err := v.validate(tree.root, nil, 0, nil, nil); err != nil {
+			return err
+		}
+	}
+	if v.previous != tree.last {
+		return errors.New("bplustree: last leaf is not the right-most leaf")
+	}
+	if tree.last != nil && tree.last.next != nil {
+		return errors.New("bplustree: last leaf has a right neighbour")
+	}
+	if v.count != tree.size {
+		return fmt.Errorf("bplustree: size is %d, but tree has %d entries", tree.size, v.count)
+	}
+	return nil
+}
+
+// validator holds the state of the in-order traversal of the tree in Validate
+type validator struct {
+	tree      *Tree
+	previous  *node  // last visited leaf
+	entry     *Entry // last visited entry
+	count     int    // number of visited entries
+	leafDepth int    // depth of the leaves, -1 until the first leaf is visited
+}
+
+// validate checks the subtree rooted at the node attached to the parent at the given depth,
+// whose keys must be greater than or equal to the lower bound and less than the upper bound (nil if unbounded).
+func (v *validator) validate(n *node, parent *node, depth int, lower interface{}, upper interface{}) error {
+	if n == nil {
+		return errors.New("bplustree: node has a nil child")
+	}
+	if n.parent != parent {
+		return fmt.Errorf("bplustree: node %v has a wrong parent", n.keys)
+	}
+	if n.children == nil {
+		return v.validateLeaf(n, depth, lower, upper)
+	}
+	if len(n.children) > v.tree.maxChildren() {
+		return fmt.Errorf("bplustree: node %v has %d children, at most %d allowed", n.keys, len(n.children), v.tree.maxChildren())
+	}
+	if parent != nil && len(n.children) < v.tree.minChildren() {
+		return fmt.Errorf("bplustree: node %v has %d children, at least %d required", n.keys, len(n.children), v.tree.minChildren())
+	}
+	if parent == nil && len(n.children) < 2 {
+		return fmt.Errorf("bplustree: root %v has %d children, at least 2 required", n.keys, len(n.children))
+	}
+	if len(n.keys) != len(n.children)-1 {
+		return fmt.Errorf("bplustree: node %v has %d keys and %d children", n.keys, len(n.keys), len(n.children))
+	}
+	for i, child := range n.children {
+		childLower, childUpper := lower, upper
+		if i > 0 {
+			childLower = n.keys[i-1]
+		}
+		if i < len(n.keys) {
+			childUpper = n.keys[i]
+		}
+		if err := v.validate(child, n, depth+1, childLower, childUpper); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLeaf checks the leaf at the given depth and its link to the previously visited leaf.
+func (v *validator) validateLeaf(leaf *node, depth int, lower interface{}, upper interface{}) error {
+	if len(leaf.entries) == 0 {
+		return errors.New("bplustree: leaf has no entries")
+	}
+	if len(leaf.entries) > v.tree.maxEntries() {
+		return fmt.Errorf("bplustree: leaf %v has %d entries, at most %d allowed", leaf.entries, len(leaf.entries), v.tree.maxEntries())
+	}
+	if leaf != v.tree.root && len(leaf.entries) < v.tree.minEntries() {
+		return fmt.Errorf("bplustree: leaf %v has %d entries, at least %d required", leaf.entries, len(leaf.entries), v.tree.minEntries())
+	}
+	if v.leafDepth < 0 {
+		v.leafDepth = depth
+	} else if v.leafDepth != depth {
+		return fmt.Errorf("bplustree: leaf %v is on level %d, other leaves are on level %d", leaf.entries, depth, v.leafDepth)
+	}
+	if leaf.prev != v.previous || (v.previous == nil && v.tree.first != leaf) || (v.previous != nil && v.previous.next != leaf) {
+		return fmt.Errorf("bplustree: leaf %v is not linked to its left neighbour", leaf.entries)
+	}
+	for _, entry := range leaf.entries {
+		if entry == nil {
+			return errors.New("bplustree: leaf has a nil entry")
+		}
+		if v.entry != nil && v.tree.Comparator(v.entry.Key, entry.Key) >= 0 {
+			return fmt.Errorf("bplustree: keys %v and %v are out of order", v.entry.Key, entry.Key)
+		}
+		if lower != nil && v.tree.Comparator(entry.Key, lower) < 0 {
+			return fmt.Errorf("bplustree: key %v is less than its separator key %v", entry.Key, lower)
+		}
+		if upper != nil && v.tree.Comparator(entry.Key, upper) >= 0 {
+			return fmt.Errorf("bplustree: key %v is not less than its separator key %v", entry.Key, upper)
+		}
+		v.entry = entry
+	}
+	v.previous = leaf
+	v.count += len(leaf.entries)
+	return nil
+}
@@ -30,10 +30,13 @@ func assertTreeImplementation() {
 
 // Tree holds elements of the B-tree
 type Tree struct {
-	Root       *Node            // Root node
-	Comparator utils.Comparator // Key comparator
-	size       int              // Total number of keys in the tree
-	m          int              // order (maximum number of children)
+	Root          *Node            // Root node
+	Comparator    utils.Comparator // Key comparator
+	keyDecoder    utils.Decoder    // decodes keys from JSON, nil for Go's default JSON decoding (see SetDecoders)
+	valueDecoder  utils.Decoder    // decodes values from JSON, nil for Go's default JSON decoding
+	size          int              // Total number of keys in the tree
+	m             int              // order (maximum number of children)
+	modifications int              // Number of structural modifications, used by iterators to fail fast
 }
 
 // Node is a single element within the tree
@@ -59,12 +62,16 @@ func NewWith(order int, comparator utils.Comparator) *Tree {
 
 // NewWithIntComparator instantiates a B-tree with the order (maximum number of children) and the IntComparator, i.e. keys are of type int.
 func NewWithIntComparator(order int) *Tree {
-	return NewWith(order, utils.IntComparator)
+	tree := NewWith(order, utils.IntComparator)
+	tree.keyDecoder = utils.IntDecoder
+	return tree
 }
 
 // NewWithStringComparator instantiates a B-tree with the order (maximum number of children) and the StringComparator, i.e. keys are of type string.
 func NewWithStringComparator(order int) *Tree {
-	return NewWith(order, utils.StringComparator)
+	tree := NewWith(order, utils.StringComparator)
+	tree.keyDecoder = utils.StringDecoder
+	return tree
 }
 
 // Put inserts key-value pair node into the tree.
@@ -76,11 +83,13 @@ func (tree *Tree) Put(key interface{}, value interface{}) {
 	if tree.Root == nil {
 		tree.Root = &Node{Entries: []*Entry{entry}, Children: []*Node{}}
 		tree.size++
+		tree.modifications++
 		return
 	}
 
 	if tree.insert(tree.Root, entry) {
 		tree.size++
+		tree.modifications++
 	}
 }
 
@@ -102,6 +111,7 @@ func (tree *Tree) Remove(key interface{}) {
 	if found {
 		tree.delete(node, index)
 		tree.size--
+		tree.modifications++
 	}
 }
 
@@ -135,10 +145,30 @@ func (tree *Tree) Values() []interface{} {
 	return values
 }
 
+// BulkLoad replaces tree's elements with the given keys and their values (slices of the same length).
+// While keys are sorted in strictly ascending order, the tree is built bottom-up in linear time with nodes as full as possible,
+// elements from the first key out of order on are inserted one by one (see Loader).
+// Panics if the comparator can not compare the keys.
+func (tree *Tree) BulkLoad(keys []interface{}, values []interface{}) {
+	loader := tree.Loader()
+	for i := range keys {
+		if err := loader.Add(keys[i], values[i]); err != nil {
+			panic(err)
+		}
+	}
+	loader.Load()
+}
+
 // Clear removes all nodes from the tree.
 func (tree *Tree) Clear() {
 	tree.Root = nil
 	tree.size = 0
+	tree.modifications++
+}
+
+// Clone returns a copy of the tree with the same structure, order and comparator (keys and values themselves are not copied).
+func (tree *Tree) Clone() *Tree {
+	return &Tree{Root: tree.Root.clone(nil), Comparator: tree.Comparator, size: tree.size, m: tree.m, keyDecoder: tree.keyDecoder, valueDecoder: tree.valueDecoder}
 }
 
 // Height returns the height of the tree.
@@ -228,6 +258,21 @@ func (node *Node) height() int {
 	return height
 }
 
+// clone copies the subtree rooted at the node, including its entries, attaching it to the parent.
+func (node *Node) clone(parent *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	clone := &Node{Parent: parent, Entries: make([]*Entry, len(node.Entries)), Children: make([]*Node, len(node.Children))}
+	for i, entry := range node.Entries {
+		clone.Entries[i] = &Entry{Key: entry.Key, Value: entry.Value}
+	}
+	for i, child := range node.Children {
+		clone.Children[i] = child.clone(clone)
+	}
+	return clone
+}
+
 func (tree *Tree) isLeaf(node *Node) bool {
 	return len(node.Children) == 0
 }
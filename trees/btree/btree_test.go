@@ -5,7 +5,15 @@
 package btree
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/maps"
+	"github.com/emirpasic/gods/trees"
+	"github.com/emirpasic/gods/utils"
 	"testing"
 )
 
@@ -1009,6 +1017,121 @@ func TestBTreeIteratorLast(t *testing.T) {
 	}
 }
 
+func TestBTreeIteratorConcurrentModification(t *testing.T) {
+	expectPanic := func(f func() bool) {
+		defer func() {
+			if r := recover(); r != containers.ErrConcurrentModification {
+				t.Errorf("Got %v expected %v", r, containers.ErrConcurrentModification)
+			}
+		}()
+		f()
+	}
+
+	tree := NewWithIntComparator(3)
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	tree.Put(3, "c")
+	it := tree.Iterator()
+	it.Next()
+	tree.Put(1, "x") // not a structural modification
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Put(4, "d")
+	expectPanic(it.Next)
+	expectPanic(it.Prev)
+
+	it.Begin()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if actualValue, expectedValue := count, 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Remove(1)
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Remove(5) // not found
+	it.Prev()
+	tree.Remove(2)
+	expectPanic(it.Next)
+
+	it.End()
+	tree.Clear()
+	if actualValue, expectedValue := it.Prev(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeIteratorRemove(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for _, key := range []int{11, 5, 17, 2, 8, 14, 20, 1, 3, 6, 9, 12, 15, 18, 4, 7, 10, 13, 16, 19} {
+		tree.Put(key, key)
+	}
+	it := tree.Iterator()
+	it.Remove() // not positioned on an element
+	for it.Next() {
+		if it.Key().(int)%2 == 0 {
+			it.Remove()
+			it.Remove() // already removed
+		}
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 3 5 7 9 11 13 15 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.End(); it.Prev(); {
+		if it.Key().(int)%3 == 0 {
+			it.Remove()
+		}
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got %v expected %v", err, nil)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 5 7 11 13 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Size(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.Begin(); it.Next(); {
+		if it.Key() == 11 {
+			break
+		}
+	}
+	it.Remove()
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Next()
+	if actualValue, expectedValue := it.Key(), 13; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.SetValue("x")
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[1 5 7 x 17 19]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for it.Begin(); it.Next(); {
+		it.Remove()
+	}
+	if actualValue, expectedValue := tree.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Prev(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func TestBTree_search(t *testing.T) {
 	{
 		tree := NewWithIntComparator(3)
@@ -1074,6 +1197,29 @@ func assertValidTreeNode(t *testing.T, node *Node, expectedEntries int, expected
 	}
 }
 
+func TestBTreeClone(t *testing.T) {
+	tree := NewWithStringComparator(3)
+	for _, key := range []string{"d", "b", "f", "a", "c", "e", "g"} {
+		tree.Put(key, key)
+	}
+
+	clone := tree.Clone()
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := clone.String(), tree.String(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	clone.Put("a", "x")
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := containers.Equal(tree.Clone(), tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestBTreeSerialization(t *testing.T) {
 	tree := NewWithStringComparator(3)
 	tree.Put("c", "3")
@@ -1105,6 +1251,194 @@ func TestBTreeSerialization(t *testing.T) {
 	assert()
 }
 
+func TestBTreeMarshalJSON(t *testing.T) {
+	type document struct {
+		Tree *Tree `json:"tree"`
+	}
+	tree := NewWithStringComparator(3)
+	tree.Put("a", "1")
+	tree.Put("b", "2")
+
+	data, err := json.Marshal(&document{Tree: tree})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected, err := tree.ToJSON()
+	if actualValue, expectedValue := string(data), `{"tree":`+string(expected)+`}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Tree: NewWithStringComparator(3)}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Tree.Size(), tree.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Tree.Values()), fmt.Sprint(tree.Values()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = json.Unmarshal(data, &document{})
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Tree).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoComparator; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeBinarySerialization(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+
+	assert := func(restored *Tree, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := restored.Size(), tree.Size(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, key := range tree.Keys() {
+			if actualValue, expectedValue := restored.Keys()[i], key; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+		for i, value := range tree.Values() {
+			if actualValue, expectedValue := restored.Values()[i], value; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+
+	data, err := tree.MarshalBinary()
+	restored := NewWithIntComparator(3)
+	if err == nil {
+		err = restored.UnmarshalBinary(data)
+	}
+	assert(restored, err)
+
+	data, err = tree.MarshalBinaryWith(utils.IntCodec, utils.StringCodec)
+	restored = NewWithIntComparator(3)
+	if err == nil {
+		err = restored.UnmarshalBinaryWith(data, utils.IntCodec, utils.StringCodec)
+	}
+	assert(restored, err)
+
+	err = restored.UnmarshalBinaryWith(data, nil, nil)
+	if err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+	assert(restored, nil)
+
+	type document struct {
+		Tree *Tree
+	}
+	buffer := new(bytes.Buffer)
+	err = gob.NewEncoder(buffer).Encode(&document{Tree: tree})
+	doc := &document{Tree: NewWithIntComparator(3)}
+	if err == nil {
+		err = gob.NewDecoder(buffer).Decode(doc)
+	}
+	assert(doc.Tree, err)
+}
+
+func TestBTreeStreaming(t *testing.T) {
+	tree := NewWithStringComparator(3)
+	tree.Put("b", "2")
+	tree.Put("a", "1")
+	tree.Put("c", "3")
+
+	assert := func(restored *Tree, err error) {
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprint(restored.Values()), fmt.Sprint(tree.Values()); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	err := tree.WriteJSON(buffer)
+	restored := NewWithStringComparator(3)
+	if err == nil {
+		err = restored.ReadJSON(buffer)
+	}
+	assert(restored, err)
+
+	buffer.Reset()
+	err = tree.WriteBinary(buffer)
+	restored = NewWithStringComparator(3)
+	if err == nil {
+		err = restored.ReadBinary(buffer)
+	}
+	assert(restored, err)
+}
+
+func TestBTreeSerializationWithDecoders(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	tree.Put(3, 30)
+	tree.Put(1, 10)
+	tree.Put(2, 20)
+
+	json, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(json), "[[1,10],[2,20],[3,30]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Clear()
+	err = tree.FromJSONWith(json, utils.IntDecoder, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, found := tree.Get(2); actualValue != 20 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 20)
+	}
+
+	err = tree.FromJSONWith([]byte(`[[4,40],[5]]`), utils.IntDecoder, utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for malformed input")
+	}
+	err = tree.FromJSONWith([]byte(`[["x",40]]`), utils.IntDecoder, utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = tree.FromJSON(json)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	tree.SetDecoders(nil, nil)
+	err = tree.FromJSON(json)
+	if err == nil {
+		t.Errorf("Got no error for keys decoded as float64")
+	}
+	if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// object of earlier versions
+	err = tree.FromJSONWith([]byte(`{"4":40,"5":50}`), utils.IntDecoder, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := tree.Get(5); actualValue != 50 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 50)
+	}
+}
+
 func benchmarkGet(b *testing.B, tree *Tree, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -1129,6 +1463,198 @@ func benchmarkRemove(b *testing.B, tree *Tree, size int) {
 	}
 }
 
+func TestBTreeToDOT(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for i := 1; i <= 4; i++ {
+		tree.Put(i, "x")
+	}
+	var buffer bytes.Buffer
+	if err := tree.ToDOT(&buffer, nil); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := `digraph "tree" {
+	node [shape=record];
+	n0 [label="<c0>|2|<c1>"];
+	n1 [label="1"];
+	n0:c0 -> n1;
+	n2 [label="3|4"];
+	n0:c1 -> n2;
+}
+`
+	if actualValue := buffer.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	tree = NewWithStringComparator(3)
+	tree.Put(`k"1"`, "v\n1")
+	buffer.Reset()
+	options := &trees.DOTOptions{Name: `a "b"`, Values: true, Format: func(element interface{}) string { return element.(string) }}
+	if err := tree.ToDOT(&buffer, options); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected = `digraph "a \"b\"" {
+	node [shape=record];
+	n0 [label="k\"1\": v\n1"];
+}
+`
+	if actualValue := buffer.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	tree.Clear()
+	buffer.Reset()
+	if err := tree.ToDOT(&buffer, nil); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected = `digraph "tree" {
+	node [shape=record];
+}
+`
+	if actualValue := buffer.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+}
+
+func TestBTreeBulkLoad(t *testing.T) {
+	for _, order := range []int{3, 4, 5, 6} {
+		for n := 0; n < 150; n++ {
+			keys := make([]interface{}, n)
+			values := make([]interface{}, n)
+			for i := range keys {
+				keys[i], values[i] = i, -i
+			}
+			tree := NewWithIntComparator(order)
+			tree.Put(-1, 1)
+			tree.BulkLoad(keys, values)
+			if actualValue, expectedValue := tree.Size(), n; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got invalid tree of order %v and size %v: %v", order, n, err)
+			}
+			for i := 0; i < n; i++ {
+				if actualValue, found := tree.Get(i); actualValue != -i || !found {
+					t.Errorf("Got %v expected %v", actualValue, -i)
+				}
+			}
+			for i := n; i < n+10; i++ {
+				tree.Put(i, i)
+			}
+			for i := 0; i < n; i += 3 {
+				tree.Remove(i)
+			}
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got invalid tree after modifying bulk loaded tree of order %v and size %v: %v", order, n, err)
+			}
+		}
+	}
+
+	for _, test := range [][3]int{{3, 2, 1}, {3, 8, 2}, {3, 26, 3}, {5, 124, 3}, {5, 125, 4}} {
+		order, n, height := test[0], test[1], test[2]
+		keys := make([]interface{}, n)
+		for i := range keys {
+			keys[i] = i
+		}
+		tree := NewWithIntComparator(order)
+		tree.BulkLoad(keys, keys)
+		if actualValue, expectedValue := tree.Height(), height; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v keys in tree of order %v", actualValue, expectedValue, n, order)
+		}
+	}
+
+	tree := NewWithIntComparator(3)
+	tree.BulkLoad([]interface{}{3, 1, 2, 1}, []interface{}{"c", "a", "b", "x"})
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[x b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeLoader(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	tree.Put(0, 0)
+	loader := tree.Loader()
+	for _, key := range []int{1, 2, 3, 5, 4, 6} {
+		if err := loader.Add(key, -key); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	if err := loader.Add("7", 7); err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[0]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	loader.Load()
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got invalid tree: %v", err)
+	}
+}
+
+func TestBTreeValidate(t *testing.T) {
+	for _, order := range []int{3, 4, 5, 6} {
+		tree := NewWithIntComparator(order)
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		for i := 0; i < 100; i++ {
+			tree.Put(i*37%101, i)
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got error %v after putting %v into tree of order %v", err, i*37%101, order)
+			}
+		}
+		for i := 0; i < 100; i += 2 {
+			tree.Remove(i * 37 % 101)
+			if err := tree.Validate(); err != nil {
+				t.Errorf("Got error %v after removing %v from tree of order %v", err, i*37%101, order)
+			}
+		}
+
+		corruptions := []func(tree *Tree){
+			func(tree *Tree) { tree.Root.Parent = tree.Root.Children[0] },
+			func(tree *Tree) { tree.Root.Children[0].Parent = nil },
+			func(tree *Tree) { tree.Root.Entries[0].Key = tree.Root.Children[0].Entries[0].Key },
+			func(tree *Tree) { tree.Root.Children[0] = nil },
+			func(tree *Tree) { tree.Root.Children = tree.Root.Children[1:] },
+			func(tree *Tree) { tree.Root.Children[1].Entries = tree.Root.Children[1].Entries[:0] },
+			func(tree *Tree) {
+				node := tree.Left()
+				for len(node.Entries) <= tree.maxEntries() {
+					node.Entries = append(node.Entries, &Entry{Key: node.Entries[len(node.Entries)-1].Key.(int) + 1})
+				}
+			},
+			func(tree *Tree) { tree.Root.Children[0].Children = nil },
+			func(tree *Tree) { tree.size++ },
+		}
+		for i, corrupt := range corruptions {
+			clone := tree.Clone()
+			corrupt(clone)
+			if err := clone.Validate(); err == nil {
+				t.Errorf("Got no error for corruption %v of tree of order %v", i, order)
+			}
+		}
+	}
+}
+
+func TestBTreeConformance(t *testing.T) {
+	containertest.TestMap(t, func() maps.Map { return NewWithIntComparator(3) })
+	for _, keys := range [][]interface{}{{}, {1}, {1, 2, 3}} {
+		tree := NewWithIntComparator(3)
+		values := []interface{}{}
+		for _, key := range keys {
+			tree.Put(key, -key.(int))
+			values = append(values, -key.(int))
+		}
+		it := tree.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+	}
+}
+
 func BenchmarkBTreeGet100(b *testing.B) {
 	b.StopTimer()
 	size := 100
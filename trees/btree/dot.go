@@ -0,0 +1,75 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"io"
+	"strings"
+)
+
+// recordEscaper escapes characters with special meaning in labels of DOT record nodes
+var recordEscaper = strings.NewReplacer("{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`)
+
+// ToDOT writes the tree into the writer as a Graphviz DOT graph with nodes drawn as records of their entries.
+// Children of internal nodes are connected to the fields between the entries they are ordered by.
+func (tree *Tree) ToDOT(w io.Writer, options *trees.DOTOptions) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "digraph %s {\n", dotGraphName(options))
+	fmt.Fprintln(writer, "\tnode [shape=record];")
+	if tree.Root != nil {
+		id := 0
+		outputDOT(writer, tree.Root, &id, options)
+	}
+	fmt.Fprintln(writer, "}")
+	return writer.Flush()
+}
+
+// outputDOT writes the subtree rooted at the node, numbering nodes in pre-order starting from id, and returns the node's number.
+func outputDOT(writer *bufio.Writer, node *Node, id *int, options *trees.DOTOptions) int {
+	n := *id
+	*id++
+	fields := make([]string, 0, 2*len(node.Entries)+1)
+	for e, entry := range node.Entries {
+		if len(node.Children) > 0 {
+			fields = append(fields, fmt.Sprintf("<c%d>", e))
+		}
+		fields = append(fields, recordEscaper.Replace(dotLabel(options, entry.Key, entry.Value)))
+	}
+	if len(node.Children) > 0 {
+		fields = append(fields, fmt.Sprintf("<c%d>", len(node.Entries)))
+	}
+	fmt.Fprintf(writer, "\tn%d [label=\"%s\"];\n", n, strings.Join(fields, "|"))
+	for c, child := range node.Children {
+		fmt.Fprintf(writer, "\tn%d:c%d -> n%d;\n", n, c, outputDOT(writer, child, id, options))
+	}
+	return n
+}
+
+// dotEscaper escapes characters with special meaning in quoted DOT strings
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", "")
+
+// dotGraphName returns the quoted name of the graph, "tree" if the options name none.
+func dotGraphName(options *trees.DOTOptions) string {
+	if options == nil || options.Name == "" {
+		return `"tree"`
+	}
+	return `"` + dotEscaper.Replace(options.Name) + `"`
+}
+
+// dotLabel returns the text of the label of a node with the key and value, escaped to be used within a quoted DOT string.
+func dotLabel(options *trees.DOTOptions, key interface{}, value interface{}) string {
+	format := func(element interface{}) string { return fmt.Sprint(element) }
+	if options != nil && options.Format != nil {
+		format = options.Format
+	}
+	label := format(key)
+	if options != nil && options.Values {
+		label += ": " + format(value)
+	}
+	return dotEscaper.Replace(label)
+}
@@ -12,10 +12,12 @@ func assertIteratorImplementation() {
 
 // Iterator holding the iterator's state
 type Iterator struct {
-	tree     *Tree
-	node     *Node
-	entry    *Entry
-	position position
+	tree          *Tree
+	node          *Node
+	entry         *Entry
+	position      position
+	removed       bool // current entry was removed, the iterator is between its neighbours
+	modifications int  // tree's modifications when the iterator moved onto the current entry
 }
 
 type position byte
@@ -25,6 +27,8 @@ const (
 )
 
 // Iterator returns a stateful iterator whose elements are key/value pairs.
+// The iterator is fail-fast: Next() and Prev() panic with containers.ErrConcurrentModification
+// if the tree has been structurally modified since the iterator moved onto its current element.
 func (tree *Tree) Iterator() Iterator {
 	return Iterator{tree: tree, node: nil, position: begin}
 }
@@ -34,6 +38,14 @@ func (tree *Tree) Iterator() Iterator {
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
 // Modifies the state of the iterator.
 func (iterator *Iterator) Next() bool {
+	iterator.checkModifications()
+	// If the current entry was removed, find the entry following its key
+	if iterator.removed {
+		if !iterator.seek(true) {
+			goto end
+		}
+		goto between
+	}
 	// If already at end, go to end
 	if iterator.position == end {
 		goto end
@@ -86,6 +98,7 @@ end:
 
 between:
 	iterator.position = between
+	iterator.modifications = iterator.tree.modifications
 	return true
 }
 
@@ -93,6 +106,14 @@ between:
 // If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
 // Modifies the state of the iterator.
 func (iterator *Iterator) Prev() bool {
+	iterator.checkModifications()
+	// If the current entry was removed, find the entry preceding its key
+	if iterator.removed {
+		if !iterator.seek(false) {
+			goto begin
+		}
+		goto between
+	}
 	// If already at beginning, go to begin
 	if iterator.position == begin {
 		goto begin
@@ -145,6 +166,7 @@ begin:
 
 between:
 	iterator.position = between
+	iterator.modifications = iterator.tree.modifications
 	return true
 }
 
@@ -166,6 +188,7 @@ func (iterator *Iterator) Begin() {
 	iterator.node = nil
 	iterator.position = begin
 	iterator.entry = nil
+	iterator.removed = false
 }
 
 // End moves the iterator past the last element (one-past-the-end).
@@ -174,6 +197,7 @@ func (iterator *Iterator) End() {
 	iterator.node = nil
 	iterator.position = end
 	iterator.entry = nil
+	iterator.removed = false
 }
 
 // First moves the iterator to the first element and returns true if there was a first element in the container.
@@ -191,3 +215,56 @@ func (iterator *Iterator) Last() bool {
 	iterator.End()
 	return iterator.Prev()
 }
+
+// Remove removes the current element from the tree.
+// Afterwards the iterator is positioned between the neighbours of the removed element,
+// i.e. Next() moves to the element after it and Prev() to the element before it.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) Remove() {
+	if iterator.position != between || iterator.removed {
+		return
+	}
+	iterator.checkModifications()
+	iterator.tree.Remove(iterator.entry.Key)
+	iterator.node = nil
+	iterator.removed = true
+	iterator.modifications = iterator.tree.modifications
+}
+
+// SetValue replaces the current element's value.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) SetValue(value interface{}) {
+	if iterator.position != between || iterator.removed {
+		return
+	}
+	iterator.checkModifications()
+	iterator.entry.Value = value
+}
+
+// seek moves the iterator from the removed entry to the entry with the smallest bigger key (next is true)
+// or the biggest smaller key (next is false) and returns true if there is one.
+func (iterator *Iterator) seek(next bool) bool {
+	key := iterator.entry.Key
+	iterator.node, iterator.entry, iterator.removed = nil, nil, false
+	// entries found deeper in the tree are closer to the key
+	for node := iterator.tree.Root; node != nil; {
+		e, _ := iterator.tree.search(node, key)
+		if next && e < len(node.Entries) {
+			iterator.node, iterator.entry = node, node.Entries[e]
+		} else if !next && e > 0 {
+			iterator.node, iterator.entry = node, node.Entries[e-1]
+		}
+		if iterator.tree.isLeaf(node) {
+			break
+		}
+		node = node.Children[e]
+	}
+	return iterator.entry != nil
+}
+
+// checkModifications panics if the iterator is positioned on an entry and the tree has been structurally modified since it moved there.
+func (iterator *Iterator) checkModifications() {
+	if iterator.position == between && iterator.modifications != iterator.tree.modifications {
+		panic(containers.ErrConcurrentModification)
+	}
+}
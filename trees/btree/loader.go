@@ -0,0 +1,128 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import "github.com/emirpasic/gods/utils"
+
+// Loader replaces the elements of a tree with elements added one at a time, e.g. while they are decoded,
+// leaving the tree unchanged until Load is called.
+//
+// While keys are added in strictly ascending order, the tree is built bottom-up in linear time: entries fill the last
+// node of each level, an entry added to a full node separates it from a new node in the level above.
+// The last nodes of the levels are filled up from their left siblings once all elements were added.
+// From the first key that is out of order on, elements are inserted one by one.
+type Loader struct {
+	tree   *Tree       // tree whose elements are replaced
+	loaded *Tree       // tree of the added elements
+	spine  []*Node     // last node of each level, from the leaves up to the root, while keys are in order
+	key    interface{} // key of the last added element
+	sorted bool        // true while keys are added in strictly ascending order
+}
+
+// Loader returns a loader replacing tree's elements (see BulkLoad and ReadJSON).
+func (tree *Tree) Loader() *Loader {
+	return &Loader{tree: tree, loaded: &Tree{Comparator: tree.Comparator, m: tree.m}, sorted: true}
+}
+
+// Add adds the element to the elements being loaded.
+// Returns an error if the comparator can not compare the key with the key of the previously added element.
+func (loader *Loader) Add(key interface{}, value interface{}) error {
+	previous := key
+	if loader.loaded.size > 0 {
+		previous = loader.key
+	}
+	compare, err := utils.CompareKeys(loader.loaded.Comparator, previous, key)
+	if err != nil {
+		return err
+	}
+	loader.key = key
+	if loader.sorted && (loader.loaded.size == 0 || compare < 0) {
+		loader.append(&Entry{Key: key, Value: value})
+		loader.loaded.size++
+		return nil
+	}
+	if loader.sorted {
+		loader.build()
+	}
+	loader.loaded.Put(key, value)
+	return nil
+}
+
+// Load replaces tree's elements with the added elements.
+func (loader *Loader) Load() {
+	if loader.sorted {
+		loader.build()
+	}
+	loader.tree.Root = loader.loaded.Root
+	loader.tree.size = loader.loaded.size
+	loader.tree.modifications++
+}
+
+// append adds the entry, whose key follows all keys added so far, to the last leaf or,
+// if the leaf is full, to the level above as the separator of the leaf and a new leaf.
+func (loader *Loader) append(entry *Entry) {
+	maxEntries := loader.loaded.maxEntries()
+	if len(loader.spine) == 0 {
+		loader.spine = append(loader.spine, &Node{Children: []*Node{}})
+	}
+	if leaf := loader.spine[0]; len(leaf.Entries) < maxEntries {
+		leaf.Entries = append(leaf.Entries, entry)
+		return
+	}
+	node := &Node{Children: []*Node{}}
+	for level := 1; ; level++ {
+		if level == len(loader.spine) {
+			root := &Node{Entries: []*Entry{entry}, Children: []*Node{loader.spine[level-1], node}}
+			setParent(root.Children, root)
+			loader.spine[level-1] = node
+			loader.spine = append(loader.spine, root)
+			return
+		}
+		loader.spine[level-1] = node
+		if parent := loader.spine[level]; len(parent.Entries) < maxEntries {
+			parent.Entries = append(parent.Entries, entry)
+			parent.Children = append(parent.Children, node)
+			node.Parent = parent
+			return
+		}
+		// the parent is full as well, the entry separates it from a new parent of the node
+		parent := &Node{Children: []*Node{node}}
+		node.Parent = parent
+		node = parent
+	}
+}
+
+// build fills up the last node of each level below the root from its left sibling, which is full,
+// so that all nodes hold at least the minimum number of entries.
+func (loader *Loader) build() {
+	if len(loader.spine) > 0 {
+		for level := len(loader.spine) - 2; level >= 0; level-- {
+			if node := loader.spine[level]; len(node.Entries) < loader.loaded.minEntries() {
+				rebalance(node)
+			}
+		}
+		loader.loaded.Root = loader.spine[len(loader.spine)-1]
+	}
+	loader.spine = nil
+	loader.sorted = false
+}
+
+// rebalance distributes the entries and children of the node, which is the last child of its parent,
+// and of its left sibling evenly among the two.
+func rebalance(node *Node) {
+	parent := node.Parent
+	index := len(parent.Children) - 1
+	sibling := parent.Children[index-1]
+	entries := make([]*Entry, 0, len(sibling.Entries)+1+len(node.Entries))
+	entries = append(append(append(entries, sibling.Entries...), parent.Entries[index-1]), node.Entries...)
+	children := append(append([]*Node{}, sibling.Children...), node.Children...)
+	middle := len(entries) / 2
+	sibling.Entries, parent.Entries[index-1], node.Entries = entries[:middle:middle], entries[middle], entries[middle+1:]
+	if len(children) > 0 {
+		sibling.Children, node.Children = children[:middle+1:middle+1], children[middle+1:]
+		setParent(sibling.Children, sibling)
+		setParent(node.Children, node)
+	}
+}
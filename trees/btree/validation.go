@@ -0,0 +1,85 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package btree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the structural invariants of the tree and returns an error describing the first violation found, if any.
+// It checks that keys are in strictly ascending order, that parent pointers are consistent, that nodes hold between
+// ⌈m/2⌉-1 (except the root) and m-1 entries, that internal nodes have one child more than entries, that all leaves
+// appear on the same level and that the size of the tree matches the number of its entries.
+func (tree *Tree) Validate() error {
+	if tree.Root != nil && tree.Root.Parent != nil {
+		return errors.New("btree: root has a parent")
+	}
+	v := &validator{tree: tree, leafDepth: -1}
+	if tree.Root != nil {
+		if err := v.validate(tree.Root, nil, 0); err != nil {
+			return err
+		}
+	}
+	if v.count != tree.size {
+		return fmt.Errorf("btree: size is %d, but tree has %d entries", tree.size, v.count)
+	}
+	return nil
+}
+
+// validator holds the state of the in-order traversal of the tree in Validate
+type validator struct {
+	tree      *Tree
+	previous  *Entry // last visited entry
+	count     int    // number of visited entries
+	leafDepth int    // depth of the leaves, -1 until the first leaf is visited
+}
+
+// validate checks the subtree rooted at the node attached to the parent at the given depth.
+func (v *validator) validate(node *Node, parent *Node, depth int) error {
+	if node == nil {
+		return errors.New("btree: node has a nil child")
+	}
+	if node.Parent != parent {
+		return fmt.Errorf("btree: node %v has a wrong parent", node.Entries)
+	}
+	if len(node.Entries) == 0 {
+		return errors.New("btree: node has no entries")
+	}
+	if len(node.Entries) > v.tree.maxEntries() {
+		return fmt.Errorf("btree: node %v has %d entries, at most %d allowed", node.Entries, len(node.Entries), v.tree.maxEntries())
+	}
+	if parent != nil && len(node.Entries) < v.tree.minEntries() {
+		return fmt.Errorf("btree: node %v has %d entries, at least %d required", node.Entries, len(node.Entries), v.tree.minEntries())
+	}
+	if v.tree.isLeaf(node) {
+		if v.leafDepth < 0 {
+			v.leafDepth = depth
+		} else if v.leafDepth != depth {
+			return fmt.Errorf("btree: leaf %v is on level %d, other leaves are on level %d", node.Entries, depth, v.leafDepth)
+		}
+	} else if len(node.Children) != len(node.Entries)+1 {
+		return fmt.Errorf("btree: node %v has %d entries and %d children", node.Entries, len(node.Entries), len(node.Children))
+	}
+	for e, entry := range node.Entries {
+		if !v.tree.isLeaf(node) {
+			if err := v.validate(node.Children[e], node, depth+1); err != nil {
+				return err
+			}
+		}
+		if entry == nil {
+			return errors.New("btree: node has a nil entry")
+		}
+		if v.previous != nil && v.tree.Comparator(v.previous.Key, entry.Key) >= 0 {
+			return fmt.Errorf("btree: keys %v and %v are out of order", v.previous.Key, entry.Key)
+		}
+		v.previous = entry
+		v.count++
+	}
+	if !v.tree.isLeaf(node) {
+		return v.validate(node.Children[len(node.Entries)], node, depth+1)
+	}
+	return nil
+}
@@ -0,0 +1,516 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package diskbtree implements a B-tree stored in a file.
+//
+// Every node of the tree is stored in a fixed-size page of the file and loaded on demand, so that the tree can hold
+// more entries than fit in memory. Recently used nodes are kept in a page cache of a bounded size.
+//
+// The tree has the same structure as the in-memory B-tree (see package btree) and is balanced by the same splits,
+// rotations and merges. Keys are ordered by a comparator, keys and values are stored in pages encoded by codecs.
+//
+// Updates are crash-safe through copy-on-write: pages of the last committed tree are never overwritten, modified
+// nodes are written to other pages instead. Sync writes the modified pages and then commits them by writing the
+// location of the new root into one of two alternating slots of the meta page, protected by a checksum. If the process crashes,
+// the tree is opened in the state of the last successful Sync.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/B-tree, https://en.wikipedia.org/wiki/Copy-on-write
+package diskbtree
+
+import (
+	"errors"
+	"github.com/emirpasic/gods/utils"
+	"os"
+)
+
+var (
+	// ErrEntryTooLarge is returned by Put when the encoded key and value do not fit the share of a page of an entry.
+	ErrEntryTooLarge = errors.New("diskbtree: entry too large for page")
+
+	// ErrCorrupted is returned when the file is not a tree file or its content is inconsistent.
+	ErrCorrupted = errors.New("diskbtree: corrupted file")
+)
+
+// Tree holds elements of the B-tree stored in a file
+type Tree struct {
+	Comparator    utils.Comparator // Key comparator
+	keyCodec      utils.Codec
+	valueCodec    utils.Codec
+	file          *os.File
+	pageSize      int
+	m             int             // order (maximum number of children)
+	root          pageID          // page of the root node, 0 if the tree is empty
+	size          int             // Total number of keys in the tree
+	pages         pageID          // number of pages of the file in use
+	txid          uint64          // id of the last commit
+	cache         *cache          // recently used nodes, including all modified nodes that have not been written yet
+	free          []pageID        // pages used neither by the committed tree nor by the current tree
+	pending       []pageID        // pages used by the committed tree only, free after the next commit
+	fresh         map[pageID]bool // pages allocated since the last commit, whose nodes are modified in place
+	err           error           // error that interrupted an update, after which the tree may be inconsistent
+	modifications int             // Number of modifications, used by iterators to fail fast
+}
+
+// Options configure a tree.
+// The page size and the order are fixed when the file is created, later values are ignored.
+type Options struct {
+	PageSize  int // size of a page in bytes, 4096 by default
+	Order     int // maximum number of children of a node, 32 by default
+	CacheSize int // maximum number of nodes kept in memory between operations, 128 by default
+}
+
+// entry is a key-value pair contained within nodes.
+// The value is kept encoded and only decoded when it is read.
+type entry struct {
+	Key   interface{}
+	key   []byte
+	value []byte
+}
+
+// node is a single element within the tree, stored in its page
+type node struct {
+	id       pageID
+	entries  []*entry
+	children []pageID // pages of the children, empty for leaves
+	dirty    bool     // modified since it was last written to its page
+}
+
+// step is a node on the path from the root and the index of the followed child or of the entry in it
+type step struct {
+	node  *node
+	index int
+}
+
+// Open opens the tree stored in the file with the path, creating the file if it does not exist.
+// Keys are ordered by the comparator and encoded by the key codec, values are encoded by the value codec.
+// Options may be nil for the default options.
+func Open(path string, comparator utils.Comparator, keyCodec utils.Codec, valueCodec utils.Codec, options *Options) (*Tree, error) {
+	if options == nil {
+		options = &Options{}
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	tree := &Tree{
+		Comparator: comparator,
+		keyCodec:   keyCodec,
+		valueCodec: valueCodec,
+		file:       file,
+		cache:      newCache(options.CacheSize),
+		fresh:      make(map[pageID]bool),
+	}
+	info, err := file.Stat()
+	if err == nil {
+		if info.Size() == 0 {
+			err = tree.create(options)
+		} else {
+			err = tree.load()
+		}
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return tree, nil
+}
+
+// OpenWithIntComparator opens the tree stored in the file with the path with the IntComparator and the IntCodec,
+// i.e. keys are of type int, creating the file if it does not exist.
+func OpenWithIntComparator(path string, valueCodec utils.Codec, options *Options) (*Tree, error) {
+	return Open(path, utils.IntComparator, utils.IntCodec, valueCodec, options)
+}
+
+// OpenWithStringComparator opens the tree stored in the file with the path with the StringComparator and the
+// StringCodec, i.e. keys are of type string, creating the file if it does not exist.
+func OpenWithStringComparator(path string, valueCodec utils.Codec, options *Options) (*Tree, error) {
+	return Open(path, utils.StringComparator, utils.StringCodec, valueCodec, options)
+}
+
+// Put inserts key-value pair node into the tree.
+// If key already exists, then its value is updated with the new value.
+// Returns ErrEntryTooLarge if the encoded key and value are longer than MaxEntrySize.
+// Key should adhere to the comparator's and the codec's type assertion, otherwise method panics.
+func (tree *Tree) Put(key interface{}, value interface{}) error {
+	if tree.err != nil {
+		return tree.err
+	}
+	e, err := tree.encode(key, value)
+	if err != nil {
+		return err
+	}
+	tree.modifications++
+	if tree.root == 0 {
+		root := tree.newNode()
+		root.entries = []*entry{e}
+		tree.root = root.id
+		tree.size++
+		return tree.done(nil)
+	}
+	path, index, found, err := tree.searchRecursively(key)
+	if err != nil {
+		return tree.done(err)
+	}
+	tree.writablePath(path)
+	node := path[len(path)-1].node
+	if found {
+		node.entries[index] = e
+		return tree.done(nil)
+	}
+	node.entries = append(node.entries, nil)
+	copy(node.entries[index+1:], node.entries[index:])
+	node.entries[index] = e
+	tree.split(path)
+	tree.size++
+	return tree.done(nil)
+}
+
+// Get searches the node in the tree by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Get(key interface{}) (value interface{}, found bool, err error) {
+	if tree.err != nil || tree.root == 0 {
+		return nil, false, tree.err
+	}
+	path, index, found, err := tree.searchRecursively(key)
+	if err == nil && found {
+		value, err = tree.valueCodec.Decode(path[len(path)-1].node.entries[index].value)
+	}
+	if err = tree.done(err); err != nil {
+		return nil, false, err
+	}
+	return value, found, nil
+}
+
+// Remove remove the node from the tree by key.
+// If reading a node fails while the tree is being rebalanced, all further updates return the error,
+// and the tree has to be reopened in the state of its last commit.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Remove(key interface{}) error {
+	if tree.err != nil || tree.root == 0 {
+		return tree.err
+	}
+	path, index, found, err := tree.searchRecursively(key)
+	if err != nil || !found {
+		return tree.done(err)
+	}
+	tree.modifications++
+	tree.writablePath(path)
+	if err := tree.delete(path, index); err != nil {
+		// the tree is left partially rebalanced, only the committed tree can be recovered
+		tree.err = err
+		return err
+	}
+	tree.size--
+	return tree.done(nil)
+}
+
+// Empty returns true if tree does not contain any nodes
+func (tree *Tree) Empty() bool {
+	return tree.size == 0
+}
+
+// Size returns number of nodes in the tree.
+func (tree *Tree) Size() int {
+	return tree.size
+}
+
+// Range calls the function for each entry with a key greater than or equal to from and less than to, in key order.
+// Iteration stops as soon as the function returns false. The function must not modify the tree.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Range(from interface{}, to interface{}, f func(key interface{}, value interface{}) bool) error {
+	it := tree.Iterator()
+	for found := it.seek(from); found && tree.Comparator(it.Key(), to) < 0; found = it.Next() {
+		value := it.Value()
+		if it.err != nil || !f(it.Key(), value) {
+			break
+		}
+	}
+	return it.Err()
+}
+
+// MaxEntrySize returns the maximum total length of an encoded key and value, which is the share of an entry of a page.
+func (tree *Tree) MaxEntrySize() int {
+	return (tree.pageSize-nodeHeaderSize-tree.maxChildren()*pageIDSize)/tree.maxEntries() - 2*lengthSize
+}
+
+// Sync commits the modifications of the tree to the file.
+// The modified nodes are written to their pages and flushed to stable storage, before the new root is recorded in the
+// meta page and flushed as well. Pages that only the previous commit used are reused afterwards.
+func (tree *Tree) Sync() error {
+	if tree.err != nil {
+		return tree.err
+	}
+	if err := tree.flush(); err != nil {
+		return err
+	}
+	if err := tree.commit(); err != nil {
+		return err
+	}
+	tree.free = append(tree.free, tree.pending...)
+	tree.pending = nil
+	tree.fresh = make(map[pageID]bool)
+	return nil
+}
+
+// Close commits the modifications of the tree with Sync and closes its file.
+func (tree *Tree) Close() error {
+	err := tree.Sync()
+	if closeErr := tree.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (tree *Tree) isLeaf(node *node) bool {
+	return len(node.children) == 0
+}
+
+func (tree *Tree) maxChildren() int {
+	return tree.m
+}
+
+func (tree *Tree) minChildren() int {
+	return (tree.m + 1) / 2 // ceil(m/2)
+}
+
+func (tree *Tree) maxEntries() int {
+	return tree.maxChildren() - 1
+}
+
+func (tree *Tree) minEntries() int {
+	return tree.minChildren() - 1
+}
+
+func (tree *Tree) middle() int {
+	return (tree.m - 1) / 2 // "-1" to favor right nodes to have more keys when splitting
+}
+
+// encode returns the entry with the encoded key and value, or ErrEntryTooLarge if they do not fit in a page.
+func (tree *Tree) encode(key interface{}, value interface{}) (*entry, error) {
+	encodedKey, err := tree.keyCodec.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+	encodedValue, err := tree.valueCodec.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(encodedKey)+len(encodedValue) > tree.MaxEntrySize() {
+		return nil, ErrEntryTooLarge
+	}
+	return &entry{Key: key, key: encodedKey, value: encodedValue}, nil
+}
+
+// search searches only within the single node among its entries
+func (tree *Tree) search(node *node, key interface{}) (index int, found bool) {
+	low, high := 0, len(node.entries)-1
+	var mid int
+	for low <= high {
+		mid = (high + low) / 2
+		compare := tree.Comparator(key, node.entries[mid].Key)
+		switch {
+		case compare > 0:
+			low = mid + 1
+		case compare < 0:
+			high = mid - 1
+		case compare == 0:
+			return mid, true
+		}
+	}
+	return low, false
+}
+
+// searchRecursively searches down the tree from the root and returns the path to the node with the key or to the
+// leaf where the key belongs, with the index of the entry or of the insert position in the last node.
+// Tree must not be empty.
+func (tree *Tree) searchRecursively(key interface{}) (path []step, index int, found bool, err error) {
+	for id := tree.root; ; {
+		node, err := tree.node(id)
+		if err != nil {
+			return nil, 0, false, err
+		}
+		index, found = tree.search(node, key)
+		path = append(path, step{node, index})
+		if found || tree.isLeaf(node) {
+			return path, index, found, nil
+		}
+		id = node.children[index]
+	}
+}
+
+// writablePath replaces the nodes of the path, starting at the root, with their writable copies.
+func (tree *Tree) writablePath(path []step) {
+	for i := range path {
+		if i == 0 {
+			path[i].node = tree.writable(path[i].node)
+			tree.root = path[i].node.id
+		} else {
+			path[i].node = tree.writableChild(path[i-1].node, path[i-1].index, path[i].node)
+		}
+	}
+}
+
+// writableChild returns the writable copy of the child of the writable parent at the index and points the parent to it.
+func (tree *Tree) writableChild(parent *node, index int, child *node) *node {
+	child = tree.writable(child)
+	parent.children[index] = child.id
+	return child
+}
+
+// split splits the last node of the writable path if it has too many entries, moving its middle entry up to its
+// parent, which is split in turn, up to the root.
+func (tree *Tree) split(path []step) {
+	for i := len(path) - 1; i >= 0; i-- {
+		node := path[i].node
+		if len(node.entries) <= tree.maxEntries() {
+			return
+		}
+		middle := tree.middle()
+		separator := node.entries[middle]
+
+		// the node keeps the left half and the right half moves to a new node
+		right := tree.newNode()
+		right.entries = append([]*entry(nil), node.entries[middle+1:]...)
+		node.entries = append([]*entry(nil), node.entries[:middle]...)
+		if !tree.isLeaf(node) {
+			right.children = append([]pageID(nil), node.children[middle+1:]...)
+			node.children = append([]pageID(nil), node.children[:middle+1]...)
+		}
+
+		if i == 0 {
+			root := tree.newNode()
+			root.entries = []*entry{separator}
+			root.children = []pageID{node.id, right.id}
+			tree.root = root.id
+			return
+		}
+
+		// insert the middle entry into the parent, followed by the right node
+		parent, insertPosition := path[i-1].node, path[i-1].index
+		parent.entries = append(parent.entries, nil)
+		copy(parent.entries[insertPosition+1:], parent.entries[insertPosition:])
+		parent.entries[insertPosition] = separator
+		parent.children = append(parent.children, 0)
+		copy(parent.children[insertPosition+2:], parent.children[insertPosition+1:])
+		parent.children[insertPosition+1] = right.id
+	}
+}
+
+// delete deletes the entry at the index of the last node of the writable path and rebalances the tree.
+// ref.: https://en.wikipedia.org/wiki/B-tree#Deletion
+func (tree *Tree) delete(path []step, index int) error {
+	node := path[len(path)-1].node
+	if !tree.isLeaf(node) {
+		// replace the entry with the largest entry in the left sub-tree, which is deleted from its leaf instead
+		owner := node
+		for !tree.isLeaf(node) {
+			last := path[len(path)-1]
+			child, err := tree.node(last.node.children[last.index])
+			if err != nil {
+				return err
+			}
+			child = tree.writableChild(last.node, last.index, child)
+			path = append(path, step{child, len(child.children) - 1})
+			node = child
+		}
+		owner.entries[index] = node.entries[len(node.entries)-1]
+		index = len(node.entries) - 1
+	}
+	node.entries = append(node.entries[:index], node.entries[index+1:]...)
+	return tree.rebalance(path)
+}
+
+// rebalance restores the minimum number of entries of the nodes of the writable path after a deletion from its last
+// node by borrowing entries from siblings or merging with them, from the last node up to the root.
+func (tree *Tree) rebalance(path []step) error {
+	for i := len(path) - 1; i > 0; i-- {
+		node := path[i].node
+		if len(node.entries) >= tree.minEntries() {
+			return nil
+		}
+		parent, index := path[i-1].node, path[i-1].index
+
+		// try to borrow from left sibling
+		if index > 0 {
+			left, err := tree.node(parent.children[index-1])
+			if err != nil {
+				return err
+			}
+			if len(left.entries) > tree.minEntries() {
+				// rotate right
+				left = tree.writableChild(parent, index-1, left)
+				node.entries = append([]*entry{parent.entries[index-1]}, node.entries...)
+				parent.entries[index-1] = left.entries[len(left.entries)-1]
+				left.entries = left.entries[:len(left.entries)-1]
+				if !tree.isLeaf(left) {
+					node.children = append([]pageID{left.children[len(left.children)-1]}, node.children...)
+					left.children = left.children[:len(left.children)-1]
+				}
+				return nil
+			}
+		}
+
+		// try to borrow from right sibling
+		if index < len(parent.children)-1 {
+			right, err := tree.node(parent.children[index+1])
+			if err != nil {
+				return err
+			}
+			if len(right.entries) > tree.minEntries() {
+				// rotate left
+				right = tree.writableChild(parent, index+1, right)
+				node.entries = append(node.entries, parent.entries[index])
+				parent.entries[index] = right.entries[0]
+				right.entries = append([]*entry(nil), right.entries[1:]...)
+				if !tree.isLeaf(right) {
+					node.children = append(node.children, right.children[0])
+					right.children = append([]pageID(nil), right.children[1:]...)
+				}
+				return nil
+			}
+		}
+
+		// merge the right one of the node and a sibling into the left one, with their separator entry between them
+		if index == len(parent.children)-1 {
+			index--
+		}
+		left, err := tree.node(parent.children[index])
+		if err != nil {
+			return err
+		}
+		right, err := tree.node(parent.children[index+1])
+		if err != nil {
+			return err
+		}
+		left = tree.writableChild(parent, index, left)
+		left.entries = append(append(left.entries, parent.entries[index]), right.entries...)
+		left.children = append(left.children, right.children...)
+		parent.entries = append(parent.entries[:index], parent.entries[index+1:]...)
+		parent.children = append(parent.children[:index+1], parent.children[index+2:]...)
+		tree.release(right)
+
+		// make the merged node the root if the root is empty
+		if i == 1 && len(parent.entries) == 0 {
+			tree.release(parent)
+			tree.root = left.id
+			return nil
+		}
+	}
+	if root := path[0].node; len(root.entries) == 0 {
+		tree.release(root)
+		tree.root = 0
+	}
+	return nil
+}
+
+// done trims the page cache after an operation and returns the first error of the operation and the trimming.
+func (tree *Tree) done(err error) error {
+	if evictErr := tree.evict(); err == nil {
+		err = evictErr
+	}
+	return err
+}
@@ -0,0 +1,536 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diskbtree
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/utils"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tempPath returns the path of a file in a new temporary directory and a function removing the directory
+func tempPath(t testing.TB) (string, func()) {
+	dir, err := ioutil.TempDir("", "diskbtree")
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	return filepath.Join(dir, "tree"), func() { os.RemoveAll(dir) }
+}
+
+// openTree opens the tree with int keys and string values in the file, failing the test on errors
+func openTree(t testing.TB, path string, options *Options) *Tree {
+	tree, err := OpenWithIntComparator(path, utils.StringCodec, options)
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	return tree
+}
+
+// assertValidTree checks the invariants of the tree and its size
+func assertValidTree(t *testing.T, tree *Tree, expectedSize int) {
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := tree.Size(), expectedSize; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+// keys returns the keys of the tree in order
+func keys(t *testing.T, tree *Tree) string {
+	keys := []interface{}{}
+	it := tree.Iterator()
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	return fmt.Sprint(keys)
+}
+
+func TestDiskBTreePutGetRemove(t *testing.T) {
+	path, cleanup := tempPath(t)
+	defer cleanup()
+	tree := openTree(t, path, &Options{PageSize: 1024, Order: 3})
+	defer tree.Close()
+
+	if value, found, err := tree.Get(1); value != nil || found || err != nil {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", value, found, err, nil, false, nil)
+	}
+	for _, key := range []int{4, 2, 6, 1, 3, 5, 7} {
+		if err := tree.Put(key, fmt.Sprint(key)); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	tree.Put(1, "x")
+	assertValidTree(t, tree, 7)
+
+	tests := [][]interface{}{
+		{0, nil, false},
+		{1, "x", true},
+		{2, "2", true},
+		{3, "3", true},
+		{4, "4", true},
+		{5, "5", true},
+		{6, "6", true},
+		{7, "7", true},
+		{8, nil, false},
+	}
+	for _, test := range tests {
+		if value, found, err := tree.Get(test[0]); value != test[1] || found != test[2] || err != nil {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", value, found, err, test[1], test[2], nil)
+		}
+	}
+
+	for _, key := range []int{4, 8, 1, 7} {
+		if err := tree.Remove(key); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Errorf("Got error %v after removing %v", err, key)
+		}
+	}
+	assertValidTree(t, tree, 4)
+	if actualValue, expectedValue := keys(t, tree), "[2 3 5 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for _, key := range []int{5, 2, 6, 3} {
+		tree.Remove(key)
+	}
+	assertValidTree(t, tree, 0)
+	if actualValue, expectedValue := tree.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if value, found, err := tree.Get(2); value != nil || found || err != nil {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", value, found, err, nil, false, nil)
+	}
+}
+
+func TestDiskBTreeRandomOperations(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, order := range []int{3, 4, 5, 8} {
+		path, cleanup := tempPath(t)
+		tree := openTree(t, path, &Options{PageSize: 1024, Order: order, CacheSize: 4})
+		model := map[int]string{}
+		for i := 0; i < 2000; i++ {
+			key := random.Intn(200)
+			var err error
+			switch random.Intn(20) {
+			case 0:
+				err = tree.Sync()
+			case 1, 2, 3, 4, 5, 6:
+				err = tree.Remove(key)
+				delete(model, key)
+			default:
+				err = tree.Put(key, fmt.Sprint(i))
+				model[key] = fmt.Sprint(i)
+			}
+			if err != nil {
+				t.Fatalf("Got error %v in operation %v on tree of order %v", err, i, order)
+			}
+			if i%50 == 0 {
+				if err := tree.Validate(); err != nil {
+					t.Fatalf("Got error %v after operation %v on tree of order %v", err, i, order)
+				}
+			}
+		}
+		assertValidTree(t, tree, len(model))
+		if err := tree.Close(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+
+		tree = openTree(t, path, nil)
+		assertValidTree(t, tree, len(model))
+		for key, value := range model {
+			if actualValue, found, err := tree.Get(key); actualValue != value || !found || err != nil {
+				t.Errorf("Got %v expected %v for %v", actualValue, value, key)
+			}
+		}
+		tree.Close()
+		cleanup()
+	}
+}
+
+func TestDiskBTreeReopen(t *testing.T) {
+	path, cleanup := tempPath(t)
+	defer cleanup()
+	tree := openTree(t, path, &Options{PageSize: 1024, Order: 4})
+	for i := 0; i < 100; i++ {
+		tree.Put(i, fmt.Sprint(i))
+	}
+	if err := tree.Close(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	// the page size and the order of the file are kept
+	tree = openTree(t, path, &Options{PageSize: 4096, Order: 50})
+	if actualValue, expectedValue := tree.pageSize, 1024; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.m, 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	assertValidTree(t, tree, 100)
+	if value, found, err := tree.Get(42); value != "42" || !found || err != nil {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", value, found, err, "42", true, nil)
+	}
+	for i := 0; i < 100; i += 2 {
+		tree.Remove(i)
+	}
+	tree.Close()
+
+	tree = openTree(t, path, nil)
+	defer tree.Close()
+	assertValidTree(t, tree, 50)
+	if value, found, err := tree.Get(42); value != nil || found || err != nil {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", value, found, err, nil, false, nil)
+	}
+}
+
+func TestDiskBTreeCrashRecovery(t *testing.T) {
+	path, cleanup := tempPath(t)
+	defer cleanup()
+	tree := openTree(t, path, &Options{PageSize: 1024, Order: 3, CacheSize: 2})
+	for i := 0; i < 50; i++ {
+		tree.Put(i, "committed")
+	}
+	if err := tree.Sync(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	// modifications are written to the file when they are evicted from the small cache, but not committed
+	for i := 0; i < 50; i++ {
+		tree.Put(i, "lost")
+		tree.Put(i+100, "lost")
+		tree.Remove(i / 2)
+	}
+	assertValidTree(t, tree, 75)
+
+	// crash without committing
+	tree.file.Close()
+
+	tree = openTree(t, path, nil)
+	defer tree.Close()
+	assertValidTree(t, tree, 50)
+	for i := 0; i < 50; i++ {
+		if value, found, err := tree.Get(i); value != "committed" || !found || err != nil {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", value, found, err, "committed", true, nil)
+		}
+	}
+	if value, found, err := tree.Get(100); value != nil || found || err != nil {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", value, found, err, nil, false, nil)
+	}
+
+	// the recovered tree continues from the committed state
+	tree.Put(100, "new")
+	assertValidTree(t, tree, 51)
+}
+
+func TestDiskBTreeTornCommit(t *testing.T) {
+	path, cleanup := tempPath(t)
+	defer cleanup()
+	tree := openTree(t, path, &Options{PageSize: 1024, Order: 3})
+	tree.Put(1, "a")
+	tree.Sync()
+	tree.Put(2, "b")
+	tree.Close()
+
+	// corrupt the meta slot of the last commit, as if writing it was interrupted
+	file, err := os.OpenFile(path, os.O_RDWR, 0666)
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	file.WriteAt([]byte{0xff}, int64(tree.txid%2)*metaSlotSize+20)
+	file.Close()
+
+	tree = openTree(t, path, nil)
+	assertValidTree(t, tree, 1)
+	if actualValue, expectedValue := keys(t, tree), "[1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Put(3, "c")
+	tree.Close()
+
+	tree = openTree(t, path, nil)
+	defer tree.Close()
+	if actualValue, expectedValue := keys(t, tree), "[1 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestDiskBTreePageReuse(t *testing.T) {
+	path, cleanup := tempPath(t)
+	defer cleanup()
+	tree := openTree(t, path, &Options{PageSize: 1024, Order: 4, CacheSize: 8})
+	defer tree.Close()
+	for i := 0; i < 100; i++ {
+		tree.Put(i, "")
+	}
+	tree.Sync()
+	pages := tree.pages
+	for round := 0; round < 20; round++ {
+		for i := 0; i < 100; i += 7 {
+			tree.Put(i, fmt.Sprint(round))
+		}
+		if err := tree.Sync(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	assertValidTree(t, tree, 100)
+	// updated pages are copied, but the copies replace pages of previous commits
+	if actualValue, expectedValue := tree.pages, 2*pages; actualValue > expectedValue {
+		t.Errorf("Got %v pages expected at most %v", actualValue, expectedValue)
+	}
+
+	for i := 0; i < 100; i++ {
+		tree.Remove(i)
+	}
+	tree.Sync()
+	assertValidTree(t, tree, 0)
+	if actualValue, expectedValue := len(tree.free), int(tree.pages)-1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestDiskBTreeOptions(t *testing.T) {
+	path, cleanup := tempPath(t)
+	defer cleanup()
+
+	tree := openTree(t, path, nil)
+	if actualValue, expectedValue := tree.MaxEntrySize(), (4096-5-32*8)/31-10; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := tree.Put(1, strings.Repeat("x", tree.MaxEntrySize())); err != ErrEntryTooLarge {
+		t.Errorf("Got %v expected %v", err, ErrEntryTooLarge)
+	}
+	if err := tree.Put(1, strings.Repeat("x", tree.MaxEntrySize()-1)); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	assertValidTree(t, tree, 1)
+	tree.Close()
+
+	for _, options := range []*Options{{Order: 2}, {PageSize: 512}, {PageSize: 1024, Order: 128}} {
+		os.Remove(path)
+		if _, err := OpenWithIntComparator(path, utils.StringCodec, options); err == nil {
+			t.Errorf("Got no error for options %v", *options)
+		}
+	}
+
+	ioutil.WriteFile(path, []byte("not a tree"), 0666)
+	if _, err := OpenWithIntComparator(path, utils.StringCodec, nil); err != ErrCorrupted {
+		t.Errorf("Got %v expected %v", err, ErrCorrupted)
+	}
+}
+
+func TestDiskBTreeIterator(t *testing.T) {
+	path, cleanup := tempPath(t)
+	defer cleanup()
+	tree := openTree(t, path, &Options{PageSize: 1024, Order: 3, CacheSize: 2})
+	defer tree.Close()
+
+	it := tree.Iterator()
+	if actualValue, expectedValue := it.Next(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Prev(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for i := 20; i > 0; i-- {
+		tree.Put(i, fmt.Sprint(i))
+	}
+	it = tree.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := it.Value(), fmt.Sprint(count); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := count, 20; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count--
+	}
+	if actualValue, expectedValue := count, 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 20; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.First(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Key(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+}
+
+func TestDiskBTreeIteratorConcurrentModification(t *testing.T) {
+	expectPanic := func(f func() bool) {
+		defer func() {
+			if r := recover(); r != containers.ErrConcurrentModification {
+				t.Errorf("Got %v expected %v", r, containers.ErrConcurrentModification)
+			}
+		}()
+		f()
+	}
+
+	path, cleanup := tempPath(t)
+	defer cleanup()
+	tree := openTree(t, path, nil)
+	defer tree.Close()
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	it := tree.Iterator()
+	it.Next()
+	tree.Put(1, "x")
+	expectPanic(it.Next)
+	expectPanic(it.Prev)
+
+	it.Begin()
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Value(), "x"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Remove(2)
+	expectPanic(it.Next)
+}
+
+func TestDiskBTreeRange(t *testing.T) {
+	path, cleanup := tempPath(t)
+	defer cleanup()
+	tree := openTree(t, path, &Options{PageSize: 1024, Order: 4, CacheSize: 3})
+	defer tree.Close()
+	for i := 0; i < 50; i += 2 {
+		tree.Put(i, fmt.Sprint(i*i))
+	}
+
+	collect := func(from, to int, limit int) string {
+		keys := []interface{}{}
+		err := tree.Range(from, to, func(key interface{}, value interface{}) bool {
+			if value != fmt.Sprint(key.(int)*key.(int)) {
+				t.Errorf("Got %v expected %v", value, key.(int)*key.(int))
+			}
+			keys = append(keys, key)
+			return len(keys) < limit
+		})
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		return fmt.Sprint(keys)
+	}
+
+	tests := []struct {
+		from, to, limit int
+		expected        string
+	}{
+		{10, 20, 100, "[10 12 14 16 18]"},
+		{9, 21, 100, "[10 12 14 16 18 20]"},
+		{-5, 3, 100, "[0 2]"},
+		{45, 100, 100, "[46 48]"},
+		{49, 100, 100, "[]"},
+		{20, 20, 100, "[]"},
+		{30, 10, 100, "[]"},
+		{0, 100, 3, "[0 2 4]"},
+	}
+	for _, test := range tests {
+		if actualValue := collect(test.from, test.to, test.limit); actualValue != test.expected {
+			t.Errorf("Got %v expected %v for [%v, %v)", actualValue, test.expected, test.from, test.to)
+		}
+	}
+	// every key starts a range, whether it is stored in a leaf or in an internal node
+	for i := 0; i < 50; i++ {
+		expected := []interface{}{}
+		for key := i + i%2; key < 50 && len(expected) < 2; key += 2 {
+			expected = append(expected, key)
+		}
+		if actualValue, expectedValue := collect(i, 100, 2), fmt.Sprint(expected); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v from %v", actualValue, expectedValue, i)
+		}
+	}
+}
+
+func TestDiskBTreeConformance(t *testing.T) {
+	for _, keys := range [][]interface{}{{}, {1}, {1, 2, 3}, {1, 2, 3, 4, 5, 6, 7, 8, 9, 10}} {
+		path, cleanup := tempPath(t)
+		tree := openTree(t, path, &Options{PageSize: 1024, Order: 3})
+		values := []interface{}{}
+		for _, key := range keys {
+			tree.Put(key, fmt.Sprint(-key.(int)))
+			values = append(values, fmt.Sprint(-key.(int)))
+		}
+		it := tree.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+		tree.Close()
+		cleanup()
+	}
+}
+
+func benchmarkGet(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Get(n)
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Put(n, "")
+		}
+		tree.Sync()
+	}
+}
+
+func BenchmarkDiskBTreeGet10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	path, cleanup := tempPath(b)
+	defer cleanup()
+	tree := openTree(b, path, nil)
+	defer tree.Close()
+	for n := 0; n < size; n++ {
+		tree.Put(n, "")
+	}
+	tree.Sync()
+	b.StartTimer()
+	benchmarkGet(b, tree, size)
+}
+
+func BenchmarkDiskBTreePut10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	path, cleanup := tempPath(b)
+	defer cleanup()
+	tree := openTree(b, path, nil)
+	defer tree.Close()
+	b.StartTimer()
+	benchmarkPut(b, tree, size)
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diskbtree
+
+import "github.com/emirpasic/gods/containers"
+
+func assertIteratorImplementation() {
+	var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
+}
+
+// Iterator holding the iterator's state
+type Iterator struct {
+	tree          *Tree
+	path          []step // nodes from the root to the current entry, with the indexes of the followed children and of the current entry in the last node
+	position      position
+	err           error // first error reading the tree
+	modifications int   // tree's modifications when the iterator moved onto the current entry
+}
+
+type position byte
+
+const (
+	begin, between, end position = 0, 1, 2
+)
+
+// Iterator returns a stateful iterator whose elements are key/value pairs.
+// Next() and Prev() load nodes of the tree as needed and return false if loading fails, which is reported by Err().
+// The iterator is fail-fast: Next() and Prev() panic with containers.ErrConcurrentModification
+// if the tree has been modified by Put or Remove since the iterator moved onto its current element.
+func (tree *Tree) Iterator() Iterator {
+	return Iterator{tree: tree, position: begin}
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator) Next() bool {
+	iterator.checkModifications()
+	found := false
+	switch iterator.position {
+	case begin:
+		found = iterator.tree.root != 0 && iterator.descend(iterator.tree.root, false)
+	case between:
+		found = iterator.next()
+	}
+	return iterator.moved(found, end)
+}
+
+// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
+// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator) Prev() bool {
+	iterator.checkModifications()
+	found := false
+	switch iterator.position {
+	case end:
+		found = iterator.tree.root != 0 && iterator.descend(iterator.tree.root, true)
+	case between:
+		found = iterator.prev()
+	}
+	return iterator.moved(found, begin)
+}
+
+// Value returns the current element's value, decoded by the value codec.
+// Returns nil if the value cannot be decoded, which is reported by Err().
+// Does not modify the state of the iterator.
+func (iterator *Iterator) Value() interface{} {
+	last := iterator.path[len(iterator.path)-1]
+	value, err := iterator.tree.valueCodec.Decode(last.node.entries[last.index].value)
+	if err != nil {
+		if iterator.err == nil {
+			iterator.err = err
+		}
+		return nil
+	}
+	return value
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *Iterator) Key() interface{} {
+	last := iterator.path[len(iterator.path)-1]
+	return last.node.entries[last.index].Key
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator) Begin() {
+	iterator.path = nil
+	iterator.position = begin
+}
+
+// End moves the iterator past the last element (one-past-the-end).
+// Call Prev() to fetch the last element if any.
+func (iterator *Iterator) End() {
+	iterator.path = nil
+	iterator.position = end
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator
+func (iterator *Iterator) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// Last moves the iterator to the last element and returns true if there was a last element in the container.
+// If Last() returns true, then last element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator) Last() bool {
+	iterator.End()
+	return iterator.Prev()
+}
+
+// Err returns the first error that occurred while loading nodes or decoding values, if any.
+func (iterator *Iterator) Err() error {
+	return iterator.err
+}
+
+// next moves from the current entry to the following one.
+func (iterator *Iterator) next() bool {
+	last := &iterator.path[len(iterator.path)-1]
+	if !iterator.tree.isLeaf(last.node) {
+		// the next entry is the left-most entry of the subtree right of the current entry
+		last.index++
+		return iterator.descend(last.node.children[last.index], false)
+	}
+	if last.index+1 < len(last.node.entries) {
+		last.index++
+		return true
+	}
+	// the next entry is the separator entry right of the first subtree on the path that has one
+	for iterator.path = iterator.path[:len(iterator.path)-1]; len(iterator.path) > 0; iterator.path = iterator.path[:len(iterator.path)-1] {
+		if top := iterator.path[len(iterator.path)-1]; top.index < len(top.node.entries) {
+			return true
+		}
+	}
+	return false
+}
+
+// prev moves from the current entry to the preceding one.
+func (iterator *Iterator) prev() bool {
+	last := &iterator.path[len(iterator.path)-1]
+	if !iterator.tree.isLeaf(last.node) {
+		// the previous entry is the right-most entry of the subtree left of the current entry
+		return iterator.descend(last.node.children[last.index], true)
+	}
+	if last.index > 0 {
+		last.index--
+		return true
+	}
+	// the previous entry is the separator entry left of the first subtree on the path that has one
+	for iterator.path = iterator.path[:len(iterator.path)-1]; len(iterator.path) > 0; iterator.path = iterator.path[:len(iterator.path)-1] {
+		if top := &iterator.path[len(iterator.path)-1]; top.index > 0 {
+			top.index--
+			return true
+		}
+	}
+	return false
+}
+
+// descend appends the path from the node in the page to its left-most (or right-most) entry.
+func (iterator *Iterator) descend(id pageID, right bool) bool {
+	for {
+		node, err := iterator.tree.node(id)
+		if err != nil {
+			iterator.err = err
+			return false
+		}
+		index := 0
+		if right && iterator.tree.isLeaf(node) {
+			index = len(node.entries) - 1
+		} else if right {
+			index = len(node.children) - 1
+		}
+		iterator.path = append(iterator.path, step{node, index})
+		if iterator.tree.isLeaf(node) {
+			return true
+		}
+		id = node.children[index]
+	}
+}
+
+// seek moves the iterator to the entry with the smallest key greater than or equal to the key and returns true if
+// there is one.
+func (iterator *Iterator) seek(key interface{}) bool {
+	iterator.path = nil
+	found := false
+	if iterator.tree.root != 0 {
+		path, index, exact, err := iterator.tree.searchRecursively(key)
+		if err != nil {
+			iterator.err = err
+		} else {
+			iterator.path, found = path, exact || index < len(path[len(path)-1].node.entries)
+			if !found {
+				// all keys of the leaf are smaller, continue after its last entry
+				iterator.path[len(path)-1].index = index - 1
+				found = iterator.next()
+			}
+		}
+	}
+	return iterator.moved(found, end)
+}
+
+// moved trims the page cache and records that the iterator moved onto the current entry if it was found,
+// otherwise it moves the iterator to the given boundary.
+func (iterator *Iterator) moved(found bool, boundary position) bool {
+	if err := iterator.tree.evict(); err != nil && iterator.err == nil {
+		iterator.err = err
+	}
+	if !found || iterator.err != nil {
+		iterator.path = nil
+		iterator.position = boundary
+		return false
+	}
+	iterator.position = between
+	iterator.modifications = iterator.tree.modifications
+	return true
+}
+
+// checkModifications panics if the iterator is positioned on an entry and the tree has been modified since it moved there.
+func (iterator *Iterator) checkModifications() {
+	if iterator.position == between && iterator.modifications != iterator.tree.modifications {
+		panic(containers.ErrConcurrentModification)
+	}
+}
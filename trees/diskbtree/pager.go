@@ -0,0 +1,372 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diskbtree
+
+import (
+	"container/list"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// pageID is the number of a page in the file, page 0 is the meta page
+type pageID uint64
+
+const (
+	magic           = 0x67646274 // "gdbt"
+	version         = 1
+	metaSize        = 52  // magic, version, page size, order, txid, root, pages, size and checksum
+	metaSlotSize    = 512 // the meta page holds two alternating meta slots in separate disk sectors
+	minPageSize     = 2 * metaSlotSize
+	nodeHeaderSize  = 5 // leaf flag and number of entries
+	pageIDSize      = 8
+	lengthSize      = binary.MaxVarintLen32 // maximum size of the length of an encoded key or value
+	defaultPageSize = 4096
+	defaultOrder    = 32
+	defaultCache    = 128
+)
+
+// meta is the content of a meta slot, which records a commit
+type meta struct {
+	pageSize uint32
+	order    uint32
+	txid     uint64
+	root     pageID
+	pages    pageID
+	size     uint64
+}
+
+// create initializes the empty file with the meta page.
+func (tree *Tree) create(options *Options) error {
+	tree.pageSize, tree.m = options.PageSize, options.Order
+	if tree.pageSize == 0 {
+		tree.pageSize = defaultPageSize
+	}
+	if tree.m == 0 {
+		tree.m = defaultOrder
+	}
+	if tree.m < 3 {
+		return fmt.Errorf("diskbtree: invalid order %d, should be at least 3", tree.m)
+	}
+	if tree.pageSize < minPageSize || tree.MaxEntrySize() <= 0 {
+		return fmt.Errorf("diskbtree: page size %d too small for order %d", tree.pageSize, tree.m)
+	}
+	tree.pages = 1
+	if err := tree.writeMeta(); err != nil {
+		return err
+	}
+	return tree.file.Sync()
+}
+
+// load reads the last commit from the meta page and collects the pages the committed tree does not use.
+func (tree *Tree) load() error {
+	var last *meta
+	for slot := 0; slot < 2; slot++ {
+		if m, err := tree.readMeta(slot); err == nil && (last == nil || m.txid > last.txid) {
+			last = m
+		}
+	}
+	if last == nil {
+		return ErrCorrupted
+	}
+	tree.pageSize, tree.m = int(last.pageSize), int(last.order)
+	tree.txid, tree.root, tree.pages, tree.size = last.txid, last.root, last.pages, int(last.size)
+	if tree.m < 3 || tree.pageSize < minPageSize || tree.MaxEntrySize() <= 0 {
+		return ErrCorrupted
+	}
+	used := make(map[pageID]bool)
+	if tree.root != 0 {
+		height := 0
+		for id := tree.root; ; height++ {
+			node, err := tree.readNode(id)
+			if err != nil {
+				return err
+			}
+			if tree.isLeaf(node) {
+				break
+			}
+			id = node.children[0]
+		}
+		if err := tree.collect(tree.root, height, used); err != nil {
+			return err
+		}
+	}
+	for id := pageID(1); id < tree.pages; id++ {
+		if !used[id] {
+			tree.free = append(tree.free, id)
+		}
+	}
+	return nil
+}
+
+// collect marks the pages of the subtree of the given height rooted at the page as used.
+// Leaves are marked through their parents without reading them.
+func (tree *Tree) collect(id pageID, height int, used map[pageID]bool) error {
+	if id < 1 || id >= tree.pages || used[id] {
+		return ErrCorrupted
+	}
+	used[id] = true
+	if height == 0 {
+		return nil
+	}
+	node, err := tree.readNode(id)
+	if err != nil {
+		return err
+	}
+	if len(node.children) == 0 {
+		return ErrCorrupted
+	}
+	for _, child := range node.children {
+		if err := tree.collect(child, height-1, used); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readMeta reads the meta slot and verifies its checksum.
+func (tree *Tree) readMeta(slot int) (*meta, error) {
+	data := make([]byte, metaSize)
+	if _, err := tree.file.ReadAt(data, int64(slot)*metaSlotSize); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(data[0:]) != magic || binary.LittleEndian.Uint32(data[4:]) != version ||
+		binary.LittleEndian.Uint32(data[48:]) != crc32.ChecksumIEEE(data[:48]) {
+		return nil, ErrCorrupted
+	}
+	return &meta{
+		pageSize: binary.LittleEndian.Uint32(data[8:]),
+		order:    binary.LittleEndian.Uint32(data[12:]),
+		txid:     binary.LittleEndian.Uint64(data[16:]),
+		root:     pageID(binary.LittleEndian.Uint64(data[24:])),
+		pages:    pageID(binary.LittleEndian.Uint64(data[32:])),
+		size:     binary.LittleEndian.Uint64(data[40:]),
+	}, nil
+}
+
+// writeMeta writes the state of the tree to the meta slot of its commit, alternating between the two slots.
+func (tree *Tree) writeMeta() error {
+	data := make([]byte, metaSize)
+	binary.LittleEndian.PutUint32(data[0:], magic)
+	binary.LittleEndian.PutUint32(data[4:], version)
+	binary.LittleEndian.PutUint32(data[8:], uint32(tree.pageSize))
+	binary.LittleEndian.PutUint32(data[12:], uint32(tree.m))
+	binary.LittleEndian.PutUint64(data[16:], tree.txid)
+	binary.LittleEndian.PutUint64(data[24:], uint64(tree.root))
+	binary.LittleEndian.PutUint64(data[32:], uint64(tree.pages))
+	binary.LittleEndian.PutUint64(data[40:], uint64(tree.size))
+	binary.LittleEndian.PutUint32(data[48:], crc32.ChecksumIEEE(data[:48]))
+	_, err := tree.file.WriteAt(data, int64(tree.txid%2)*metaSlotSize)
+	return err
+}
+
+// commit records the current root in the next meta slot and flushes it. Modified nodes must have been flushed.
+func (tree *Tree) commit() error {
+	tree.txid++
+	if err := tree.writeMeta(); err != nil {
+		tree.txid--
+		return err
+	}
+	if err := tree.file.Sync(); err != nil {
+		tree.txid--
+		return err
+	}
+	return nil
+}
+
+// flush writes all modified nodes to their pages and flushes the file to stable storage.
+func (tree *Tree) flush() error {
+	for element := tree.cache.order.Front(); element != nil; element = element.Next() {
+		if err := tree.writeNode(element.Value.(*node)); err != nil {
+			return err
+		}
+	}
+	return tree.file.Sync()
+}
+
+// readNode reads and decodes the node stored in the page, bypassing the cache.
+func (tree *Tree) readNode(id pageID) (*node, error) {
+	data := make([]byte, tree.pageSize)
+	if _, err := tree.file.ReadAt(data, int64(id)*int64(tree.pageSize)); err != nil {
+		return nil, err
+	}
+	count := int(binary.LittleEndian.Uint32(data[1:]))
+	if count > tree.maxEntries() || data[0] > 1 {
+		return nil, ErrCorrupted
+	}
+	node := &node{id: id, entries: make([]*entry, count)}
+	offset := nodeHeaderSize
+	if data[0] == 0 {
+		node.children = make([]pageID, count+1)
+		for i := range node.children {
+			node.children[i] = pageID(binary.LittleEndian.Uint64(data[offset:]))
+			offset += pageIDSize
+		}
+	}
+	field := func() ([]byte, error) {
+		length, n := binary.Uvarint(data[offset:])
+		if n <= 0 || uint64(len(data)-offset-n) < length {
+			return nil, ErrCorrupted
+		}
+		offset += n + int(length)
+		return data[offset-int(length) : offset], nil
+	}
+	for i := range node.entries {
+		key, err := field()
+		if err != nil {
+			return nil, err
+		}
+		value, err := field()
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := tree.keyCodec.Decode(key)
+		if err != nil {
+			return nil, err
+		}
+		node.entries[i] = &entry{Key: decoded, key: key, value: value}
+	}
+	return node, nil
+}
+
+// writeNode encodes the modified node into its page.
+func (tree *Tree) writeNode(node *node) error {
+	if !node.dirty {
+		return nil
+	}
+	data := make([]byte, tree.pageSize)
+	if tree.isLeaf(node) {
+		data[0] = 1
+	}
+	binary.LittleEndian.PutUint32(data[1:], uint32(len(node.entries)))
+	offset := nodeHeaderSize
+	for _, child := range node.children {
+		binary.LittleEndian.PutUint64(data[offset:], uint64(child))
+		offset += pageIDSize
+	}
+	for _, entry := range node.entries {
+		offset += binary.PutUvarint(data[offset:], uint64(len(entry.key)))
+		offset += copy(data[offset:], entry.key)
+		offset += binary.PutUvarint(data[offset:], uint64(len(entry.value)))
+		offset += copy(data[offset:], entry.value)
+	}
+	if _, err := tree.file.WriteAt(data, int64(node.id)*int64(tree.pageSize)); err != nil {
+		return err
+	}
+	node.dirty = false
+	return nil
+}
+
+// node returns the node stored in the page, from the cache if possible.
+func (tree *Tree) node(id pageID) (*node, error) {
+	if node := tree.cache.get(id); node != nil {
+		return node, nil
+	}
+	node, err := tree.readNode(id)
+	if err != nil {
+		return nil, err
+	}
+	tree.cache.put(node)
+	return node, nil
+}
+
+// newNode returns an empty node in a newly allocated page.
+func (tree *Tree) newNode() *node {
+	node := &node{id: tree.allocate(), dirty: true}
+	tree.cache.put(node)
+	return node
+}
+
+// writable returns a node that may be modified in place of the node, which is the node itself if its page was
+// allocated since the last commit. Otherwise the node is copied to a new page and its page is released.
+func (tree *Tree) writable(n *node) *node {
+	if tree.fresh[n.id] {
+		n.dirty = true
+		return n
+	}
+	clone := tree.newNode()
+	clone.entries = append([]*entry(nil), n.entries...)
+	if !tree.isLeaf(n) {
+		clone.children = append([]pageID(nil), n.children...)
+	}
+	tree.release(n)
+	return clone
+}
+
+// allocate returns a page for a new node, reusing a free page if there is one.
+func (tree *Tree) allocate() pageID {
+	var id pageID
+	if len(tree.free) > 0 {
+		id = tree.free[len(tree.free)-1]
+		tree.free = tree.free[:len(tree.free)-1]
+	} else {
+		id = tree.pages
+		tree.pages++
+	}
+	tree.fresh[id] = true
+	return id
+}
+
+// release frees the page of the node that is no longer part of the tree.
+// Pages of the committed tree can only be reused after the next commit.
+func (tree *Tree) release(node *node) {
+	tree.cache.remove(node.id)
+	if tree.fresh[node.id] {
+		delete(tree.fresh, node.id)
+		tree.free = append(tree.free, node.id)
+	} else {
+		tree.pending = append(tree.pending, node.id)
+	}
+}
+
+// evict removes the least recently used nodes from the cache until it is within its capacity,
+// writing modified nodes to their pages, which are never pages of the committed tree.
+func (tree *Tree) evict() error {
+	for tree.cache.order.Len() > tree.cache.capacity {
+		node := tree.cache.order.Back().Value.(*node)
+		if err := tree.writeNode(node); err != nil {
+			return err
+		}
+		tree.cache.remove(node.id)
+	}
+	return nil
+}
+
+// cache holds recently used nodes by their pages
+type cache struct {
+	capacity int
+	elements map[pageID]*list.Element
+	order    *list.List // nodes from the most to the least recently used
+}
+
+func newCache(capacity int) *cache {
+	if capacity <= 0 {
+		capacity = defaultCache
+	}
+	return &cache{capacity: capacity, elements: make(map[pageID]*list.Element), order: list.New()}
+}
+
+// get returns the cached node of the page and marks it as the most recently used, or nil if it is not cached.
+func (cache *cache) get(id pageID) *node {
+	element, found := cache.elements[id]
+	if !found {
+		return nil
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*node)
+}
+
+// put adds the node as the most recently used.
+func (cache *cache) put(node *node) {
+	cache.elements[node.id] = cache.order.PushFront(node)
+}
+
+// remove drops the node of the page from the cache, if it is cached.
+func (cache *cache) remove(id pageID) {
+	if element, found := cache.elements[id]; found {
+		cache.order.Remove(element)
+		delete(cache.elements, id)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diskbtree
+
+import (
+	"fmt"
+)
+
+// Validate checks the structural invariants of the tree and returns an error describing the first violation found, if any.
+// It checks that keys are in strictly ascending order, that nodes hold between ⌈m/2⌉-1 (except the root) and m-1
+// entries, that internal nodes have one child more than entries, that all leaves appear on the same level, that the
+// size of the tree matches the number of its entries and that every page of the file is either used by exactly one
+// node or free. It reads the whole tree and returns errors of reading it as well.
+func (tree *Tree) Validate() error {
+	v := &validator{tree: tree, leafDepth: -1, pages: make(map[pageID]bool)}
+	if tree.root != 0 {
+		if err := v.validate(tree.root, 0); err != nil {
+			return tree.done(err)
+		}
+	}
+	if v.count != tree.size {
+		return tree.done(fmt.Errorf("diskbtree: size is %d, but tree has %d entries", tree.size, v.count))
+	}
+	for _, free := range [][]pageID{tree.free, tree.pending} {
+		for _, id := range free {
+			if err := v.page(id); err != nil {
+				return tree.done(err)
+			}
+		}
+	}
+	if len(v.pages) != int(tree.pages)-1 {
+		return tree.done(fmt.Errorf("diskbtree: %d of %d pages are neither used nor free", int(tree.pages)-1-len(v.pages), tree.pages-1))
+	}
+	return tree.done(nil)
+}
+
+// validator holds the state of the in-order traversal of the tree in Validate
+type validator struct {
+	tree      *Tree
+	previous  *entry          // last visited entry
+	count     int             // number of visited entries
+	leafDepth int             // depth of the leaves, -1 until the first leaf is visited
+	pages     map[pageID]bool // visited pages
+}
+
+// page checks that the page is within the file and has not been visited before.
+func (v *validator) page(id pageID) error {
+	if id < 1 || id >= v.tree.pages {
+		return fmt.Errorf("diskbtree: page %d is out of range", id)
+	}
+	if v.pages[id] {
+		return fmt.Errorf("diskbtree: page %d is used twice", id)
+	}
+	v.pages[id] = true
+	return nil
+}
+
+// validate checks the subtree rooted at the node in the page at the given depth.
+func (v *validator) validate(id pageID, depth int) error {
+	if err := v.page(id); err != nil {
+		return err
+	}
+	node, err := v.tree.node(id)
+	if err != nil {
+		return err
+	}
+	if len(node.entries) == 0 {
+		return fmt.Errorf("diskbtree: node in page %d has no entries", id)
+	}
+	if len(node.entries) > v.tree.maxEntries() {
+		return fmt.Errorf("diskbtree: node in page %d has %d entries, at most %d allowed", id, len(node.entries), v.tree.maxEntries())
+	}
+	if depth > 0 && len(node.entries) < v.tree.minEntries() {
+		return fmt.Errorf("diskbtree: node in page %d has %d entries, at least %d required", id, len(node.entries), v.tree.minEntries())
+	}
+	if v.tree.isLeaf(node) {
+		if v.leafDepth < 0 {
+			v.leafDepth = depth
+		} else if v.leafDepth != depth {
+			return fmt.Errorf("diskbtree: leaf in page %d is on level %d, other leaves are on level %d", id, depth, v.leafDepth)
+		}
+	} else if len(node.children) != len(node.entries)+1 {
+		return fmt.Errorf("diskbtree: node in page %d has %d entries and %d children", id, len(node.entries), len(node.children))
+	}
+	for e, entry := range node.entries {
+		if !v.tree.isLeaf(node) {
+			if err := v.validate(node.children[e], depth+1); err != nil {
+				return err
+			}
+		}
+		if v.previous != nil && v.tree.Comparator(v.previous.Key, entry.Key) >= 0 {
+			return fmt.Errorf("diskbtree: keys %v and %v are out of order", v.previous.Key, entry.Key)
+		}
+		v.previous = entry
+		v.count++
+	}
+	if !v.tree.isLeaf(node) {
+		return v.validate(node.children[len(node.entries)], depth+1)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trees
+
+// DOTOptions configures the Graphviz DOT output of trees (see ToDOT of red-black, AVL and B-trees).
+// A nil *DOTOptions is equivalent to the zero value, i.e. a graph named "tree" with keys only.
+type DOTOptions struct {
+	Name   string                           // Name of the graph, "tree" if empty
+	Values bool                             // Include values of nodes in their labels
+	Format func(element interface{}) string // Formats keys and values in labels, fmt.Sprint if nil
+}
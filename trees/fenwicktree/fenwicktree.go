@@ -0,0 +1,202 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fenwicktree implements a Fenwick tree (binary indexed tree) of integers.
+//
+// A Fenwick tree holds a sequence of integers and supports updating an element and computing the sum of a prefix
+// or a range of the sequence in logarithmic time. It is stored in a single slice of partial sums, where the element
+// at (one-based) position i holds the sum of the lowbit(i) elements ending at i, lowbit(i) being the lowest set bit of i.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Fenwick_tree
+package fenwicktree
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"strings"
+)
+
+func assertTreeImplementation() {
+	var _ trees.Tree = (*Tree)(nil)
+}
+
+// Tree holds the partial sums of the elements
+type Tree struct {
+	sums []int // sums[i] is the sum of elements with indexes in (i-lowbit(i+1), i]
+}
+
+// New instantiates a tree holding the values in linear time.
+// Use New(make([]int, size)...) for a tree of size zeros.
+func New(values ...int) *Tree {
+	sums := make([]int, len(values))
+	copy(sums, values)
+	return build(sums)
+}
+
+// build instantiates a tree holding the values in linear time, turning the slice of values into the sums of the tree.
+func build(values []int) *Tree {
+	for i := 1; i <= len(values); i++ {
+		if parent := i + lowbit(i); parent <= len(values) {
+			values[parent-1] += values[i-1]
+		}
+	}
+	return &Tree{sums: values}
+}
+
+// Update adds delta to the element at the index (delta may be negative).
+// Does not do anything if the index is out of bounds.
+func (tree *Tree) Update(index int, delta int) {
+	if !tree.withinRange(index) {
+		return
+	}
+	for i := index + 1; i <= len(tree.sums); i += lowbit(i) {
+		tree.sums[i-1] += delta
+	}
+}
+
+// Set replaces the element at the index with the value.
+// Does not do anything if the index is out of bounds.
+func (tree *Tree) Set(index int, value int) {
+	if current, ok := tree.Get(index); ok {
+		tree.Update(index, value-current)
+	}
+}
+
+// Get returns the element at the index.
+// Second return parameter is true if index is within bounds of the tree, otherwise false.
+func (tree *Tree) Get(index int) (int, bool) {
+	if !tree.withinRange(index) {
+		return 0, false
+	}
+	// subtract the partial sums covering the elements preceding the index from the one ending at it
+	value := tree.sums[index]
+	for i, start := index, index+1-lowbit(index+1); i > start; i -= lowbit(i) {
+		value -= tree.sums[i-1]
+	}
+	return value, true
+}
+
+// Append adds the values at the end of the tree.
+func (tree *Tree) Append(values ...int) {
+	for _, value := range values {
+		n := len(tree.sums) + 1
+		for i := n - 1; i > n-lowbit(n); i -= lowbit(i) {
+			value += tree.sums[i-1]
+		}
+		tree.sums = append(tree.sums, value)
+	}
+}
+
+// PrefixSum returns the sum of the first n elements, i.e. of elements with indexes in [0, n).
+// n is clamped to the bounds of the tree.
+func (tree *Tree) PrefixSum(n int) int {
+	if n > len(tree.sums) {
+		n = len(tree.sums)
+	}
+	sum := 0
+	for i := n; i > 0; i -= lowbit(i) {
+		sum += tree.sums[i-1]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of elements with indexes in [from, to).
+// Indexes are clamped to the bounds of the tree, the sum is zero if from >= to.
+func (tree *Tree) RangeSum(from int, to int) int {
+	if from >= to {
+		return 0
+	}
+	return tree.PrefixSum(to) - tree.PrefixSum(from)
+}
+
+// Sum returns the sum of all elements.
+func (tree *Tree) Sum() int {
+	return tree.PrefixSum(len(tree.sums))
+}
+
+// LowerBound returns the smallest index such that the sum of elements up to and including it is at least sum,
+// or Size() if there is no such index (like sort.Search). All elements must be non-negative.
+//
+// E.g. if the elements are the weights of choices, LowerBound(r+1) for a random r in [0, Sum()) picks a choice with probability proportional to its weight.
+func (tree *Tree) LowerBound(sum int) int {
+	step := 1
+	for step*2 <= len(tree.sums) {
+		step *= 2
+	}
+	// descend from the largest partial sum, skipping partial sums smaller than the remaining sum
+	n := 0
+	for ; step > 0; step /= 2 {
+		if n+step <= len(tree.sums) && tree.sums[n+step-1] < sum {
+			n += step
+			sum -= tree.sums[n-1]
+		}
+	}
+	return n
+}
+
+// Empty returns true if tree does not contain any elements.
+func (tree *Tree) Empty() bool {
+	return len(tree.sums) == 0
+}
+
+// Size returns number of elements in the tree.
+func (tree *Tree) Size() int {
+	return len(tree.sums)
+}
+
+// Clear removes all elements from the tree.
+func (tree *Tree) Clear() {
+	tree.sums = []int{}
+}
+
+// Values returns all elements in order of their indexes, computed in linear time.
+func (tree *Tree) Values() []interface{} {
+	values := make([]interface{}, len(tree.sums))
+	for i, value := range tree.ints() {
+		values[i] = value
+	}
+	return values
+}
+
+// Clone returns a copy of the tree.
+func (tree *Tree) Clone() *Tree {
+	sums := make([]int, len(tree.sums))
+	copy(sums, tree.sums)
+	return &Tree{sums: sums}
+}
+
+// String returns a string representation of container
+func (tree *Tree) String() string {
+	str := "FenwickTree\n"
+	values := []string{}
+	for _, value := range tree.ints() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// ints returns the elements in order of their indexes, reverting the construction of New.
+func (tree *Tree) ints() []int {
+	values := make([]int, len(tree.sums))
+	copy(values, tree.sums)
+	for i := len(values); i > 0; i-- {
+		if parent := i + lowbit(i); parent <= len(values) {
+			values[parent-1] -= values[i-1]
+		}
+	}
+	return values
+}
+
+// Check that the index is within bounds of the tree
+func (tree *Tree) withinRange(index int) bool {
+	return index >= 0 && index < len(tree.sums)
+}
+
+// lowbit returns the lowest set bit of i
+func lowbit(i int) int {
+	return i & -i
+}
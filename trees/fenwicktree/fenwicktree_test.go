@@ -0,0 +1,346 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fenwicktree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"math/rand"
+	"testing"
+)
+
+func TestFenwickTreeNew(t *testing.T) {
+	tree := New(3, 1, 4, 1, 5, 9, 2, 6)
+	if actualValue, expectedValue := tree.Size(), 8; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.sums), "[3 4 4 9 5 14 2 31]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[3 1 4 1 5 9 2 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.String(), "FenwickTree\n3, 1, 4, 1, 5, 9, 2, 6"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree = New(make([]int, 5)...)
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[0 0 0 0 0]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree = New()
+	if actualValue, expectedValue := tree.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Sum(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestFenwickTreeSums(t *testing.T) {
+	tree := New(3, 1, 4, 1, 5, 9, 2, 6)
+
+	tests := [][]int{
+		{0, 0},
+		{1, 3},
+		{2, 4},
+		{5, 14},
+		{8, 31},
+		{9, 31},   // clamped
+		{-1, 0},   // clamped
+		{100, 31}, // clamped
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := tree.PrefixSum(test[0]), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for PrefixSum(%v)", actualValue, expectedValue, test[0])
+		}
+	}
+
+	tests = [][]int{
+		{0, 8, 31},
+		{2, 5, 10},
+		{3, 4, 1},
+		{4, 4, 0},
+		{5, 2, 0},
+		{-2, 2, 4},
+		{6, 10, 8},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := tree.RangeSum(test[0], test[1]), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for RangeSum(%v, %v)", actualValue, expectedValue, test[0], test[1])
+		}
+	}
+
+	if actualValue, expectedValue := tree.Sum(), 31; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestFenwickTreeUpdate(t *testing.T) {
+	tree := New(3, 1, 4, 1, 5)
+	tree.Update(1, 10)
+	tree.Update(4, -5)
+	tree.Update(5, 100)  // out of bounds
+	tree.Update(-1, 100) // out of bounds
+	tree.Set(0, 7)
+	tree.Set(5, 100) // out of bounds
+
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[7 11 4 1 0]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.PrefixSum(3), 22; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, ok := tree.Get(1); actualValue != 11 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 11)
+	}
+	if actualValue, ok := tree.Get(5); actualValue != 0 || ok {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+
+	tree.Append(2, 6)
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[7 11 4 1 0 2 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RangeSum(4, 7), 8; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Clear()
+	if actualValue, expectedValue := tree.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Append(1)
+	if actualValue, expectedValue := tree.Sum(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestFenwickTreeLowerBound(t *testing.T) {
+	tree := New(2, 0, 3, 1, 0, 4)
+
+	tests := [][]int{
+		{-1, 0},
+		{0, 0},
+		{1, 0},
+		{2, 0},
+		{3, 2},
+		{5, 2},
+		{6, 3},
+		{7, 5},
+		{10, 5},
+		{11, 6},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := tree.LowerBound(test[0]), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for LowerBound(%v)", actualValue, expectedValue, test[0])
+		}
+	}
+
+	if actualValue, expectedValue := New().LowerBound(1), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestFenwickTreeRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for n := 0; n < 40; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = random.Intn(10)
+		}
+		tree := New(values...)
+		for operation := 0; operation < 50; operation++ {
+			switch random.Intn(3) {
+			case 0:
+				value := random.Intn(10)
+				tree.Append(value)
+				values = append(values, value)
+			default:
+				if len(values) > 0 {
+					index, delta := random.Intn(len(values)), random.Intn(10)
+					tree.Update(index, delta)
+					values[index] += delta
+				}
+			}
+
+			sum := 0
+			for i, value := range values {
+				if actualValue, expectedValue := tree.PrefixSum(i), sum; actualValue != expectedValue {
+					t.Fatalf("Got %v expected %v for PrefixSum(%v) of %v", actualValue, expectedValue, i, values)
+				}
+				if actualValue, _ := tree.Get(i); actualValue != value {
+					t.Fatalf("Got %v expected %v for Get(%v) of %v", actualValue, value, i, values)
+				}
+				sum += value
+				if value > 0 {
+					if actualValue, expectedValue := tree.LowerBound(sum), i; actualValue != expectedValue {
+						t.Fatalf("Got %v expected %v for LowerBound(%v) of %v", actualValue, expectedValue, sum, values)
+					}
+				}
+			}
+			if actualValue, expectedValue := tree.Sum(), sum; actualValue != expectedValue {
+				t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+			}
+			if actualValue, expectedValue := fmt.Sprint(tree.Values()), fmt.Sprint(New(values...).Values()); actualValue != expectedValue {
+				t.Fatalf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+}
+
+func TestFenwickTreeClone(t *testing.T) {
+	tree := New(1, 2, 3)
+
+	clone := tree.Clone()
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	clone.Update(0, 10)
+	if actualValue, expectedValue := tree.Sum(), 6; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := clone.Sum(), 16; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestFenwickTreeSerialization(t *testing.T) {
+	tree := New(3, 1, 4)
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[3 1 4]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := tree.PrefixSum(2), 4; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := tree.ToJSON()
+	assert()
+	if actualValue, expectedValue := string(json), "[3,1,4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = tree.FromJSON(json)
+	assert()
+
+	var buffer bytes.Buffer
+	err = tree.WriteJSON(&buffer)
+	assert()
+	err = tree.ReadJSON(&buffer)
+	assert()
+
+	err = tree.FromJSON([]byte(`[1,"x"]`))
+	if err == nil {
+		t.Errorf("Got no error for mistyped element")
+	}
+	err = tree.ReadJSON(bytes.NewBufferString(`[1,"x"]`))
+	if err == nil {
+		t.Errorf("Got no error for mistyped element")
+	}
+	err = nil
+	assert()
+}
+
+func TestFenwickTreeMarshalJSON(t *testing.T) {
+	type document struct {
+		Tree *Tree `json:"tree"`
+	}
+	tree := New(1, 2)
+
+	data, err := json.Marshal(&document{Tree: tree})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"tree":[1,2]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := doc.Tree.Sum(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestFenwickTreeBinarySerialization(t *testing.T) {
+	tree := New(3, -1, 4)
+
+	data, err := tree.MarshalBinary()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded := New()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue := containers.Equal(decoded, tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(tree); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded = New()
+	if err := gob.NewDecoder(&buffer).Decode(decoded); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(decoded.Values()), "[3 -1 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("Got no error for truncated input")
+	}
+}
+
+func benchmarkUpdate(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Update(n, 1)
+		}
+	}
+}
+
+func benchmarkPrefixSum(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.PrefixSum(n)
+		}
+	}
+}
+
+func BenchmarkFenwickTreeUpdate100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := New(make([]int, size)...)
+	b.StartTimer()
+	benchmarkUpdate(b, tree, size)
+}
+
+func BenchmarkFenwickTreePrefixSum100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := New(make([]int, size)...)
+	b.StartTimer()
+	benchmarkPrefixSum(b, tree, size)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fenwicktree
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
+
+func assertSerializationImplementation() {
+	var _ containers.JSONSerializer = (*Tree)(nil)
+	var _ containers.JSONDeserializer = (*Tree)(nil)
+	var _ json.Marshaler = (*Tree)(nil)
+	var _ json.Unmarshaler = (*Tree)(nil)
+	var _ encoding.BinaryMarshaler = (*Tree)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Tree)(nil)
+	var _ gob.GobEncoder = (*Tree)(nil)
+	var _ gob.GobDecoder = (*Tree)(nil)
+}
+
+// ToJSON outputs the JSON representation of tree's elements as an array in order of their indexes.
+func (tree *Tree) ToJSON() ([]byte, error) {
+	return json.Marshal(tree.ints())
+}
+
+// FromJSON populates tree's elements from the input JSON representation.
+func (tree *Tree) FromJSON(data []byte) error {
+	values := []int{}
+	err := json.Unmarshal(data, &values)
+	if err == nil {
+		*tree = *New(values...)
+	}
+	return err
+}
+
+// WriteJSON writes the JSON representation of tree's elements (see ToJSON) into the writer,
+// marshalling one element at a time.
+func (tree *Tree) WriteJSON(w io.Writer) error {
+	values := tree.ints()
+	i := -1
+	return utils.WriteJSONValues(w, func() (interface{}, bool) {
+		if i++; i == len(values) {
+			return nil, false
+		}
+		return values[i], true
+	})
+}
+
+// ReadJSON populates tree's elements from the JSON representation read from the reader (see FromJSON).
+// Tree is not modified if the input can not be decoded.
+func (tree *Tree) ReadJSON(r io.Reader) error {
+	values := []int{}
+	if err := utils.ReadJSONValues(r, utils.IntDecoder, appendInt(&values)); err != nil {
+		return err
+	}
+	*tree = *build(values)
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of tree's elements (implements json.Marshaler).
+func (tree *Tree) MarshalJSON() ([]byte, error) {
+	return tree.ToJSON()
+}
+
+// UnmarshalJSON populates tree's elements from the input JSON representation (implements json.Unmarshaler).
+func (tree *Tree) UnmarshalJSON(data []byte) error {
+	return tree.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of tree's elements encoded as varints (implements encoding.BinaryMarshaler).
+func (tree *Tree) MarshalBinary() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := tree.WriteBinary(buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates tree's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+// Tree is not modified if the input can not be decoded.
+func (tree *Tree) UnmarshalBinary(data []byte) error {
+	return tree.ReadBinary(bytes.NewReader(data))
+}
+
+// WriteBinary writes the binary representation of tree's elements (see MarshalBinary) into the writer,
+// encoding elements in small chunks.
+func (tree *Tree) WriteBinary(w io.Writer) error {
+	values := tree.ints()
+	i := -1
+	return utils.WriteBinaryValues(w, len(values), func() interface{} {
+		i++
+		return values[i]
+	}, utils.IntCodec)
+}
+
+// ReadBinary populates tree's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (tree *Tree) ReadBinary(r io.Reader) error {
+	values := []int{}
+	if err := utils.ReadBinaryValues(r, utils.IntCodec, appendInt(&values)); err != nil {
+		return err
+	}
+	*tree = *build(values)
+	return nil
+}
+
+// GobEncode outputs the binary representation of tree's elements (implements gob.GobEncoder).
+func (tree *Tree) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
+}
+
+// GobDecode populates tree's elements from the input binary representation (implements gob.GobDecoder).
+func (tree *Tree) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
+}
+
+// appendInt returns a function appending the decoded values, which are of type int, to the ints,
+// which become the sums of the tree once all values were read (see build).
+func appendInt(ints *[]int) func(value interface{}) error {
+	return func(value interface{}) error {
+		*ints = append(*ints, value.(int))
+		return nil
+	}
+}
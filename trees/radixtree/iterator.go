@@ -0,0 +1,159 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package radixtree
+
+import "github.com/emirpasic/gods/containers"
+
+func assertIteratorImplementation() {
+	var _ containers.ReverseIteratorWithKey = (*Iterator)(nil)
+}
+
+// Iterator holding the iterator's state
+type Iterator struct {
+	tree     *Tree
+	node     *node
+	position position
+}
+
+type position byte
+
+const (
+	begin, between, end position = 0, 1, 2
+)
+
+// Iterator returns a stateful iterator whose elements are key/value pairs in order of keys.
+// Keys are returned as strings.
+func (tree *Tree) Iterator() Iterator {
+	return Iterator{tree: tree, node: nil, position: begin}
+}
+
+// Next moves the iterator to the next element and returns true if there was a next element in the container.
+// If Next() returns true, then next element's key and value can be retrieved by Key() and Value().
+// If Next() was called for the first time, then it will point the iterator to the first element if it exists.
+// Modifies the state of the iterator.
+func (iterator *Iterator) Next() bool {
+	switch iterator.position {
+	case begin:
+		iterator.position = between
+		iterator.node = iterator.tree.root
+	case between:
+		iterator.node = iterator.node.next()
+	case end:
+		return false
+	}
+	for iterator.node != nil && !iterator.node.leaf {
+		iterator.node = iterator.node.next()
+	}
+	if iterator.node == nil {
+		iterator.position = end
+		return false
+	}
+	return true
+}
+
+// Prev moves the iterator to the previous element and returns true if there was a previous element in the container.
+// If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator) Prev() bool {
+	switch iterator.position {
+	case end:
+		iterator.position = between
+		iterator.node = iterator.tree.root.last()
+	case between:
+		iterator.node = iterator.node.prev()
+	case begin:
+		return false
+	}
+	for iterator.node != nil && !iterator.node.leaf {
+		iterator.node = iterator.node.prev()
+	}
+	if iterator.node == nil {
+		iterator.position = begin
+		return false
+	}
+	return true
+}
+
+// Value returns the current element's value.
+// Does not modify the state of the iterator.
+func (iterator *Iterator) Value() interface{} {
+	if iterator.node == nil {
+		return nil
+	}
+	return iterator.node.value
+}
+
+// Key returns the current element's key.
+// Does not modify the state of the iterator.
+func (iterator *Iterator) Key() interface{} {
+	if iterator.node == nil {
+		return nil
+	}
+	return iterator.node.key
+}
+
+// Begin resets the iterator to its initial state (one-before-first)
+// Call Next() to fetch the first element if any.
+func (iterator *Iterator) Begin() {
+	iterator.node = nil
+	iterator.position = begin
+}
+
+// End moves the iterator past the last element (one-past-the-end).
+// Call Prev() to fetch the last element if any.
+func (iterator *Iterator) End() {
+	iterator.node = nil
+	iterator.position = end
+}
+
+// First moves the iterator to the first element and returns true if there was a first element in the container.
+// If First() returns true, then first element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator
+func (iterator *Iterator) First() bool {
+	iterator.Begin()
+	return iterator.Next()
+}
+
+// Last moves the iterator to the last element and returns true if there was a last element in the container.
+// If Last() returns true, then last element's key and value can be retrieved by Key() and Value().
+// Modifies the state of the iterator.
+func (iterator *Iterator) Last() bool {
+	iterator.End()
+	return iterator.Prev()
+}
+
+// next returns the node following the node in pre-order, i.e. in order of keys, or nil if there is none.
+func (n *node) next() *node {
+	if len(n.children) > 0 {
+		return n.children[0]
+	}
+	for ; n.parent != nil; n = n.parent {
+		index, _ := n.parent.child(n.prefix[0])
+		if index+1 < len(n.parent.children) {
+			return n.parent.children[index+1]
+		}
+	}
+	return nil
+}
+
+// prev returns the node preceding the node in pre-order, i.e. in order of keys, or nil if there is none.
+func (n *node) prev() *node {
+	if n.parent == nil {
+		return nil
+	}
+	index, _ := n.parent.child(n.prefix[0])
+	if index == 0 {
+		return n.parent
+	}
+	return n.parent.children[index-1].last()
+}
+
+// last returns the last node in pre-order of the subtree rooted at the node.
+func (n *node) last() *node {
+	for len(n.children) > 0 {
+		n = n.children[len(n.children)-1]
+	}
+	return n
+}
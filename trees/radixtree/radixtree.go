@@ -0,0 +1,314 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package radixtree implements a radix tree (compressed trie) keyed by strings or byte slices.
+//
+// Keys sharing a prefix share the path from the root for that prefix, and chains of nodes with a single child are
+// merged into one node, so that lookups take time proportional to the length of the key rather than to the number of keys.
+// Besides lookups by key, the tree supports finding the longest key that is a prefix of a given key (LongestPrefix)
+// and visiting all keys starting with a given prefix (WalkPrefix). Elements are iterated in lexicographic (byte-wise) order of keys.
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Radix_tree
+package radixtree
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"github.com/emirpasic/gods/utils"
+	"sort"
+	"strings"
+)
+
+func assertTreeImplementation() {
+	var _ trees.Tree = new(Tree)
+}
+
+// Tree holds elements of the radix tree.
+type Tree struct {
+	root    *node         // Root node, its prefix is always empty
+	size    int           // Total number of keys in the tree
+	decoder utils.Decoder // decodes values from JSON, nil for Go's default JSON decoding (see SetDecoder)
+}
+
+// node is a single node of the tree, holding an element if it is a leaf in the sense of the trie (i.e. a key ends in it).
+type node struct {
+	prefix   string      // Part of the key on the edge from the parent to the node
+	key      string      // Whole key of the element held by the node
+	value    interface{} // Value of the element held by the node
+	leaf     bool        // Whether the node holds an element
+	parent   *node       // Parent node
+	children []*node     // Children nodes sorted by the first byte of their prefixes
+}
+
+// New instantiates a radix tree.
+func New() *Tree {
+	return &Tree{root: &node{}}
+}
+
+// Put inserts the key-value pair into the tree, replacing the value if the key is already in the tree.
+// Key should be a string or a []byte, otherwise method panics.
+func (tree *Tree) Put(key interface{}, value interface{}) {
+	k := toString(key)
+	n, rest := tree.root, k
+	for rest != "" {
+		index, child := n.child(rest[0])
+		if child == nil {
+			child = &node{prefix: rest, parent: n}
+			n.children = append(n.children, nil)
+			copy(n.children[index+1:], n.children[index:])
+			n.children[index] = child
+			n = child
+			break
+		}
+		common := commonPrefix(rest, child.prefix)
+		if common < len(child.prefix) {
+			// split the child's prefix, the new node takes the common part
+			middle := &node{prefix: child.prefix[:common], parent: n, children: []*node{child}}
+			child.prefix = child.prefix[common:]
+			child.parent = middle
+			n.children[index] = middle
+			child = middle
+		}
+		n, rest = child, rest[common:]
+	}
+	if !n.leaf {
+		n.leaf = true
+		n.key = k
+		tree.size++
+	}
+	n.value = value
+}
+
+// Get searches the element in the tree by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+// Key should be a string or a []byte, otherwise method panics.
+func (tree *Tree) Get(key interface{}) (value interface{}, found bool) {
+	n := tree.lookup(toString(key))
+	if n == nil || !n.leaf {
+		return nil, false
+	}
+	return n.value, true
+}
+
+// Remove removes the element from the tree by key.
+// Key should be a string or a []byte, otherwise method panics.
+func (tree *Tree) Remove(key interface{}) {
+	n := tree.lookup(toString(key))
+	if n == nil || !n.leaf {
+		return
+	}
+	n.leaf = false
+	n.key = ""
+	n.value = nil
+	tree.size--
+	if n != tree.root && len(n.children) == 0 {
+		parent := n.parent
+		index, _ := parent.child(n.prefix[0])
+		parent.children = append(parent.children[:index], parent.children[index+1:]...)
+		n = parent
+	}
+	n.compact()
+}
+
+// LongestPrefix returns the longest key in the tree that is a prefix of the given key and its value.
+// Third return parameter is true if such key was found, otherwise false.
+// Key should be a string or a []byte, otherwise method panics.
+func (tree *Tree) LongestPrefix(key interface{}) (prefix string, value interface{}, found bool) {
+	n, rest := tree.root, toString(key)
+	for {
+		if n.leaf {
+			prefix, value, found = n.key, n.value, true
+		}
+		if rest == "" {
+			return
+		}
+		_, child := n.child(rest[0])
+		if child == nil || !strings.HasPrefix(rest, child.prefix) {
+			return
+		}
+		n, rest = child, rest[len(child.prefix):]
+	}
+}
+
+// WalkPrefix calls the given function with each element whose key starts with the given prefix, in order of keys.
+// Walking stops when the function returns false.
+// Prefix should be a string or a []byte, otherwise method panics.
+func (tree *Tree) WalkPrefix(prefix interface{}, f func(key string, value interface{}) bool) {
+	n, rest := tree.root, toString(prefix)
+	for rest != "" {
+		_, child := n.child(rest[0])
+		if child == nil {
+			return
+		}
+		if len(rest) <= len(child.prefix) {
+			if !strings.HasPrefix(child.prefix, rest) {
+				return
+			}
+			n = child
+			break
+		}
+		if !strings.HasPrefix(rest, child.prefix) {
+			return
+		}
+		n, rest = child, rest[len(child.prefix):]
+	}
+	n.walk(f)
+}
+
+// Empty returns true if tree does not contain any elements.
+func (tree *Tree) Empty() bool {
+	return tree.size == 0
+}
+
+// Size returns number of elements in the tree.
+func (tree *Tree) Size() int {
+	return tree.size
+}
+
+// Keys returns all keys in order.
+func (tree *Tree) Keys() []interface{} {
+	keys := make([]interface{}, 0, tree.size)
+	tree.root.walk(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all values in order of their keys.
+func (tree *Tree) Values() []interface{} {
+	values := make([]interface{}, 0, tree.size)
+	tree.root.walk(func(key string, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// Clear removes all elements from the tree.
+func (tree *Tree) Clear() {
+	tree.root = &node{}
+	tree.size = 0
+}
+
+// Clone returns a deep copy of the tree. Keys and values are copied as they are (shallow copies).
+func (tree *Tree) Clone() *Tree {
+	return &Tree{root: tree.root.clone(nil), size: tree.size, decoder: tree.decoder}
+}
+
+// String returns a string representation of container
+func (tree *Tree) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("RadixTree\n")
+	if tree.root.leaf {
+		buffer.WriteString(fmt.Sprintf("%q: %v\n", "", tree.root.value))
+	}
+	for i, child := range tree.root.children {
+		child.output(&buffer, "", i == len(tree.root.children)-1)
+	}
+	return buffer.String()
+}
+
+func (n *node) output(buffer *bytes.Buffer, indent string, isTail bool) {
+	buffer.WriteString(indent)
+	if isTail {
+		buffer.WriteString("└── ")
+		indent += "    "
+	} else {
+		buffer.WriteString("├── ")
+		indent += "│   "
+	}
+	buffer.WriteString(n.prefix)
+	if n.leaf {
+		buffer.WriteString(fmt.Sprintf(": %v", n.value))
+	}
+	buffer.WriteString("\n")
+	for i, child := range n.children {
+		child.output(buffer, indent, i == len(n.children)-1)
+	}
+}
+
+// lookup returns the node in which the key ends or nil if there is no such node.
+func (tree *Tree) lookup(key string) *node {
+	n := tree.root
+	for key != "" {
+		_, child := n.child(key[0])
+		if child == nil || !strings.HasPrefix(key, child.prefix) {
+			return nil
+		}
+		n, key = child, key[len(child.prefix):]
+	}
+	return n
+}
+
+// child returns the child whose prefix starts with the byte and its index,
+// or nil and the index at which such child would be inserted.
+func (n *node) child(b byte) (int, *node) {
+	index := sort.Search(len(n.children), func(i int) bool { return n.children[i].prefix[0] >= b })
+	if index < len(n.children) && n.children[index].prefix[0] == b {
+		return index, n.children[index]
+	}
+	return index, nil
+}
+
+// compact merges the node with its only child if the node holds no element.
+func (n *node) compact() {
+	if n.parent == nil || n.leaf || len(n.children) != 1 {
+		return
+	}
+	child := n.children[0]
+	child.prefix = n.prefix + child.prefix
+	child.parent = n.parent
+	index, _ := n.parent.child(child.prefix[0])
+	n.parent.children[index] = child
+}
+
+// walk calls the function with each element in the subtree rooted at the node in order of keys,
+// returning false if the function did.
+func (n *node) walk(f func(key string, value interface{}) bool) bool {
+	if n.leaf && !f(n.key, n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.walk(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// clone copies the subtree rooted at the node, attaching it to the parent.
+func (n *node) clone(parent *node) *node {
+	clone := &node{prefix: n.prefix, key: n.key, value: n.value, leaf: n.leaf, parent: parent}
+	if len(n.children) > 0 {
+		clone.children = make([]*node, len(n.children))
+		for i, child := range n.children {
+			clone.children[i] = child.clone(clone)
+		}
+	}
+	return clone
+}
+
+// commonPrefix returns the length of the longest common prefix of the strings.
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// toString returns the key as a string, panicking if it is neither a string nor a []byte.
+func toString(key interface{}) string {
+	switch k := key.(type) {
+	case string:
+		return k
+	case []byte:
+		return string(k)
+	}
+	panic(fmt.Sprintf("radixtree: key %v of type %T is neither a string nor a []byte", key, key))
+}
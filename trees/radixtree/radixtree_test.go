@@ -0,0 +1,503 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package radixtree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/containers/containertest"
+	"github.com/emirpasic/gods/utils"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRadixTreePut(t *testing.T) {
+	tree := New()
+	tree.Put("romane", 1)
+	tree.Put("romanus", 2)
+	tree.Put("romulus", 3)
+	tree.Put("rubens", 4)
+	tree.Put("ruber", 5)
+	tree.Put("rubicon", 6)
+	tree.Put("rubicundus", 7)
+	tree.Put("rom", 8)
+	tree.Put("romane", 9) // overwrite
+
+	if actualValue := tree.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[rom romane romanus romulus rubens ruber rubicon rubicundus]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[8 9 2 3 4 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := [][]interface{}{
+		{"rom", 8, true},
+		{"romane", 9, true},
+		{"romanus", 2, true},
+		{"rubicundus", 7, true},
+		{"r", nil, false},
+		{"ro", nil, false},
+		{"roman", nil, false},
+		{"romanes", nil, false},
+		{"x", nil, false},
+		{"", nil, false},
+	}
+	for _, test := range tests {
+		actualValue, actualFound := tree.Get(test[0])
+		if actualValue != test[1] || actualFound != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+	}
+
+	tree.Put("", 0)
+	if actualValue, found := tree.Get(""); actualValue != 0 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := tree.Size(); actualValue != 9 {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+}
+
+func TestRadixTreeByteSliceKeys(t *testing.T) {
+	tree := New()
+	tree.Put([]byte("abc"), 1)
+	tree.Put("abd", 2)
+
+	if actualValue, found := tree.Get("abc"); actualValue != 1 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, found := tree.Get([]byte("abd")); actualValue != 2 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	tree.Remove([]byte("abc"))
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[abd]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for a key of type int")
+		}
+	}()
+	tree.Put(1, 1)
+}
+
+func TestRadixTreeRemove(t *testing.T) {
+	tree := New()
+	for i, key := range []string{"", "a", "ab", "abc", "abd", "b", "ba"} {
+		tree.Put(key, i)
+	}
+
+	tree.Remove("ab")
+	tree.Remove("abd")
+	tree.Remove("x")  // not found
+	tree.Remove("ab") // already removed
+	tree.Remove("")
+
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[a abc b ba]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := tree.Size(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue, expectedValue := tree.String(), "RadixTree\n├── a: 1\n│   └── bc: 3\n└── b: 5\n    └── a: 6\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Remove("a")
+	tree.Remove("b")
+	if actualValue, expectedValue := tree.String(), "RadixTree\n├── abc: 3\n└── ba: 6\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Remove("abc")
+	tree.Remove("ba")
+	if actualValue := tree.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, expectedValue := tree.String(), "RadixTree\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeLongestPrefix(t *testing.T) {
+	tree := New()
+	tree.Put("/", "root")
+	tree.Put("/api", "api")
+	tree.Put("/api/users", "users")
+	tree.Put("/apidocs", "docs")
+
+	tests := [][]interface{}{
+		{"/api/users/42", "/api/users", "users", true},
+		{"/api/user", "/api", "api", true},
+		{"/api", "/api", "api", true},
+		{"/apidocs/index.html", "/apidocs", "docs", true},
+		{"/apid", "/api", "api", true},
+		{"/static/main.css", "/", "root", true},
+		{"static", "", nil, false},
+		{"", "", nil, false},
+	}
+	for _, test := range tests {
+		actualPrefix, actualValue, actualFound := tree.LongestPrefix(test[0])
+		if actualPrefix != test[1] || actualValue != test[2] || actualFound != test[3] {
+			t.Errorf("Got %v %v %v expected %v %v %v", actualPrefix, actualValue, actualFound, test[1], test[2], test[3])
+		}
+	}
+
+	tree.Put("", "default")
+	if actualPrefix, actualValue, actualFound := tree.LongestPrefix("static"); actualPrefix != "" || actualValue != "default" || !actualFound {
+		t.Errorf("Got %v %v %v expected %v %v %v", actualPrefix, actualValue, actualFound, "", "default", true)
+	}
+}
+
+func TestRadixTreeWalkPrefix(t *testing.T) {
+	tree := New()
+	for i, key := range []string{"car", "cart", "carbon", "cat", "dog", "do"} {
+		tree.Put(key, i)
+	}
+
+	walk := func(prefix interface{}, limit int) string {
+		keys := []string{}
+		tree.WalkPrefix(prefix, func(key string, value interface{}) bool {
+			keys = append(keys, key)
+			return len(keys) < limit
+		})
+		return strings.Join(keys, ",")
+	}
+
+	tests := [][]interface{}{
+		{"", "car,carbon,cart,cat,do,dog"},
+		{"c", "car,carbon,cart,cat"},
+		{"ca", "car,carbon,cart,cat"},
+		{"car", "car,carbon,cart"},
+		{"carb", "carbon"},
+		{"carbon", "carbon"},
+		{"carbons", ""},
+		{"cab", ""},
+		{"d", "do,dog"},
+		{"x", ""},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := walk(test[0], 100), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for prefix %v", actualValue, expectedValue, test[0])
+		}
+	}
+
+	if actualValue, expectedValue := walk("ca", 2), "car,carbon"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := walk([]byte("do"), 1), "do"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeIterator(t *testing.T) {
+	tree := New()
+	tree.Put("b", 2)
+	tree.Put("ab", 1)
+	tree.Put("", 0)
+	tree.Put("bcd", 3)
+	tree.Put("bce", 4)
+
+	it := tree.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if actualValue, expectedValue := count, 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Next(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys := []string{}
+	for it.End(); it.Prev(); {
+		keys = append(keys, it.Key().(string))
+	}
+	if actualValue, expectedValue := strings.Join(keys, ","), "bce,bcd,b,ab,"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	it.Last()
+	if actualValue, expectedValue := it.Key(), "bce"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Prev()
+	it.Next()
+	if actualValue, expectedValue := it.Value(), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeClone(t *testing.T) {
+	tree := New()
+	tree.Put("ab", 1)
+	tree.Put("ac", 2)
+
+	clone := tree.Clone()
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	clone.Put("a", 0)
+	clone.Remove("ab")
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[ab ac]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(clone.Keys()), "[a ac]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tree := New()
+	model := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		key := strconv.FormatInt(random.Int63n(200), 3)
+		if random.Intn(3) == 0 {
+			tree.Remove(key)
+			delete(model, key)
+		} else {
+			tree.Put(key, i)
+			model[key] = i
+		}
+
+		keys := make([]string, 0, len(model))
+		for key := range model {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(keys); actualValue != expectedValue {
+			t.Fatalf("Got %v expected %v after operation %v", actualValue, expectedValue, i)
+		}
+		for _, key := range keys {
+			if actualValue, found := tree.Get(key); actualValue != model[key] || !found {
+				t.Fatalf("Got %v expected %v after operation %v", actualValue, model[key], i)
+			}
+		}
+		checkNodes(t, tree.root)
+	}
+}
+
+// checkNodes checks that nodes other than the root hold an element or have at least two children.
+func checkNodes(t *testing.T, n *node) {
+	if n.parent != nil && !n.leaf && len(n.children) < 2 {
+		t.Fatalf("Got node %q with %v children holding no element", n.prefix, len(n.children))
+	}
+	for _, child := range n.children {
+		if child.parent != n {
+			t.Fatalf("Got node %q with wrong parent", child.prefix)
+		}
+		checkNodes(t, child)
+	}
+}
+
+func TestRadixTreeSerialization(t *testing.T) {
+	tree := New()
+	tree.Put("c", "3")
+	tree.Put("b", "2")
+	tree.Put("a", "1")
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[a b c]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[1 2 3]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := tree.ToJSON()
+	assert()
+	if actualValue, expectedValue := string(json), `{"a":"1","b":"2","c":"3"}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = tree.FromJSON(json)
+	assert()
+}
+
+func TestRadixTreeMarshalJSON(t *testing.T) {
+	type document struct {
+		Tree *Tree `json:"tree"`
+	}
+	tree := New()
+	tree.Put("a", 1)
+	tree.Put("b", 2)
+
+	data, err := json.Marshal(&document{Tree: tree})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"tree":{"a":1,"b":2}}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	doc := &document{Tree: New()}
+	err = json.Unmarshal(data, doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(doc.Tree.Values()), "[1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = doc.Tree.FromJSONWith([]byte(`{"x":"y"}`), utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped value")
+	}
+	if actualValue, expectedValue := doc.Tree.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeBinarySerialization(t *testing.T) {
+	tree := New()
+	tree.Put("b", 2)
+	tree.Put("a", 1)
+
+	data, err := tree.MarshalBinaryWith(utils.IntCodec)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded := New()
+	if err := decoded.UnmarshalBinaryWith(data, utils.IntCodec); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue := containers.Equal(decoded, tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(tree); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded = New()
+	if err := gob.NewDecoder(&buffer).Decode(decoded); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(decoded.Keys(), decoded.Values()), "[a b] [1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree = New()
+	tree.Put([]byte{'a', 0xff}, 1)
+	data, err = tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), "{\"a\ufffd\":1}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	data, err = tree.MarshalBinary()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded = New()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, found := decoded.Get([]byte{'a', 0xff}); actualValue != 1 || !found {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestRadixTreeConformance(t *testing.T) {
+	for _, keys := range [][]interface{}{{}, {""}, {"a", "ab", "b"}, {"", "car", "carbon", "cart", "cat"}} {
+		tree := New()
+		values := []interface{}{}
+		for i, key := range keys {
+			tree.Put(key, i)
+			values = append(values, i)
+		}
+		it := tree.Iterator()
+		containertest.TestReverseIteratorWithKey(t, &it, keys, values)
+	}
+}
+
+func benchmarkGet(b *testing.B, tree *Tree, keys []string) {
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			tree.Get(key)
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, tree *Tree, keys []string) {
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			tree.Put(key, struct{}{})
+		}
+	}
+}
+
+func benchmarkLongestPrefix(b *testing.B, tree *Tree, keys []string) {
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			tree.LongestPrefix(key + "/x")
+		}
+	}
+}
+
+func benchmarkKeys(size int) []string {
+	keys := make([]string, size)
+	for n := range keys {
+		keys[n] = "/key/" + strconv.Itoa(n)
+	}
+	return keys
+}
+
+func BenchmarkRadixTreeGet1000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(1000)
+	tree := New()
+	for _, key := range keys {
+		tree.Put(key, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, keys)
+}
+
+func BenchmarkRadixTreePut1000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(1000)
+	tree := New()
+	b.StartTimer()
+	benchmarkPut(b, tree, keys)
+}
+
+func BenchmarkRadixTreeLongestPrefix1000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(1000)
+	tree := New()
+	for _, key := range keys {
+		tree.Put(key, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkLongestPrefix(b, tree, keys)
+}
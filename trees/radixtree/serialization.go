@@ -0,0 +1,166 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package radixtree
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
+
+func assertSerializationImplementation() {
+	var _ containers.JSONSerializer = (*Tree)(nil)
+	var _ containers.JSONDeserializer = (*Tree)(nil)
+	var _ json.Marshaler = (*Tree)(nil)
+	var _ json.Unmarshaler = (*Tree)(nil)
+	var _ encoding.BinaryMarshaler = (*Tree)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Tree)(nil)
+	var _ gob.GobEncoder = (*Tree)(nil)
+	var _ gob.GobDecoder = (*Tree)(nil)
+}
+
+// ToJSON outputs the JSON representation of tree's elements as an object with members in key order.
+// Keys become member names, which are UTF-8 strings, hence invalid UTF-8 in keys (e.g. arbitrary byte slices)
+// is replaced by U+FFFD. Use the binary representation (see MarshalBinary) for keys that are not valid UTF-8.
+func (tree *Tree) ToJSON() ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := tree.WriteJSON(buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// FromJSON populates tree's elements from the input JSON representation.
+// Values are decoded with the tree's decoder (see SetDecoder and FromJSONWith for other decoders).
+func (tree *Tree) FromJSON(data []byte) error {
+	return tree.FromJSONWith(data, tree.decoder)
+}
+
+// FromJSONWith populates tree's elements from the input JSON representation,
+// decoding values with the given decoder (nil for Go's default JSON decoding).
+// Tree is not modified if the input can not be decoded.
+func (tree *Tree) FromJSONWith(data []byte, valueDecoder utils.Decoder) error {
+	return tree.ReadJSONWith(bytes.NewReader(data), valueDecoder)
+}
+
+// SetDecoder sets the decoder of values used by FromJSON, ReadJSON and UnmarshalJSON (nil for Go's default JSON decoding),
+// e.g. for unmarshalling a field holding the tree with json.Unmarshal.
+func (tree *Tree) SetDecoder(valueDecoder utils.Decoder) {
+	tree.decoder = valueDecoder
+}
+
+// WriteJSON writes the JSON representation of tree's elements (see ToJSON) into the writer,
+// marshalling one element at a time instead of building the whole representation in memory.
+func (tree *Tree) WriteJSON(w io.Writer) error {
+	it := tree.Iterator()
+	return utils.WriteJSONObject(w, func() (string, interface{}, bool) {
+		if !it.Next() {
+			return "", nil, false
+		}
+		return it.Key().(string), it.Value(), true
+	})
+}
+
+// ReadJSON populates tree's elements from the JSON representation read from the reader (see FromJSON).
+func (tree *Tree) ReadJSON(r io.Reader) error {
+	return tree.ReadJSONWith(r, tree.decoder)
+}
+
+// ReadJSONWith populates tree's elements from the JSON representation read from the reader (see FromJSONWith).
+func (tree *Tree) ReadJSONWith(r io.Reader, valueDecoder utils.Decoder) error {
+	loaded := New()
+	if err := utils.ReadJSONObject(r, valueDecoder, func(key string, value interface{}) error {
+		loaded.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	tree.root, tree.size = loaded.root, loaded.size
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of tree's elements (implements json.Marshaler).
+func (tree *Tree) MarshalJSON() ([]byte, error) {
+	return tree.ToJSON()
+}
+
+// UnmarshalJSON populates tree's elements from the input JSON representation (implements json.Unmarshaler).
+func (tree *Tree) UnmarshalJSON(data []byte) error {
+	return tree.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of tree's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (tree *Tree) MarshalBinary() ([]byte, error) {
+	return tree.MarshalBinaryWith(nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of tree's elements,
+// encoding values with the given codec (nil for gob). Keys are always encoded with utils.StringCodec.
+func (tree *Tree) MarshalBinaryWith(valueCodec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := tree.WriteBinaryWith(buffer, valueCodec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates tree's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (tree *Tree) UnmarshalBinary(data []byte) error {
+	return tree.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates tree's elements from the input binary representation,
+// decoding values with the given codec, which must be the one the values were encoded with.
+// Tree is not modified if the input can not be decoded.
+func (tree *Tree) UnmarshalBinaryWith(data []byte, valueCodec utils.Codec) error {
+	return tree.ReadBinaryWith(bytes.NewReader(data), valueCodec)
+}
+
+// WriteBinary writes the binary representation of tree's elements (see MarshalBinary) into the writer.
+func (tree *Tree) WriteBinary(w io.Writer) error {
+	return tree.WriteBinaryWith(w, nil)
+}
+
+// WriteBinaryWith writes the binary representation of tree's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks instead of building the whole representation in memory.
+func (tree *Tree) WriteBinaryWith(w io.Writer, valueCodec utils.Codec) error {
+	it := tree.Iterator()
+	return utils.WriteBinaryPairs(w, tree.Size(), func() (interface{}, interface{}) {
+		it.Next()
+		return it.Key(), it.Value()
+	}, utils.StringCodec, valueCodec)
+}
+
+// ReadBinary populates tree's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (tree *Tree) ReadBinary(r io.Reader) error {
+	return tree.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates tree's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+func (tree *Tree) ReadBinaryWith(r io.Reader, valueCodec utils.Codec) error {
+	loaded := New()
+	if err := utils.ReadBinaryPairs(r, utils.StringCodec, valueCodec, func(key interface{}, value interface{}) error {
+		loaded.Put(key, value)
+		return nil
+	}); err != nil {
+		return err
+	}
+	tree.root, tree.size = loaded.root, loaded.size
+	return nil
+}
+
+// GobEncode outputs the binary representation of tree's elements (implements gob.GobEncoder).
+func (tree *Tree) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
+}
+
+// GobDecode populates tree's elements from the input binary representation (implements gob.GobDecoder).
+func (tree *Tree) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redblacktree
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"io"
+	"strings"
+)
+
+// ToDOT writes the tree into the writer as a Graphviz DOT graph with nodes filled in their colors.
+// If a node has only one child, the missing one is drawn as an invisible node to keep left and right children apart.
+func (tree *Tree) ToDOT(w io.Writer, options *trees.DOTOptions) error {
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "digraph %s {\n", dotGraphName(options))
+	fmt.Fprintln(writer, "\tordering=out;")
+	fmt.Fprintln(writer, "\tnode [shape=circle, style=filled, fontcolor=white];")
+	if tree.Root != nil {
+		id := 0
+		outputDOT(writer, tree.Root, &id, options)
+	}
+	fmt.Fprintln(writer, "}")
+	return writer.Flush()
+}
+
+// outputDOT writes the subtree rooted at the node, numbering nodes in pre-order starting from id, and returns the node's number.
+func outputDOT(writer *bufio.Writer, node *Node, id *int, options *trees.DOTOptions) int {
+	n := *id
+	*id++
+	fillColor := "black"
+	if node.color == red {
+		fillColor = "red"
+	}
+	fmt.Fprintf(writer, "\tn%d [label=\"%s\", fillcolor=%s];\n", n, dotLabel(options, node.Key, node.Value), fillColor)
+	if node.Left == nil && node.Right == nil {
+		return n
+	}
+	for _, child := range []*Node{node.Left, node.Right} {
+		if child != nil {
+			fmt.Fprintf(writer, "\tn%d -> n%d;\n", n, outputDOT(writer, child, id, options))
+		} else {
+			fmt.Fprintf(writer, "\tn%d [style=invis];\n\tn%d -> n%d [style=invis];\n", *id, n, *id)
+			*id++
+		}
+	}
+	return n
+}
+
+// dotEscaper escapes characters with special meaning in quoted DOT strings
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", "")
+
+// dotGraphName returns the quoted name of the graph, "tree" if the options name none.
+func dotGraphName(options *trees.DOTOptions) string {
+	if options == nil || options.Name == "" {
+		return `"tree"`
+	}
+	return `"` + dotEscaper.Replace(options.Name) + `"`
+}
+
+// dotLabel returns the text of the label of a node with the key and value, escaped to be used within a quoted DOT string.
+func dotLabel(options *trees.DOTOptions, key interface{}, value interface{}) string {
+	format := func(element interface{}) string { return fmt.Sprint(element) }
+	if options != nil && options.Format != nil {
+		format = options.Format
+	}
+	label := format(key)
+	if options != nil && options.Values {
+		label += ": " + format(value)
+	}
+	return dotEscaper.Replace(label)
+}
@@ -12,9 +12,12 @@ func assertIteratorImplementation() {
 
 // Iterator holding the iterator's state
 type Iterator struct {
-	tree     *Tree
-	node     *Node
-	position position
+	tree          *Tree
+	node          *Node
+	position      position
+	removed       bool  // current node was removed, node is its successor and previous its predecessor
+	previous      *Node // predecessor of the removed node
+	modifications int   // tree's modifications when the iterator moved onto the current node
 }
 
 type position byte
@@ -24,6 +27,8 @@ const (
 )
 
 // Iterator returns a stateful iterator whose elements are key/value pairs.
+// The iterator is fail-fast: Next() and Prev() panic with containers.ErrConcurrentModification
+// if the tree has been structurally modified since the iterator moved onto its current element.
 func (tree *Tree) Iterator() Iterator {
 	return Iterator{tree: tree, node: nil, position: begin}
 }
@@ -33,6 +38,14 @@ func (tree *Tree) Iterator() Iterator {
 // If Next() was called for the first time, then it will point the iterator to the first element if it exists.
 // Modifies the state of the iterator.
 func (iterator *Iterator) Next() bool {
+	iterator.checkModifications()
+	if iterator.removed {
+		iterator.removed = false
+		if iterator.node == nil {
+			goto end
+		}
+		goto between
+	}
 	if iterator.position == end {
 		goto end
 	}
@@ -68,6 +81,7 @@ end:
 
 between:
 	iterator.position = between
+	iterator.modifications = iterator.tree.modifications
 	return true
 }
 
@@ -75,6 +89,15 @@ between:
 // If Prev() returns true, then previous element's key and value can be retrieved by Key() and Value().
 // Modifies the state of the iterator.
 func (iterator *Iterator) Prev() bool {
+	iterator.checkModifications()
+	if iterator.removed {
+		iterator.removed = false
+		iterator.node, iterator.previous = iterator.previous, nil
+		if iterator.node == nil {
+			goto begin
+		}
+		goto between
+	}
 	if iterator.position == begin {
 		goto begin
 	}
@@ -110,6 +133,7 @@ begin:
 
 between:
 	iterator.position = between
+	iterator.modifications = iterator.tree.modifications
 	return true
 }
 
@@ -130,6 +154,8 @@ func (iterator *Iterator) Key() interface{} {
 func (iterator *Iterator) Begin() {
 	iterator.node = nil
 	iterator.position = begin
+	iterator.removed = false
+	iterator.previous = nil
 }
 
 // End moves the iterator past the last element (one-past-the-end).
@@ -137,6 +163,8 @@ func (iterator *Iterator) Begin() {
 func (iterator *Iterator) End() {
 	iterator.node = nil
 	iterator.position = end
+	iterator.removed = false
+	iterator.previous = nil
 }
 
 // First moves the iterator to the first element and returns true if there was a first element in the container.
@@ -154,3 +182,42 @@ func (iterator *Iterator) Last() bool {
 	iterator.End()
 	return iterator.Prev()
 }
+
+// Remove removes the current element from the tree without searching for it.
+// Afterwards the iterator is positioned between the neighbours of the removed element,
+// i.e. Next() moves to the element after it and Prev() to the element before it.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) Remove() {
+	if iterator.position != between || iterator.removed {
+		return
+	}
+	iterator.checkModifications()
+	node, next, prev := iterator.node, *iterator, *iterator
+	next.Next()
+	prev.Prev()
+	if node.Left != nil && node.Right != nil {
+		// the predecessor is moved into the node
+		prev.node = node
+	}
+	iterator.tree.removeNode(node)
+	iterator.node, iterator.previous = next.node, prev.node
+	iterator.removed = true
+	iterator.modifications = iterator.tree.modifications
+}
+
+// SetValue replaces the current element's value.
+// Does nothing if the iterator is not positioned on an element.
+func (iterator *Iterator) SetValue(value interface{}) {
+	if iterator.position != between || iterator.removed {
+		return
+	}
+	iterator.checkModifications()
+	iterator.node.Value = value
+}
+
+// checkModifications panics if the iterator is positioned on a node and the tree has been structurally modified since it moved there.
+func (iterator *Iterator) checkModifications() {
+	if iterator.position == between && iterator.modifications != iterator.tree.modifications {
+		panic(containers.ErrConcurrentModification)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redblacktree
+
+import "github.com/emirpasic/gods/utils"
+
+// Split moves the nodes with keys smaller than the key into the left tree and the remaining ones into the right tree,
+// leaving the tree empty. Both trees have the comparator of the tree.
+// The tree is split in O(log n) time, counting the elements of the smaller tree takes time linear in its size.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Split(key interface{}) (left *Tree, right *Tree) {
+	l, _, equal, r, rh := tree.split(tree.Root, blackHeight(tree.Root), key)
+	if equal != nil {
+		r, _ = join(nil, 0, equal, r, rh)
+	}
+	left, right = newTree(tree.Comparator, l, 0), newTree(tree.Comparator, r, 0)
+	count(left, right, tree.size)
+	tree.Clear()
+	return left, right
+}
+
+// Join moves the nodes of both trees into a new tree with the comparator of the left tree, leaving them empty.
+// If all keys of the left tree are smaller than the keys of the right tree, the trees are joined in O(log n) time,
+// otherwise the elements of the right tree are put into the left tree one by one, replacing values of equal keys.
+func Join(left *Tree, right *Tree) *Tree {
+	var tree *Tree
+	if left.Empty() || right.Empty() || left.Comparator(left.Right().Key, right.Left().Key) < 0 {
+		root, _ := join2(left.Root, blackHeight(left.Root), right.Root, blackHeight(right.Root))
+		tree = newTree(left.Comparator, root, left.size+right.size)
+	} else {
+		tree = newTree(left.Comparator, left.Root, left.size)
+		it := right.Iterator()
+		for it.Next() {
+			tree.Put(it.Key(), it.Value())
+		}
+	}
+	left.Clear()
+	right.Clear()
+	return tree
+}
+
+// Union moves the elements of both trees into a new tree with the comparator of the first tree, leaving them empty.
+// Keys found in both trees are taken with their values from the second tree.
+// Subtrees are merged by splitting and joining them, so that merging trees of m and n ≥ m elements takes
+// O(m log(n/m + 1)) joins, which is much faster than inserting the elements of one tree into the other.
+func Union(first *Tree, second *Tree) *Tree {
+	root, _, common := first.union(first.Root, blackHeight(first.Root), second.Root, blackHeight(second.Root))
+	tree := newTree(first.Comparator, root, first.size+second.size-common)
+	first.Clear()
+	second.Clear()
+	return tree
+}
+
+// Intersection moves the elements of the first tree whose keys are found in the second tree into a new tree
+// with the comparator of the first tree, leaving both trees empty.
+// Keys are taken with their values from the second tree. Subtrees are merged by splitting and joining them (see Union).
+func Intersection(first *Tree, second *Tree) *Tree {
+	root, _, size := first.intersection(first.Root, blackHeight(first.Root), second.Root, blackHeight(second.Root))
+	tree := newTree(first.Comparator, root, size)
+	first.Clear()
+	second.Clear()
+	return tree
+}
+
+// Difference moves the elements of the first tree whose keys are not found in the second tree into a new tree
+// with the comparator of the first tree, leaving both trees empty.
+// Subtrees are merged by splitting and joining them (see Union).
+func Difference(first *Tree, second *Tree) *Tree {
+	root, _, removed := first.difference(first.Root, blackHeight(first.Root), second.Root, blackHeight(second.Root))
+	tree := newTree(first.Comparator, root, first.size-removed)
+	first.Clear()
+	second.Clear()
+	return tree
+}
+
+// split splits the subtree of the black height rooted at the node into the subtree with keys smaller than the key,
+// the node with the key, if any, and the subtree with keys larger than the key, returning the subtrees with their black heights.
+func (tree *Tree) split(node *Node, height int, key interface{}) (*Node, int, *Node, *Node, int) {
+	if node == nil {
+		return nil, 0, nil, nil, 0
+	}
+	height = node.childHeight(height)
+	left, right := node.detach()
+	compare := tree.Comparator(key, node.Key)
+	switch {
+	case compare == 0:
+		return left, height, node, right, height
+	case compare < 0:
+		leftLeft, leftLeftHeight, equal, leftRight, leftRightHeight := tree.split(left, height, key)
+		right, rightHeight := join(leftRight, leftRightHeight, node, right, height)
+		return leftLeft, leftLeftHeight, equal, right, rightHeight
+	default:
+		rightLeft, rightLeftHeight, equal, rightRight, rightRightHeight := tree.split(right, height, key)
+		left, leftHeight := join(left, height, node, rightLeft, rightLeftHeight)
+		return left, leftHeight, equal, rightRight, rightRightHeight
+	}
+}
+
+// union merges the subtrees of the black heights and returns the merged subtree with its black height and the number of common keys.
+func (tree *Tree) union(node1 *Node, height1 int, node2 *Node, height2 int) (*Node, int, int) {
+	if node1 == nil {
+		return node2, height2, 0
+	}
+	if node2 == nil {
+		return node1, height1, 0
+	}
+	height1 = node1.childHeight(height1)
+	left1, right1 := node1.detach()
+	left2, leftHeight2, equal, right2, rightHeight2 := tree.split(node2, height2, node1.Key)
+	common := 0
+	if equal != nil {
+		node1.Key, node1.Value, common = equal.Key, equal.Value, 1
+	}
+	left, leftHeight, leftCommon := tree.union(left1, height1, left2, leftHeight2)
+	right, rightHeight, rightCommon := tree.union(right1, height1, right2, rightHeight2)
+	node, height := join(left, leftHeight, node1, right, rightHeight)
+	return node, height, common + leftCommon + rightCommon
+}
+
+// intersection merges the subtrees of the black heights and returns the subtree of their common keys with its black height and size.
+func (tree *Tree) intersection(node1 *Node, height1 int, node2 *Node, height2 int) (*Node, int, int) {
+	if node1 == nil || node2 == nil {
+		return nil, 0, 0
+	}
+	height1 = node1.childHeight(height1)
+	left1, right1 := node1.detach()
+	left2, leftHeight2, equal, right2, rightHeight2 := tree.split(node2, height2, node1.Key)
+	left, leftHeight, leftSize := tree.intersection(left1, height1, left2, leftHeight2)
+	right, rightHeight, rightSize := tree.intersection(right1, height1, right2, rightHeight2)
+	if equal == nil {
+		node, height := join2(left, leftHeight, right, rightHeight)
+		return node, height, leftSize + rightSize
+	}
+	node1.Key, node1.Value = equal.Key, equal.Value
+	node, height := join(left, leftHeight, node1, right, rightHeight)
+	return node, height, leftSize + rightSize + 1
+}
+
+// difference merges the subtrees of the black heights and returns the subtree of the keys of the first one
+// that are not found in the second one with its black height and the number of removed keys.
+func (tree *Tree) difference(node1 *Node, height1 int, node2 *Node, height2 int) (*Node, int, int) {
+	if node1 == nil {
+		return nil, 0, 0
+	}
+	if node2 == nil {
+		return node1, height1, 0
+	}
+	height2 = node2.childHeight(height2)
+	left2, right2 := node2.detach()
+	left1, leftHeight1, equal, right1, rightHeight1 := tree.split(node1, height1, node2.Key)
+	left, leftHeight, leftRemoved := tree.difference(left1, leftHeight1, left2, height2)
+	right, rightHeight, rightRemoved := tree.difference(right1, rightHeight1, right2, height2)
+	removed := leftRemoved + rightRemoved
+	if equal != nil {
+		removed++
+	}
+	node, height := join2(left, leftHeight, right, rightHeight)
+	return node, height, removed
+}
+
+// join joins the subtrees of the black heights through the node, whose key is between the keys of the subtrees,
+// and returns the joined subtree with its black height.
+// The node is inserted as a red node in place of the black node of the same black height as the shorter subtree
+// on the facing spine of the taller subtree, and the tree is fixed up as after an insertion.
+func join(left *Node, leftHeight int, node *Node, right *Node, rightHeight int) (*Node, int) {
+	if nodeColor(left) == red {
+		left.color = black
+		leftHeight++
+	}
+	if nodeColor(right) == red {
+		right.color = black
+		rightHeight++
+	}
+	node.Parent = nil
+	switch {
+	case leftHeight > rightHeight:
+		parent, child, height := (*Node)(nil), left, leftHeight
+		for nodeColor(child) == red || height > rightHeight {
+			if child.color == black {
+				height--
+			}
+			parent, child = child, child.Right
+		}
+		node.Left, node.Right, node.color = child, right, red
+		parent.Right, node.Parent = node, parent
+		node.attach()
+		return fixJoin(left, leftHeight, node, left.Left)
+	case rightHeight > leftHeight:
+		parent, child, height := (*Node)(nil), right, rightHeight
+		for nodeColor(child) == red || height > leftHeight {
+			if child.color == black {
+				height--
+			}
+			parent, child = child, child.Left
+		}
+		node.Left, node.Right, node.color = left, child, red
+		parent.Left, node.Parent = node, parent
+		node.attach()
+		return fixJoin(right, rightHeight, node, right.Right)
+	}
+	node.Left, node.Right, node.color = left, right, black
+	node.attach()
+	return node, leftHeight + 1
+}
+
+// fixJoin fixes up the subtree of the black height rooted at the root after the red node has been inserted into it and
+// returns the fixed up subtree with its black height. The black height grows only if the fix up reaches the root,
+// which happens if the other child of the root, which is away from the inserted node, is red and is colored black.
+func fixJoin(root *Node, height int, node *Node, other *Node) (*Node, int) {
+	tree := &Tree{Root: root}
+	otherRed := nodeColor(other) == red
+	tree.insertCase1(node)
+	if tree.Root == root && otherRed && nodeColor(other) == black {
+		height++
+	}
+	return tree.Root, height
+}
+
+// join2 joins the subtrees of the black heights, whose keys are all smaller in the left one than in the right one,
+// through the minimum of the right one and returns the joined subtree with its black height.
+func join2(left *Node, leftHeight int, right *Node, rightHeight int) (*Node, int) {
+	if right == nil {
+		return left, leftHeight
+	}
+	right, rightHeight, minimum := splitMinimum(right, rightHeight)
+	return join(left, leftHeight, minimum, right, rightHeight)
+}
+
+// splitMinimum removes the minimum from the subtree of the black height rooted at the node
+// and returns the rest of the subtree with its black height and the minimum.
+func splitMinimum(node *Node, height int) (*Node, int, *Node) {
+	height = node.childHeight(height)
+	left, right := node.detach()
+	if left == nil {
+		return right, height, node
+	}
+	left, leftHeight, minimum := splitMinimum(left, height)
+	node, height = join(left, leftHeight, node, right, height)
+	return node, height, minimum
+}
+
+// blackHeight returns the number of black nodes on the paths from the node to the leaves of its subtree.
+func blackHeight(node *Node) int {
+	height := 0
+	for ; node != nil; node = node.Left {
+		if node.color == black {
+			height++
+		}
+	}
+	return height
+}
+
+// childHeight returns the black height of the children of the node of the black height.
+func (node *Node) childHeight(height int) int {
+	if node.color == black {
+		return height - 1
+	}
+	return height
+}
+
+// detach removes the children of the node and returns them as roots of their subtrees.
+func (node *Node) detach() (left *Node, right *Node) {
+	left, right = node.Left, node.Right
+	node.Left, node.Right = nil, nil
+	if left != nil {
+		left.Parent = nil
+	}
+	if right != nil {
+		right.Parent = nil
+	}
+	return left, right
+}
+
+// attach makes the node the parent of its children.
+func (node *Node) attach() {
+	if node.Left != nil {
+		node.Left.Parent = node
+	}
+	if node.Right != nil {
+		node.Right.Parent = node
+	}
+}
+
+// newTree returns a tree of the size rooted at the node, which is colored black.
+func newTree(comparator utils.Comparator, root *Node, size int) *Tree {
+	if root != nil {
+		root.color = black
+	}
+	return &Tree{Root: root, size: size, Comparator: comparator}
+}
+
+// count sets the sizes of the trees, which have the size elements together, by counting the elements of the smaller one.
+func count(left *Tree, right *Tree, size int) {
+	leftIterator, rightIterator := left.Iterator(), right.Iterator()
+	rightIterator.End()
+	for n := 0; ; n++ {
+		if !leftIterator.Next() {
+			left.size, right.size = n, size-n
+			return
+		}
+		if !rightIterator.Prev() {
+			left.size, right.size = size-n, n
+			return
+		}
+	}
+}
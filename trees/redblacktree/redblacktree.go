@@ -29,9 +29,12 @@ const (
 
 // Tree holds elements of the red-black tree
 type Tree struct {
-	Root       *Node
-	size       int
-	Comparator utils.Comparator
+	Root          *Node
+	size          int
+	Comparator    utils.Comparator
+	keyDecoder    utils.Decoder // decodes keys from JSON, nil for Go's default JSON decoding (see SetDecoders)
+	valueDecoder  utils.Decoder // decodes values from JSON, nil for Go's default JSON decoding
+	modifications int           // number of structural modifications, used by iterators to fail fast
 }
 
 // Node is a single element within the tree
@@ -51,12 +54,12 @@ func NewWith(comparator utils.Comparator) *Tree {
 
 // NewWithIntComparator instantiates a red-black tree with the IntComparator, i.e. keys are of type int.
 func NewWithIntComparator() *Tree {
-	return &Tree{Comparator: utils.IntComparator}
+	return &Tree{Comparator: utils.IntComparator, keyDecoder: utils.IntDecoder}
 }
 
 // NewWithStringComparator instantiates a red-black tree with the StringComparator, i.e. keys are of type string.
 func NewWithStringComparator() *Tree {
-	return &Tree{Comparator: utils.StringComparator}
+	return &Tree{Comparator: utils.StringComparator, keyDecoder: utils.StringDecoder}
 }
 
 // Put inserts node into the tree.
@@ -98,6 +101,7 @@ func (tree *Tree) Put(key interface{}, value interface{}) {
 	}
 	tree.insertCase1(insertedNode)
 	tree.size++
+	tree.modifications++
 }
 
 // Get searches the node in the tree by key and returns its value or nil if key is not found in tree.
@@ -114,11 +118,15 @@ func (tree *Tree) Get(key interface{}) (value interface{}, found bool) {
 // Remove remove the node from the tree by key.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (tree *Tree) Remove(key interface{}) {
-	var child *Node
-	node := tree.lookup(key)
-	if node == nil {
-		return
+	if node := tree.lookup(key); node != nil {
+		tree.removeNode(node)
 	}
+}
+
+// removeNode removes the node from the tree.
+// If the node has two children, its in-order predecessor's key and value are moved into it and the predecessor's node is removed instead.
+func (tree *Tree) removeNode(node *Node) {
+	var child *Node
 	if node.Left != nil && node.Right != nil {
 		pred := node.Left.maximumNode()
 		node.Key = pred.Key
@@ -141,6 +149,7 @@ func (tree *Tree) Remove(key interface{}) {
 		}
 	}
 	tree.size--
+	tree.modifications++
 }
 
 // Empty returns true if tree does not contain any nodes
@@ -253,10 +262,30 @@ func (tree *Tree) Ceiling(key interface{}) (ceiling *Node, found bool) {
 	return nil, false
 }
 
+// BulkLoad replaces tree's elements with the given keys and their values (slices of the same length).
+// While keys are sorted in strictly ascending order, the balanced tree is built directly in linear time,
+// elements from the first key out of order on are inserted one by one (see Loader).
+// Panics if the comparator can not compare the keys.
+func (tree *Tree) BulkLoad(keys []interface{}, values []interface{}) {
+	loader := tree.Loader()
+	for i := range keys {
+		if err := loader.Add(keys[i], values[i]); err != nil {
+			panic(err)
+		}
+	}
+	loader.Load()
+}
+
 // Clear removes all nodes from the tree.
 func (tree *Tree) Clear() {
 	tree.Root = nil
 	tree.size = 0
+	tree.modifications++
+}
+
+// Clone returns a copy of the tree with the same structure and comparator (keys and values themselves are not copied).
+func (tree *Tree) Clone() *Tree {
+	return &Tree{Root: tree.Root.clone(nil), size: tree.size, Comparator: tree.Comparator, keyDecoder: tree.keyDecoder, valueDecoder: tree.valueDecoder}
 }
 
 // String returns a string representation of container
@@ -316,6 +345,17 @@ func (tree *Tree) lookup(key interface{}) *Node {
 	return nil
 }
 
+// clone copies the subtree rooted at the node, attaching it to the parent.
+func (node *Node) clone(parent *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	clone := &Node{Key: node.Key, Value: node.Value, color: node.color, Parent: parent}
+	clone.Left = node.Left.clone(clone)
+	clone.Right = node.Right.clone(clone)
+	return clone
+}
+
 func (node *Node) grandparent() *Node {
 	if node != nil && node.Parent != nil {
 		return node.Parent.Parent
@@ -0,0 +1,68 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redblacktree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the structural invariants of the tree and returns an error describing the first violation found, if any.
+// It checks that keys are in strictly ascending order, that parent pointers are consistent, that the root is black,
+// that red nodes have no red children, that all paths from a node to its leaves contain the same number of black nodes
+// and that the size of the tree matches the number of its nodes.
+func (tree *Tree) Validate() error {
+	if tree.Root != nil {
+		if tree.Root.Parent != nil {
+			return errors.New("redblacktree: root has a parent")
+		}
+		if tree.Root.color != black {
+			return errors.New("redblacktree: root is red")
+		}
+	}
+	var previous *Node
+	count := 0
+	if _, err := tree.validate(tree.Root, nil, &previous, &count); err != nil {
+		return err
+	}
+	if count != tree.size {
+		return fmt.Errorf("redblacktree: size is %d, but tree has %d nodes", tree.size, count)
+	}
+	return nil
+}
+
+// validate checks the subtree rooted at the node attached to the parent and returns its black height.
+// Nodes are visited in-order, previous is the last visited node and count the number of visited nodes.
+func (tree *Tree) validate(node *Node, parent *Node, previous **Node, count *int) (int, error) {
+	if node == nil {
+		return 1, nil
+	}
+	if node.Parent != parent {
+		return 0, fmt.Errorf("redblacktree: node %v has a wrong parent", node.Key)
+	}
+	if node.color == red && (nodeColor(node.Left) == red || nodeColor(node.Right) == red) {
+		return 0, fmt.Errorf("redblacktree: red node %v has a red child", node.Key)
+	}
+	left, err := tree.validate(node.Left, node, previous, count)
+	if err != nil {
+		return 0, err
+	}
+	if *previous != nil && tree.Comparator((*previous).Key, node.Key) >= 0 {
+		return 0, fmt.Errorf("redblacktree: keys %v and %v are out of order", (*previous).Key, node.Key)
+	}
+	*previous = node
+	*count++
+	right, err := tree.validate(node.Right, node, previous, count)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, fmt.Errorf("redblacktree: node %v has black heights %d on the left and %d on the right", node.Key, left, right)
+	}
+	if node.color == black {
+		left++
+	}
+	return left, nil
+}
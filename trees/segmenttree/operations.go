@@ -0,0 +1,96 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package segmenttree
+
+// Combiner combines the aggregates of two adjacent ranges of elements, the left range first.
+// It must be associative, i.e. combiner(combiner(a, b), c) == combiner(a, combiner(b, c)).
+type Combiner func(left, right interface{}) interface{}
+
+// Updater describes updates applied to whole ranges of elements at once (see Tree.Update).
+type Updater interface {
+	// Apply returns the aggregate of a range of size elements after the update was applied to each of its elements.
+	Apply(aggregate interface{}, update interface{}, size int) interface{}
+	// Compose returns the update equivalent to applying the first update and then the second one.
+	Compose(first interface{}, second interface{}) interface{}
+}
+
+// MaxInt and MinInt are the identities of IntMin and IntMax respectively
+const (
+	MaxInt = int(^uint(0) >> 1)
+	MinInt = -MaxInt - 1
+)
+
+// IntSum combines ints by their sum, its identity is 0
+func IntSum(left, right interface{}) interface{} {
+	return left.(int) + right.(int)
+}
+
+// IntMin combines ints by their minimum, its identity is MaxInt
+func IntMin(left, right interface{}) interface{} {
+	if right.(int) < left.(int) {
+		return right
+	}
+	return left
+}
+
+// IntMax combines ints by their maximum, its identity is MinInt
+func IntMax(left, right interface{}) interface{} {
+	if right.(int) > left.(int) {
+		return right
+	}
+	return left
+}
+
+// IntSumAdd adds an int to each element of a range, for trees combined by IntSum
+var IntSumAdd Updater = intSumAdd{}
+
+// IntSumAssign assigns an int to each element of a range, for trees combined by IntSum
+var IntSumAssign Updater = intSumAssign{}
+
+// IntMinMaxAdd adds an int to each element of a range, for trees combined by IntMin or IntMax
+var IntMinMaxAdd Updater = intMinMaxAdd{}
+
+// IntMinMaxAssign assigns an int to each element of a range, for trees combined by IntMin or IntMax
+var IntMinMaxAssign Updater = intMinMaxAssign{}
+
+type intSumAdd struct{}
+
+func (intSumAdd) Apply(aggregate interface{}, update interface{}, size int) interface{} {
+	return aggregate.(int) + update.(int)*size
+}
+
+func (intSumAdd) Compose(first interface{}, second interface{}) interface{} {
+	return first.(int) + second.(int)
+}
+
+type intSumAssign struct{}
+
+func (intSumAssign) Apply(aggregate interface{}, update interface{}, size int) interface{} {
+	return update.(int) * size
+}
+
+func (intSumAssign) Compose(first interface{}, second interface{}) interface{} {
+	return second
+}
+
+type intMinMaxAdd struct{}
+
+func (intMinMaxAdd) Apply(aggregate interface{}, update interface{}, size int) interface{} {
+	return aggregate.(int) + update.(int)
+}
+
+func (intMinMaxAdd) Compose(first interface{}, second interface{}) interface{} {
+	return first.(int) + second.(int)
+}
+
+type intMinMaxAssign struct{}
+
+func (intMinMaxAssign) Apply(aggregate interface{}, update interface{}, size int) interface{} {
+	return update
+}
+
+func (intMinMaxAssign) Compose(first interface{}, second interface{}) interface{} {
+	return second
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package segmenttree implements a segment tree with lazy propagation of range updates.
+//
+// A segment tree holds a sequence of elements and the aggregates of ranges of the sequence, combined by an associative
+// function with an identity element (e.g. sum and zero, minimum and the largest value). The aggregate of any range of
+// elements is queried in logarithmic time. Elements are updated one at a time or, if the tree has an Updater, a whole
+// range at once: an update is applied to the aggregate of a range and recorded in its node, and pushed down to the
+// children of the node only once a query or another update needs them (lazy propagation).
+//
+// Structure is not thread safe.
+//
+// Reference: https://en.wikipedia.org/wiki/Segment_tree
+package segmenttree
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"github.com/emirpasic/gods/utils"
+	"strings"
+)
+
+func assertTreeImplementation() {
+	var _ trees.Tree = (*Tree)(nil)
+}
+
+// Tree holds the elements and the aggregates of their ranges
+type Tree struct {
+	combiner   Combiner
+	identity   interface{}
+	updater    Updater       // nil if range updates are not supported
+	size       int           // Total number of elements in the tree
+	aggregates []interface{} // aggregates[node] is the aggregate of the range of the node, the root is node 1
+	updates    []interface{} // updates[node] is the update not yet applied to the children of the node
+	pending    []bool        // pending[node] is true if updates[node] holds an update
+	decoder    utils.Decoder // decodes elements from JSON, nil for Go's default JSON decoding (see SetDecoder)
+}
+
+// NewWith instantiates a tree holding the values, with their aggregates combined by the combiner.
+// Identity is the aggregate of an empty range, i.e. combiner(identity, x) == combiner(x, identity) == x.
+// The tree is built in linear time.
+func NewWith(combiner Combiner, identity interface{}, values ...interface{}) *Tree {
+	return NewWithUpdater(combiner, identity, nil, values...)
+}
+
+// NewWithUpdater instantiates a tree holding the values, with their aggregates combined by the combiner,
+// supporting range updates with the updater (see Update).
+func NewWithUpdater(combiner Combiner, identity interface{}, updater Updater, values ...interface{}) *Tree {
+	tree := &Tree{combiner: combiner, identity: identity, updater: updater}
+	tree.load(values)
+	return tree
+}
+
+// Get returns the element at the index.
+// Second return parameter is true if index is within bounds of the tree, otherwise false.
+func (tree *Tree) Get(index int) (interface{}, bool) {
+	if !tree.withinRange(index) {
+		return nil, false
+	}
+	node, from, to := 1, 0, tree.size
+	for to-from > 1 {
+		mid := tree.push(node, from, to)
+		if index < mid {
+			node, to = 2*node, mid
+		} else {
+			node, from = 2*node+1, mid
+		}
+	}
+	return tree.aggregates[node], true
+}
+
+// Set replaces the element at the index with the value.
+// Does not do anything if the index is out of bounds.
+func (tree *Tree) Set(index int, value interface{}) {
+	if tree.withinRange(index) {
+		tree.set(1, 0, tree.size, index, value)
+	}
+}
+
+// Query returns the aggregate of elements with indexes in [from, to), combined in order of their indexes.
+// Indexes are clamped to the bounds of the tree, the aggregate is the identity if the range is empty.
+func (tree *Tree) Query(from int, to int) interface{} {
+	from, to = tree.clamp(from, to)
+	if from >= to {
+		return tree.identity
+	}
+	return tree.query(1, 0, tree.size, from, to)
+}
+
+// Update applies the update to elements with indexes in [from, to) in logarithmic time.
+// Indexes are clamped to the bounds of the tree.
+// Tree must have an updater (see NewWithUpdater), otherwise method panics.
+func (tree *Tree) Update(from int, to int, update interface{}) {
+	if tree.updater == nil {
+		panic("segmenttree: range update of a tree without an updater")
+	}
+	from, to = tree.clamp(from, to)
+	if from < to {
+		tree.update(1, 0, tree.size, from, to, update)
+	}
+}
+
+// Empty returns true if tree does not contain any elements.
+func (tree *Tree) Empty() bool {
+	return tree.size == 0
+}
+
+// Size returns number of elements in the tree.
+func (tree *Tree) Size() int {
+	return tree.size
+}
+
+// Clear removes all elements from the tree.
+func (tree *Tree) Clear() {
+	tree.load(nil)
+}
+
+// Values returns all elements in order of their indexes, applying the pending updates.
+func (tree *Tree) Values() []interface{} {
+	values := make([]interface{}, 0, tree.size)
+	if tree.size > 0 {
+		tree.values(1, 0, tree.size, &values)
+	}
+	return values
+}
+
+// Clone returns a copy of the tree with the same combiner, identity and updater.
+// Elements and aggregates themselves are not copied.
+func (tree *Tree) Clone() *Tree {
+	clone := NewWithUpdater(tree.combiner, tree.identity, tree.updater, tree.Values()...)
+	clone.decoder = tree.decoder
+	return clone
+}
+
+// String returns a string representation of container
+func (tree *Tree) String() string {
+	str := "SegmentTree\n"
+	values := []string{}
+	for _, value := range tree.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// load replaces the elements of the tree with the values.
+func (tree *Tree) load(values []interface{}) {
+	tree.size = len(values)
+	tree.aggregates = make([]interface{}, 4*tree.size)
+	tree.updates = make([]interface{}, 4*tree.size)
+	tree.pending = make([]bool, 4*tree.size)
+	if tree.size > 0 {
+		tree.build(1, 0, tree.size, values)
+	}
+}
+
+// build computes the aggregates of the node covering [from, to) and of its descendants.
+func (tree *Tree) build(node int, from int, to int, values []interface{}) {
+	if to-from == 1 {
+		tree.aggregates[node] = values[from]
+		return
+	}
+	mid := from + (to-from)/2
+	tree.build(2*node, from, mid, values)
+	tree.build(2*node+1, mid, to, values)
+	tree.aggregates[node] = tree.combiner(tree.aggregates[2*node], tree.aggregates[2*node+1])
+}
+
+func (tree *Tree) set(node int, from int, to int, index int, value interface{}) {
+	if to-from == 1 {
+		tree.aggregates[node] = value
+		return
+	}
+	if mid := tree.push(node, from, to); index < mid {
+		tree.set(2*node, from, mid, index, value)
+	} else {
+		tree.set(2*node+1, mid, to, index, value)
+	}
+	tree.aggregates[node] = tree.combiner(tree.aggregates[2*node], tree.aggregates[2*node+1])
+}
+
+func (tree *Tree) query(node int, from int, to int, queryFrom int, queryTo int) interface{} {
+	if queryFrom <= from && to <= queryTo {
+		return tree.aggregates[node]
+	}
+	mid := tree.push(node, from, to)
+	if queryTo <= mid {
+		return tree.query(2*node, from, mid, queryFrom, queryTo)
+	}
+	if queryFrom >= mid {
+		return tree.query(2*node+1, mid, to, queryFrom, queryTo)
+	}
+	return tree.combiner(tree.query(2*node, from, mid, queryFrom, queryTo), tree.query(2*node+1, mid, to, queryFrom, queryTo))
+}
+
+func (tree *Tree) update(node int, from int, to int, updateFrom int, updateTo int, update interface{}) {
+	if updateFrom <= from && to <= updateTo {
+		tree.apply(node, from, to, update)
+		return
+	}
+	mid := tree.push(node, from, to)
+	if updateFrom < mid {
+		tree.update(2*node, from, mid, updateFrom, updateTo, update)
+	}
+	if updateTo > mid {
+		tree.update(2*node+1, mid, to, updateFrom, updateTo, update)
+	}
+	tree.aggregates[node] = tree.combiner(tree.aggregates[2*node], tree.aggregates[2*node+1])
+}
+
+func (tree *Tree) values(node int, from int, to int, values *[]interface{}) {
+	if to-from == 1 {
+		*values = append(*values, tree.aggregates[node])
+		return
+	}
+	mid := tree.push(node, from, to)
+	tree.values(2*node, from, mid, values)
+	tree.values(2*node+1, mid, to, values)
+}
+
+// apply applies the update to the aggregate of the node covering [from, to) and records it for the node's children.
+func (tree *Tree) apply(node int, from int, to int, update interface{}) {
+	tree.aggregates[node] = tree.updater.Apply(tree.aggregates[node], update, to-from)
+	if to-from == 1 {
+		return
+	}
+	if tree.pending[node] {
+		tree.updates[node] = tree.updater.Compose(tree.updates[node], update)
+	} else {
+		tree.updates[node] = update
+		tree.pending[node] = true
+	}
+}
+
+// push applies the pending update of the node covering [from, to) to its children and returns the middle of the range.
+func (tree *Tree) push(node int, from int, to int) int {
+	mid := from + (to-from)/2
+	if tree.pending[node] {
+		tree.apply(2*node, from, mid, tree.updates[node])
+		tree.apply(2*node+1, mid, to, tree.updates[node])
+		tree.updates[node] = nil
+		tree.pending[node] = false
+	}
+	return mid
+}
+
+// clamp clamps the range to the bounds of the tree
+func (tree *Tree) clamp(from int, to int) (int, int) {
+	if from < 0 {
+		from = 0
+	}
+	if to > tree.size {
+		to = tree.size
+	}
+	return from, to
+}
+
+// Check that the index is within bounds of the tree
+func (tree *Tree) withinRange(index int) bool {
+	return index >= 0 && index < tree.size
+}
@@ -0,0 +1,337 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package segmenttree
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"math/rand"
+	"testing"
+)
+
+func concat(left, right interface{}) interface{} {
+	return left.(string) + right.(string)
+}
+
+func TestSegmentTreeQuery(t *testing.T) {
+	tree := NewWith(IntSum, 0, 3, 1, 4, 1, 5, 9, 2, 6)
+	if actualValue, expectedValue := tree.Size(), 8; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := [][]int{
+		{0, 8, 31},
+		{0, 1, 3},
+		{2, 5, 10},
+		{7, 8, 6},
+		{3, 3, 0},
+		{5, 2, 0},
+		{-5, 2, 4},
+		{6, 100, 8},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := tree.Query(test[0], test[1]), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for Query(%v, %v)", actualValue, expectedValue, test[0], test[1])
+		}
+	}
+
+	tree = NewWith(concat, "", "a", "b", "c", "d", "e")
+	if actualValue, expectedValue := tree.Query(1, 4), "bcd"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Query(0, 5), "abcde"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree = NewWith(IntMin, MaxInt)
+	if actualValue, expectedValue := tree.Query(0, 1), MaxInt; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Empty(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSegmentTreeGetAndSet(t *testing.T) {
+	tree := NewWith(IntMax, MinInt, 3, 1, 4, 1, 5)
+	tree.Set(4, 0)
+	tree.Set(1, 7)
+	tree.Set(5, 100)  // out of bounds
+	tree.Set(-1, 100) // out of bounds
+
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[3 7 4 1 0]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Query(0, 5), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Query(2, 5), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, ok := tree.Get(1); actualValue != 7 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue, ok := tree.Get(5); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, expectedValue := tree.String(), "SegmentTree\n3, 7, 4, 1, 0"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.Clear()
+	if actualValue, expectedValue := tree.Size(), 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Query(0, 5), MinInt; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSegmentTreeUpdate(t *testing.T) {
+	tree := NewWithUpdater(IntSum, 0, IntSumAdd, 3, 1, 4, 1, 5, 9, 2, 6)
+	tree.Update(1, 5, 10)
+	tree.Update(3, 8, -1)
+	tree.Update(-3, 1, 2)
+	tree.Update(4, 2, 1000) // empty range
+
+	if actualValue, expectedValue := tree.Query(0, 8), 68; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Query(2, 4), 24; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[5 11 14 10 14 8 1 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree = NewWithUpdater(IntMin, MaxInt, IntMinMaxAssign, 3, 1, 4, 1, 5, 9, 2, 6)
+	tree.Update(0, 4, 7)
+	tree.Update(2, 6, 3)
+	tree.Set(2, 8)
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[7 7 8 3 3 3 2 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Query(0, 3), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic for a range update without an updater")
+		}
+	}()
+	NewWith(IntSum, 0, 1, 2).Update(0, 1, 1)
+}
+
+func TestSegmentTreeRandom(t *testing.T) {
+	tests := []struct {
+		name      string
+		combiner  Combiner
+		identity  int
+		updater   Updater
+		aggregate func(values []int) int
+		update    func(value int, update int) int
+	}{
+		{"sum add", IntSum, 0, IntSumAdd, sumOf, add},
+		{"sum assign", IntSum, 0, IntSumAssign, sumOf, assign},
+		{"min add", IntMin, MaxInt, IntMinMaxAdd, minOf, add},
+		{"min assign", IntMin, MaxInt, IntMinMaxAssign, minOf, assign},
+		{"max add", IntMax, MinInt, IntMinMaxAdd, maxOf, add},
+		{"max assign", IntMax, MinInt, IntMinMaxAssign, maxOf, assign},
+	}
+	random := rand.New(rand.NewSource(1))
+	for _, test := range tests {
+		for n := 1; n < 30; n++ {
+			values := make([]int, n)
+			elements := make([]interface{}, n)
+			for i := range values {
+				values[i] = random.Intn(100) - 50
+				elements[i] = values[i]
+			}
+			tree := NewWithUpdater(test.combiner, test.identity, test.updater, elements...)
+			for operation := 0; operation < 50; operation++ {
+				from, to := random.Intn(n), random.Intn(n+1)
+				if from > to {
+					from, to = to, from
+				}
+				switch random.Intn(3) {
+				case 0:
+					value := random.Intn(100) - 50
+					tree.Update(from, to, value)
+					for i := from; i < to; i++ {
+						values[i] = test.update(values[i], value)
+					}
+				case 1:
+					value := random.Intn(100) - 50
+					tree.Set(from, value)
+					values[from] = value
+				default:
+					expectedValue := test.identity
+					if from < to {
+						expectedValue = test.aggregate(values[from:to])
+					}
+					if actualValue := tree.Query(from, to); actualValue != expectedValue {
+						t.Fatalf("%v: Got %v expected %v for Query(%v, %v) of %v", test.name, actualValue, expectedValue, from, to, values)
+					}
+				}
+			}
+			if actualValue, expectedValue := fmt.Sprint(tree.Values()), fmt.Sprint(values); actualValue != expectedValue {
+				t.Fatalf("%v: Got %v expected %v", test.name, actualValue, expectedValue)
+			}
+		}
+	}
+}
+
+func sumOf(values []int) int {
+	sum := 0
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+
+func minOf(values []int) int {
+	min := values[0]
+	for _, value := range values {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
+
+func maxOf(values []int) int {
+	max := values[0]
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+	return max
+}
+
+func add(value int, update int) int {
+	return value + update
+}
+
+func assign(value int, update int) int {
+	return update
+}
+
+func TestSegmentTreeClone(t *testing.T) {
+	tree := NewWithUpdater(IntSum, 0, IntSumAdd, 1, 2, 3)
+	tree.Update(0, 3, 1)
+
+	clone := tree.Clone()
+	if actualValue := containers.Equal(clone, tree, nil); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	clone.Update(0, 1, 10)
+	if actualValue, expectedValue := tree.Query(0, 3), 9; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := clone.Query(0, 3), 19; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSegmentTreeSerialization(t *testing.T) {
+	tree := NewWithUpdater(IntSum, 0, IntSumAdd, 3, 1, 4)
+	tree.Update(0, 2, 1)
+
+	json, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(json), "[4,2,4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	decoded := NewWithUpdater(IntSum, 0, IntSumAdd)
+	err = decoded.FromJSONWith(json, utils.IntDecoder)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := decoded.Query(1, 3), 6; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = decoded.FromJSONWith([]byte(`[1,"x"]`), utils.IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped element")
+	}
+	if actualValue, expectedValue := decoded.Size(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	data, err := tree.MarshalBinaryWith(utils.IntCodec)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded = NewWith(IntMax, MinInt)
+	if err := decoded.UnmarshalBinaryWith(data, utils.IntCodec); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := decoded.Query(0, 3), 4; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(tree); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	decoded = NewWith(IntSum, 0)
+	if err := gob.NewDecoder(&buffer).Decode(decoded); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(decoded.Values()), "[4 2 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	err = new(Tree).UnmarshalJSON(json)
+	if actualValue, expectedValue := err, errNoCombiner; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	err = new(Tree).UnmarshalBinary(nil)
+	if actualValue, expectedValue := err, errNoCombiner; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func BenchmarkSegmentTreeQuery100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	values := make([]interface{}, size)
+	for i := range values {
+		values[i] = i
+	}
+	tree := NewWithUpdater(IntSum, 0, IntSumAdd, values...)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Query(n/2, n)
+		}
+	}
+}
+
+func BenchmarkSegmentTreeUpdate100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	values := make([]interface{}, size)
+	for i := range values {
+		values[i] = i
+	}
+	tree := NewWithUpdater(IntSum, 0, IntSumAdd, values...)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Update(n/2, n, 1)
+		}
+	}
+}
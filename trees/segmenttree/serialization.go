@@ -0,0 +1,180 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package segmenttree
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"github.com/emirpasic/gods/containers"
+	"github.com/emirpasic/gods/utils"
+	"io"
+)
+
+func assertSerializationImplementation() {
+	var _ containers.JSONSerializer = (*Tree)(nil)
+	var _ containers.JSONDeserializer = (*Tree)(nil)
+	var _ json.Marshaler = (*Tree)(nil)
+	var _ json.Unmarshaler = (*Tree)(nil)
+	var _ encoding.BinaryMarshaler = (*Tree)(nil)
+	var _ encoding.BinaryUnmarshaler = (*Tree)(nil)
+	var _ gob.GobEncoder = (*Tree)(nil)
+	var _ gob.GobDecoder = (*Tree)(nil)
+}
+
+// errNoCombiner is returned when elements are decoded into a tree that was not instantiated by a constructor,
+// e.g. the zero value of a field being unmarshalled, since the tree can not aggregate the elements without a combiner.
+var errNoCombiner = errors.New("segmenttree: can not decode into a tree without combiner, instantiate it with NewWith")
+
+// ToJSON outputs the JSON representation of tree's elements as an array in order of their indexes.
+// Only the elements are serialized, the combiner, identity and updater are kept by the tree they are loaded into.
+func (tree *Tree) ToJSON() ([]byte, error) {
+	return json.Marshal(tree.Values())
+}
+
+// FromJSON populates tree's elements from the input JSON representation.
+// Elements are decoded with the tree's decoder (see SetDecoder and FromJSONWith for other decoders).
+func (tree *Tree) FromJSON(data []byte) error {
+	return tree.FromJSONWith(data, tree.decoder)
+}
+
+// FromJSONWith populates tree's elements from the input JSON representation,
+// decoding elements with the given decoder (nil for Go's default JSON decoding).
+// Tree is not modified if the input can not be decoded.
+func (tree *Tree) FromJSONWith(data []byte, elementDecoder utils.Decoder) error {
+	return tree.ReadJSONWith(bytes.NewReader(data), elementDecoder)
+}
+
+// SetDecoder sets the decoder of elements used by FromJSON, ReadJSON and UnmarshalJSON (nil for Go's default JSON decoding),
+// e.g. for unmarshalling a field holding the tree with json.Unmarshal.
+func (tree *Tree) SetDecoder(elementDecoder utils.Decoder) {
+	tree.decoder = elementDecoder
+}
+
+// WriteJSON writes the JSON representation of tree's elements (see ToJSON) into the writer,
+// marshalling one element at a time.
+func (tree *Tree) WriteJSON(w io.Writer) error {
+	values := tree.Values()
+	i := -1
+	return utils.WriteJSONValues(w, func() (interface{}, bool) {
+		if i++; i == len(values) {
+			return nil, false
+		}
+		return values[i], true
+	})
+}
+
+// ReadJSON populates tree's elements from the JSON representation read from the reader (see FromJSON).
+func (tree *Tree) ReadJSON(r io.Reader) error {
+	return tree.ReadJSONWith(r, tree.decoder)
+}
+
+// ReadJSONWith populates tree's elements from the JSON representation read from the reader,
+// decoding elements with the given decoder (nil for Go's default JSON decoding).
+// Tree is not modified if the input can not be decoded.
+// Elements are collected before the tree is built, since the layout of the tree depends on their number.
+func (tree *Tree) ReadJSONWith(r io.Reader, elementDecoder utils.Decoder) error {
+	if tree.combiner == nil {
+		return errNoCombiner
+	}
+	values := []interface{}{}
+	if err := utils.ReadJSONValues(r, elementDecoder, appendValue(&values)); err != nil {
+		return err
+	}
+	tree.load(values)
+	return nil
+}
+
+// MarshalJSON outputs the JSON representation of tree's elements (implements json.Marshaler).
+func (tree *Tree) MarshalJSON() ([]byte, error) {
+	return tree.ToJSON()
+}
+
+// UnmarshalJSON populates tree's elements from the input JSON representation (implements json.Unmarshaler).
+func (tree *Tree) UnmarshalJSON(data []byte) error {
+	return tree.FromJSON(data)
+}
+
+// MarshalBinary outputs the binary representation of tree's elements encoded with gob (implements encoding.BinaryMarshaler).
+func (tree *Tree) MarshalBinary() ([]byte, error) {
+	return tree.MarshalBinaryWith(nil)
+}
+
+// MarshalBinaryWith outputs the binary representation of tree's elements,
+// encoding each element with the given codec (nil for gob).
+func (tree *Tree) MarshalBinaryWith(codec utils.Codec) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	if err := tree.WriteBinaryWith(buffer, codec); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalBinary populates tree's elements from the input binary representation (implements encoding.BinaryUnmarshaler).
+func (tree *Tree) UnmarshalBinary(data []byte) error {
+	return tree.UnmarshalBinaryWith(data, nil)
+}
+
+// UnmarshalBinaryWith populates tree's elements from the input binary representation,
+// decoding each element with the given codec, which must be the one the elements were encoded with.
+// Tree is not modified if the input can not be decoded.
+func (tree *Tree) UnmarshalBinaryWith(data []byte, codec utils.Codec) error {
+	return tree.ReadBinaryWith(bytes.NewReader(data), codec)
+}
+
+// WriteBinary writes the binary representation of tree's elements (see MarshalBinary) into the writer.
+func (tree *Tree) WriteBinary(w io.Writer) error {
+	return tree.WriteBinaryWith(w, nil)
+}
+
+// WriteBinaryWith writes the binary representation of tree's elements (see MarshalBinaryWith) into the writer,
+// encoding elements in small chunks.
+func (tree *Tree) WriteBinaryWith(w io.Writer, codec utils.Codec) error {
+	values := tree.Values()
+	i := -1
+	return utils.WriteBinaryValues(w, len(values), func() interface{} {
+		i++
+		return values[i]
+	}, codec)
+}
+
+// ReadBinary populates tree's elements from the binary representation read from the reader (see UnmarshalBinary).
+func (tree *Tree) ReadBinary(r io.Reader) error {
+	return tree.ReadBinaryWith(r, nil)
+}
+
+// ReadBinaryWith populates tree's elements from the binary representation read from the reader (see UnmarshalBinaryWith).
+// Elements are collected before the tree is built, since the layout of the tree depends on their number.
+func (tree *Tree) ReadBinaryWith(r io.Reader, codec utils.Codec) error {
+	if tree.combiner == nil {
+		return errNoCombiner
+	}
+	values := []interface{}{}
+	if err := utils.ReadBinaryValues(r, codec, appendValue(&values)); err != nil {
+		return err
+	}
+	tree.load(values)
+	return nil
+}
+
+// GobEncode outputs the binary representation of tree's elements (implements gob.GobEncoder).
+func (tree *Tree) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
+}
+
+// GobDecode populates tree's elements from the input binary representation (implements gob.GobDecoder).
+func (tree *Tree) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
+}
+
+// appendValue returns a function appending the decoded values to the values.
+func appendValue(values *[]interface{}) func(value interface{}) error {
+	return func(value interface{}) error {
+		*values = append(*values, value)
+		return nil
+	}
+}
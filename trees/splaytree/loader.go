@@ -0,0 +1,93 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package splaytree
+
+import "github.com/emirpasic/gods/utils"
+
+// Loader replaces the elements of a tree with elements added one at a time, e.g. while they are decoded,
+// leaving the tree unchanged until Load is called.
+//
+// While keys are added in strictly ascending order, their nodes are only linked in a list, from which the balanced
+// tree is built in linear time. From the first key that is out of order on, elements are inserted one by one.
+type Loader struct {
+	tree   *Tree       // tree whose elements are replaced
+	loaded *Tree       // tree of the added elements, once a key was out of order
+	first  *Node       // first node of the list, linked to the next ones by their right children
+	last   *Node       // last node of the list
+	size   int         // number of nodes in the list
+	key    interface{} // key of the last added element
+	sorted bool        // true while keys are added in strictly ascending order
+}
+
+// Loader returns a loader replacing tree's elements (see BulkLoad and ReadJSON).
+func (tree *Tree) Loader() *Loader {
+	return &Loader{tree: tree, loaded: &Tree{Comparator: tree.Comparator}, sorted: true}
+}
+
+// Add adds the element to the elements being loaded.
+// Returns an error if the comparator can not compare the key with the key of the previously added element.
+func (loader *Loader) Add(key interface{}, value interface{}) error {
+	previous := key
+	if loader.last != nil || !loader.sorted {
+		previous = loader.key
+	}
+	compare, err := utils.CompareKeys(loader.loaded.Comparator, previous, key)
+	if err != nil {
+		return err
+	}
+	loader.key = key
+	if loader.sorted && (loader.last == nil || compare < 0) {
+		node := &Node{Key: key, Value: value}
+		if loader.last == nil {
+			loader.first = node
+		} else {
+			loader.last.Right = node
+		}
+		loader.last = node
+		loader.size++
+		return nil
+	}
+	if loader.sorted {
+		loader.build()
+	}
+	loader.loaded.Put(key, value)
+	return nil
+}
+
+// Load replaces tree's elements with the added elements.
+func (loader *Loader) Load() {
+	if loader.sorted {
+		loader.build()
+	}
+	loader.tree.Root = loader.loaded.Root
+	loader.tree.size = loader.loaded.size
+	loader.tree.modifications++
+}
+
+// build builds the balanced tree from the list.
+func (loader *Loader) build() {
+	loader.loaded.Root = loader.subtree(loader.size)
+	loader.loaded.size = loader.size
+	loader.first, loader.last, loader.size = nil, nil, 0
+	loader.sorted = false
+}
+
+// subtree builds the balanced subtree of the next size nodes of the list.
+func (loader *Loader) subtree(size int) *Node {
+	if size == 0 {
+		return nil
+	}
+	left := loader.subtree(size / 2)
+	node := loader.first
+	loader.first = node.Right
+	node.Left, node.Right = left, loader.subtree(size-size/2-1)
+	if node.Left != nil {
+		node.Left.Parent = node
+	}
+	if node.Right != nil {
+		node.Right.Parent = node
+	}
+	return node
+}
@@ -0,0 +1,437 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package splaytree implements a splay tree.
+//
+// A splay tree is a self-adjusting binary search tree which moves every accessed node to the root,
+// so that recently accessed keys are quick to access again. Operations take O(log n) amortized time
+// and much less on skewed access patterns, where a few keys are accessed most of the time.
+//
+// Unlike other trees, lookups (Get, Floor, Ceiling, Left and Right) restructure the tree.
+// Restructuring does not change the order of the keys, so it does not invalidate iterators.
+//
+// Structure is not thread safe, not even for concurrent lookups.
+//
+// References: https://en.wikipedia.org/wiki/Splay_tree
+package splaytree
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"github.com/emirpasic/gods/utils"
+)
+
+func assertTreeImplementation() {
+	var _ trees.Tree = (*Tree)(nil)
+}
+
+// Tree holds elements of the splay tree
+type Tree struct {
+	Root          *Node
+	size          int
+	Comparator    utils.Comparator
+	keyDecoder    utils.Decoder // decodes keys from JSON, nil for Go's default JSON decoding (see SetDecoders)
+	valueDecoder  utils.Decoder // decodes values from JSON, nil for Go's default JSON decoding
+	modifications int           // number of structural modifications, used by iterators to fail fast
+}
+
+// Node is a single element within the tree
+type Node struct {
+	Key    interface{}
+	Value  interface{}
+	Left   *Node
+	Right  *Node
+	Parent *Node
+}
+
+// NewWith instantiates a splay tree with the custom comparator.
+func NewWith(comparator utils.Comparator) *Tree {
+	return &Tree{Comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a splay tree with the IntComparator, i.e. keys are of type int.
+func NewWithIntComparator() *Tree {
+	return &Tree{Comparator: utils.IntComparator, keyDecoder: utils.IntDecoder}
+}
+
+// NewWithStringComparator instantiates a splay tree with the StringComparator, i.e. keys are of type string.
+func NewWithStringComparator() *Tree {
+	return &Tree{Comparator: utils.StringComparator, keyDecoder: utils.StringDecoder}
+}
+
+// Put inserts node into the tree and splays it to the root.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Put(key interface{}, value interface{}) {
+	if tree.Root == nil {
+		tree.Root = &Node{Key: key, Value: value}
+		tree.size++
+		tree.modifications++
+		return
+	}
+	node := tree.Root
+	for {
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare == 0:
+			node.Key = key
+			node.Value = value
+			tree.splay(node)
+			return
+		case compare < 0:
+			if node.Left == nil {
+				node.Left = &Node{Key: key, Value: value, Parent: node}
+				tree.splay(node.Left)
+				tree.size++
+				tree.modifications++
+				return
+			}
+			node = node.Left
+		case compare > 0:
+			if node.Right == nil {
+				node.Right = &Node{Key: key, Value: value, Parent: node}
+				tree.splay(node.Right)
+				tree.size++
+				tree.modifications++
+				return
+			}
+			node = node.Right
+		}
+	}
+}
+
+// Get searches the node in the tree by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+// The found node (or the last node visited by the search) is splayed to the root.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Get(key interface{}) (value interface{}, found bool) {
+	node := tree.lookup(key)
+	if node != nil {
+		return node.Value, true
+	}
+	return nil, false
+}
+
+// Remove remove the node from the tree by key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Remove(key interface{}) {
+	if node := tree.lookup(key); node != nil {
+		tree.removeNode(node)
+	}
+}
+
+// removeNode splays the node to the root and replaces it with the join of its subtrees,
+// which is the left subtree with its maximum splayed to the root and the right subtree attached to it.
+func (tree *Tree) removeNode(node *Node) {
+	tree.splay(node)
+	left, right := node.Left, node.Right
+	if left == nil {
+		tree.Root = right
+		if right != nil {
+			right.Parent = nil
+		}
+	} else {
+		left.Parent = nil
+		tree.Root = left
+		maximum := left.maximumNode()
+		tree.splay(maximum)
+		maximum.Right = right
+		if right != nil {
+			right.Parent = maximum
+		}
+	}
+	node.Left, node.Right = nil, nil
+	tree.size--
+	tree.modifications++
+}
+
+// Empty returns true if tree does not contain any nodes
+func (tree *Tree) Empty() bool {
+	return tree.size == 0
+}
+
+// Size returns number of nodes in the tree.
+func (tree *Tree) Size() int {
+	return tree.size
+}
+
+// Keys returns all keys in-order
+func (tree *Tree) Keys() []interface{} {
+	keys := make([]interface{}, tree.size)
+	it := tree.Iterator()
+	for i := 0; it.Next(); i++ {
+		keys[i] = it.Key()
+	}
+	return keys
+}
+
+// Values returns all values in-order based on the key.
+func (tree *Tree) Values() []interface{} {
+	values := make([]interface{}, tree.size)
+	it := tree.Iterator()
+	for i := 0; it.Next(); i++ {
+		values[i] = it.Value()
+	}
+	return values
+}
+
+// Left returns the left-most (min) node or nil if tree is empty.
+// The node is splayed to the root.
+func (tree *Tree) Left() *Node {
+	node := tree.Root.minimumNode()
+	if node != nil {
+		tree.splay(node)
+	}
+	return node
+}
+
+// Right returns the right-most (max) node or nil if tree is empty.
+// The node is splayed to the root.
+func (tree *Tree) Right() *Node {
+	node := tree.Root.maximumNode()
+	if node != nil {
+		tree.splay(node)
+	}
+	return node
+}
+
+// Floor Finds floor node of the input key, return the floor node or nil if no floor is found.
+// Second return parameter is true if floor was found, otherwise false.
+//
+// Floor node is defined as the largest node that is smaller than or equal to the given node.
+// A floor node may not be found, either because the tree is empty, or because
+// all nodes in the tree is larger than the given node.
+//
+// The floor node (or the last node visited by the search) is splayed to the root.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Floor(key interface{}) (floor *Node, found bool) {
+	var last *Node
+	node := tree.Root
+	for node != nil {
+		last = node
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare == 0:
+			tree.splay(node)
+			return node, true
+		case compare < 0:
+			node = node.Left
+		case compare > 0:
+			floor, found = node, true
+			node = node.Right
+		}
+	}
+	if found {
+		tree.splay(floor)
+		return floor, true
+	}
+	if last != nil {
+		tree.splay(last)
+	}
+	return nil, false
+}
+
+// Ceiling finds ceiling node of the input key, return the ceiling node or nil if no ceiling is found.
+// Second return parameter is true if ceiling was found, otherwise false.
+//
+// Ceiling node is defined as the smallest node that is larger than or equal to the given node.
+// A ceiling node may not be found, either because the tree is empty, or because
+// all nodes in the tree is smaller than the given node.
+//
+// The ceiling node (or the last node visited by the search) is splayed to the root.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Ceiling(key interface{}) (ceiling *Node, found bool) {
+	var last *Node
+	node := tree.Root
+	for node != nil {
+		last = node
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare == 0:
+			tree.splay(node)
+			return node, true
+		case compare < 0:
+			ceiling, found = node, true
+			node = node.Left
+		case compare > 0:
+			node = node.Right
+		}
+	}
+	if found {
+		tree.splay(ceiling)
+		return ceiling, true
+	}
+	if last != nil {
+		tree.splay(last)
+	}
+	return nil, false
+}
+
+// BulkLoad replaces tree's elements with the given keys and their values (slices of the same length).
+// While keys are sorted in strictly ascending order, the balanced tree is built directly in linear time,
+// elements from the first key out of order on are inserted one by one (see Loader).
+// Panics if the comparator can not compare the keys.
+func (tree *Tree) BulkLoad(keys []interface{}, values []interface{}) {
+	loader := tree.Loader()
+	for i := range keys {
+		if err := loader.Add(keys[i], values[i]); err != nil {
+			panic(err)
+		}
+	}
+	loader.Load()
+}
+
+// Clear removes all nodes from the tree.
+func (tree *Tree) Clear() {
+	tree.Root = nil
+	tree.size = 0
+	tree.modifications++
+}
+
+// Clone returns a copy of the tree with the same structure and comparator (keys and values themselves are not copied).
+func (tree *Tree) Clone() *Tree {
+	return &Tree{Root: tree.Root.clone(nil), size: tree.size, Comparator: tree.Comparator, keyDecoder: tree.keyDecoder, valueDecoder: tree.valueDecoder}
+}
+
+// String returns a string representation of container
+func (tree *Tree) String() string {
+	str := "SplayTree\n"
+	if !tree.Empty() {
+		output(tree.Root, "", true, &str)
+	}
+	return str
+}
+
+func (node *Node) String() string {
+	return fmt.Sprintf("%v", node.Key)
+}
+
+func output(node *Node, prefix string, isTail bool, str *string) {
+	if node.Right != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "│   "
+		} else {
+			newPrefix += "    "
+		}
+		output(node.Right, newPrefix, false, str)
+	}
+	*str += prefix
+	if isTail {
+		*str += "└── "
+	} else {
+		*str += "┌── "
+	}
+	*str += node.String() + "\n"
+	if node.Left != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "    "
+		} else {
+			newPrefix += "│   "
+		}
+		output(node.Left, newPrefix, true, str)
+	}
+}
+
+// lookup searches the node by key and splays it (or the last node visited by the search) to the root.
+func (tree *Tree) lookup(key interface{}) *Node {
+	var last *Node
+	node := tree.Root
+	for node != nil {
+		last = node
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare == 0:
+			tree.splay(node)
+			return node
+		case compare < 0:
+			node = node.Left
+		case compare > 0:
+			node = node.Right
+		}
+	}
+	if last != nil {
+		tree.splay(last)
+	}
+	return nil
+}
+
+// splay moves the node to the root by zig-zig and zig-zag steps, finishing with a single rotation (zig) if needed.
+func (tree *Tree) splay(node *Node) {
+	for node.Parent != nil {
+		parent := node.Parent
+		grandparent := parent.Parent
+		switch {
+		case grandparent == nil:
+			tree.rotate(node)
+		case (node == parent.Left) == (parent == grandparent.Left):
+			tree.rotate(parent)
+			tree.rotate(node)
+		default:
+			tree.rotate(node)
+			tree.rotate(node)
+		}
+	}
+}
+
+// rotate moves the node above its parent, keeping the order of the keys.
+func (tree *Tree) rotate(node *Node) {
+	parent := node.Parent
+	if node == parent.Left {
+		parent.Left = node.Right
+		if node.Right != nil {
+			node.Right.Parent = parent
+		}
+		node.Right = parent
+	} else {
+		parent.Right = node.Left
+		if node.Left != nil {
+			node.Left.Parent = parent
+		}
+		node.Left = parent
+	}
+	node.Parent = parent.Parent
+	parent.Parent = node
+	switch {
+	case node.Parent == nil:
+		tree.Root = node
+	case node.Parent.Left == parent:
+		node.Parent.Left = node
+	default:
+		node.Parent.Right = node
+	}
+}
+
+// clone copies the subtree rooted at the node, attaching it to the parent.
+func (node *Node) clone(parent *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	clone := &Node{Key: node.Key, Value: node.Value, Parent: parent}
+	clone.Left = node.Left.clone(clone)
+	clone.Right = node.Right.clone(clone)
+	return clone
+}
+
+func (node *Node) minimumNode() *Node {
+	if node == nil {
+		return nil
+	}
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node
+}
+
+func (node *Node) maximumNode() *Node {
+	if node == nil {
+		return nil
+	}
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package splaytree
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the structural invariants of the tree and returns an error describing the first violation found, if any.
+// It checks that keys are in strictly ascending order, that parent pointers are consistent
+// and that the size of the tree matches the number of its nodes.
+// Splay trees are not balanced, so the shape of the tree is not checked.
+func (tree *Tree) Validate() error {
+	if tree.Root != nil && tree.Root.Parent != nil {
+		return errors.New("splaytree: root has a parent")
+	}
+	var previous *Node
+	count := 0
+	if err := tree.validate(tree.Root, nil, &previous, &count); err != nil {
+		return err
+	}
+	if count != tree.size {
+		return fmt.Errorf("splaytree: size is %d, but tree has %d nodes", tree.size, count)
+	}
+	return nil
+}
+
+// validate checks the subtree rooted at the node attached to the parent.
+// Nodes are visited in-order, previous is the last visited node and count the number of visited nodes.
+func (tree *Tree) validate(node *Node, parent *Node, previous **Node, count *int) error {
+	if node == nil {
+		return nil
+	}
+	if node.Parent != parent {
+		return fmt.Errorf("splaytree: node %v has a wrong parent", node.Key)
+	}
+	if err := tree.validate(node.Left, node, previous, count); err != nil {
+		return err
+	}
+	if *previous != nil && tree.Comparator((*previous).Key, node.Key) >= 0 {
+		return fmt.Errorf("splaytree: keys %v and %v are out of order", (*previous).Key, node.Key)
+	}
+	*previous = node
+	*count++
+	return tree.validate(node.Right, node, previous, count)
+}
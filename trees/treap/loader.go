@@ -0,0 +1,86 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package treap
+
+import (
+	"github.com/emirpasic/gods/utils"
+	"math/rand"
+)
+
+// Loader replaces the elements of a tree with elements added one at a time, e.g. while they are decoded,
+// leaving the tree unchanged until Load is called.
+//
+// While keys are added in strictly ascending order, each node is attached to the right spine of the treap of the
+// previous nodes, which builds the treap in linear time. From the first key that is out of order on,
+// elements are inserted one by one.
+type Loader struct {
+	tree   *Tree       // tree whose elements are replaced
+	loaded *Tree       // tree of the added elements, once a key was out of order
+	spine  []*Node     // nodes on the path from the root to the last node
+	key    interface{} // key of the last added element
+	sorted bool        // true while keys are added in strictly ascending order
+}
+
+// Loader returns a loader replacing tree's elements (see BulkLoad and ReadJSON).
+func (tree *Tree) Loader() *Loader {
+	return &Loader{tree: tree, loaded: &Tree{Comparator: tree.Comparator}, sorted: true}
+}
+
+// Add adds the element to the elements being loaded.
+// Returns an error if the comparator can not compare the key with the key of the previously added element.
+func (loader *Loader) Add(key interface{}, value interface{}) error {
+	previous := key
+	if len(loader.spine) > 0 || !loader.sorted {
+		previous = loader.key
+	}
+	compare, err := utils.CompareKeys(loader.loaded.Comparator, previous, key)
+	if err != nil {
+		return err
+	}
+	loader.key = key
+	if loader.sorted && (len(loader.spine) == 0 || compare < 0) {
+		node := &Node{Key: key, Value: value, priority: rand.Uint32(), size: 1}
+		var last *Node
+		for len(loader.spine) > 0 && loader.spine[len(loader.spine)-1].priority < node.priority {
+			last = loader.spine[len(loader.spine)-1]
+			loader.spine = loader.spine[:len(loader.spine)-1]
+			last.resize()
+		}
+		node.Left = last
+		attach(node, last)
+		if len(loader.spine) > 0 {
+			loader.spine[len(loader.spine)-1].Right = node
+			node.Parent = loader.spine[len(loader.spine)-1]
+		}
+		loader.spine = append(loader.spine, node)
+		return nil
+	}
+	if loader.sorted {
+		loader.build()
+	}
+	loader.loaded.Put(key, value)
+	return nil
+}
+
+// Load replaces tree's elements with the added elements.
+func (loader *Loader) Load() {
+	if loader.sorted {
+		loader.build()
+	}
+	loader.tree.Root = loader.loaded.Root
+	loader.tree.modifications++
+}
+
+// build completes the treap of the nodes on the spine.
+func (loader *Loader) build() {
+	for i := len(loader.spine) - 1; i >= 0; i-- {
+		loader.spine[i].resize()
+	}
+	if len(loader.spine) > 0 {
+		loader.loaded.Root = loader.spine[0]
+	}
+	loader.spine = nil
+	loader.sorted = false
+}
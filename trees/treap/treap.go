@@ -0,0 +1,487 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package treap implements a treap.
+//
+// A treap is a binary search tree by keys and a heap by random priorities assigned to its nodes,
+// which keeps it balanced with high probability, so that operations take O(log n) expected time.
+// Treaps can be split by a key and joined in O(log n) expected time, see Split and Join.
+//
+// Structure is not thread safe.
+//
+// References: https://en.wikipedia.org/wiki/Treap
+package treap
+
+import (
+	"fmt"
+	"github.com/emirpasic/gods/trees"
+	"github.com/emirpasic/gods/utils"
+	"math/rand"
+)
+
+func assertTreeImplementation() {
+	var _ trees.Tree = (*Tree)(nil)
+}
+
+// Tree holds elements of the treap
+type Tree struct {
+	Root          *Node
+	Comparator    utils.Comparator
+	keyDecoder    utils.Decoder // decodes keys from JSON, nil for Go's default JSON decoding (see SetDecoders)
+	valueDecoder  utils.Decoder // decodes values from JSON, nil for Go's default JSON decoding
+	modifications int           // number of structural modifications, used by iterators to fail fast
+}
+
+// Node is a single element within the tree
+type Node struct {
+	Key      interface{}
+	Value    interface{}
+	priority uint32 // not smaller than the priorities of the children
+	size     int    // number of nodes in the subtree rooted at the node
+	Left     *Node
+	Right    *Node
+	Parent   *Node
+}
+
+// NewWith instantiates a treap with the custom comparator.
+func NewWith(comparator utils.Comparator) *Tree {
+	return &Tree{Comparator: comparator}
+}
+
+// NewWithIntComparator instantiates a treap with the IntComparator, i.e. keys are of type int.
+func NewWithIntComparator() *Tree {
+	return &Tree{Comparator: utils.IntComparator, keyDecoder: utils.IntDecoder}
+}
+
+// NewWithStringComparator instantiates a treap with the StringComparator, i.e. keys are of type string.
+func NewWithStringComparator() *Tree {
+	return &Tree{Comparator: utils.StringComparator, keyDecoder: utils.StringDecoder}
+}
+
+// Put inserts node into the tree.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Put(key interface{}, value interface{}) {
+	inserted := &Node{Key: key, Value: value, priority: rand.Uint32(), size: 1}
+	if tree.Root == nil {
+		tree.Root = inserted
+		tree.modifications++
+		return
+	}
+	node := tree.Root
+	for inserted.Parent == nil {
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare == 0:
+			node.Key = key
+			node.Value = value
+			return
+		case compare < 0:
+			if node.Left == nil {
+				node.Left = inserted
+				inserted.Parent = node
+			}
+			node = node.Left
+		case compare > 0:
+			if node.Right == nil {
+				node.Right = inserted
+				inserted.Parent = node
+			}
+			node = node.Right
+		}
+	}
+	for node := inserted.Parent; node != nil; node = node.Parent {
+		node.size++
+	}
+	for inserted.Parent != nil && inserted.priority > inserted.Parent.priority {
+		tree.rotate(inserted)
+	}
+	tree.modifications++
+}
+
+// Get searches the node in the tree by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Get(key interface{}) (value interface{}, found bool) {
+	node := tree.lookup(key)
+	if node != nil {
+		return node.Value, true
+	}
+	return nil, false
+}
+
+// Remove remove the node from the tree by key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Remove(key interface{}) {
+	if node := tree.lookup(key); node != nil {
+		tree.removeNode(node)
+	}
+}
+
+// removeNode rotates the node down below its child of higher priority until it has at most one child and replaces it with that child.
+func (tree *Tree) removeNode(node *Node) {
+	for node.Left != nil && node.Right != nil {
+		if node.Left.priority > node.Right.priority {
+			tree.rotate(node.Left)
+		} else {
+			tree.rotate(node.Right)
+		}
+	}
+	child := node.Left
+	if child == nil {
+		child = node.Right
+	}
+	switch {
+	case node.Parent == nil:
+		tree.Root = child
+	case node == node.Parent.Left:
+		node.Parent.Left = child
+	default:
+		node.Parent.Right = child
+	}
+	if child != nil {
+		child.Parent = node.Parent
+	}
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		parent.size--
+	}
+	node.Left, node.Right, node.size = nil, nil, 1
+	tree.modifications++
+}
+
+// Empty returns true if tree does not contain any nodes
+func (tree *Tree) Empty() bool {
+	return tree.Root == nil
+}
+
+// Size returns number of nodes in the tree.
+func (tree *Tree) Size() int {
+	return size(tree.Root)
+}
+
+// Keys returns all keys in-order
+func (tree *Tree) Keys() []interface{} {
+	keys := make([]interface{}, tree.Size())
+	it := tree.Iterator()
+	for i := 0; it.Next(); i++ {
+		keys[i] = it.Key()
+	}
+	return keys
+}
+
+// Values returns all values in-order based on the key.
+func (tree *Tree) Values() []interface{} {
+	values := make([]interface{}, tree.Size())
+	it := tree.Iterator()
+	for i := 0; it.Next(); i++ {
+		values[i] = it.Value()
+	}
+	return values
+}
+
+// Left returns the left-most (min) node or nil if tree is empty.
+func (tree *Tree) Left() *Node {
+	return tree.Root.minimumNode()
+}
+
+// Right returns the right-most (max) node or nil if tree is empty.
+func (tree *Tree) Right() *Node {
+	return tree.Root.maximumNode()
+}
+
+// Floor Finds floor node of the input key, return the floor node or nil if no floor is found.
+// Second return parameter is true if floor was found, otherwise false.
+//
+// Floor node is defined as the largest node that is smaller than or equal to the given node.
+// A floor node may not be found, either because the tree is empty, or because
+// all nodes in the tree is larger than the given node.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Floor(key interface{}) (floor *Node, found bool) {
+	found = false
+	node := tree.Root
+	for node != nil {
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare == 0:
+			return node, true
+		case compare < 0:
+			node = node.Left
+		case compare > 0:
+			floor, found = node, true
+			node = node.Right
+		}
+	}
+	if found {
+		return floor, true
+	}
+	return nil, false
+}
+
+// Ceiling finds ceiling node of the input key, return the ceiling node or nil if no ceiling is found.
+// Second return parameter is true if ceiling was found, otherwise false.
+//
+// Ceiling node is defined as the smallest node that is larger than or equal to the given node.
+// A ceiling node may not be found, either because the tree is empty, or because
+// all nodes in the tree is smaller than the given node.
+//
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Ceiling(key interface{}) (ceiling *Node, found bool) {
+	found = false
+	node := tree.Root
+	for node != nil {
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare == 0:
+			return node, true
+		case compare < 0:
+			ceiling, found = node, true
+			node = node.Left
+		case compare > 0:
+			node = node.Right
+		}
+	}
+	if found {
+		return ceiling, true
+	}
+	return nil, false
+}
+
+// Split moves the nodes with keys smaller than the key into the left tree and the remaining ones into the right tree,
+// leaving the tree empty. Both trees have the comparator of the tree.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Tree) Split(key interface{}) (left *Tree, right *Tree) {
+	left, right = &Tree{Comparator: tree.Comparator}, &Tree{Comparator: tree.Comparator}
+	left.Root, right.Root = tree.split(tree.Root, key)
+	detach(left.Root)
+	detach(right.Root)
+	tree.Clear()
+	return left, right
+}
+
+// Join moves the nodes of both trees into a new tree with the comparator of the left tree, leaving them empty.
+// If all keys of the left tree are smaller than the keys of the right tree, the trees are joined in O(log n) expected time,
+// otherwise the elements of the right tree are put into the left tree one by one, replacing values of equal keys.
+func Join(left *Tree, right *Tree) *Tree {
+	tree := &Tree{Comparator: left.Comparator}
+	if left.Empty() || right.Empty() || tree.Comparator(left.Right().Key, right.Left().Key) < 0 {
+		tree.Root = merge(left.Root, right.Root)
+		detach(tree.Root)
+	} else {
+		tree.Root = left.Root
+		it := right.Iterator()
+		for it.Next() {
+			tree.Put(it.Key(), it.Value())
+		}
+	}
+	left.Clear()
+	right.Clear()
+	return tree
+}
+
+// BulkLoad replaces tree's elements with the given keys and their values (slices of the same length).
+// While keys are sorted in strictly ascending order, the treap is built directly in linear time,
+// elements from the first key out of order on are inserted one by one (see Loader).
+// Panics if the comparator can not compare the keys.
+func (tree *Tree) BulkLoad(keys []interface{}, values []interface{}) {
+	loader := tree.Loader()
+	for i := range keys {
+		if err := loader.Add(keys[i], values[i]); err != nil {
+			panic(err)
+		}
+	}
+	loader.Load()
+}
+
+// Clear removes all nodes from the tree.
+func (tree *Tree) Clear() {
+	tree.Root = nil
+	tree.modifications++
+}
+
+// Clone returns a copy of the tree with the same structure and comparator (keys and values themselves are not copied).
+func (tree *Tree) Clone() *Tree {
+	return &Tree{Root: tree.Root.clone(nil), Comparator: tree.Comparator, keyDecoder: tree.keyDecoder, valueDecoder: tree.valueDecoder}
+}
+
+// String returns a string representation of container
+func (tree *Tree) String() string {
+	str := "Treap\n"
+	if !tree.Empty() {
+		output(tree.Root, "", true, &str)
+	}
+	return str
+}
+
+func (node *Node) String() string {
+	return fmt.Sprintf("%v", node.Key)
+}
+
+func output(node *Node, prefix string, isTail bool, str *string) {
+	if node.Right != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "│   "
+		} else {
+			newPrefix += "    "
+		}
+		output(node.Right, newPrefix, false, str)
+	}
+	*str += prefix
+	if isTail {
+		*str += "└── "
+	} else {
+		*str += "┌── "
+	}
+	*str += node.String() + "\n"
+	if node.Left != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "    "
+		} else {
+			newPrefix += "│   "
+		}
+		output(node.Left, newPrefix, true, str)
+	}
+}
+
+func (tree *Tree) lookup(key interface{}) *Node {
+	node := tree.Root
+	for node != nil {
+		compare := tree.Comparator(key, node.Key)
+		switch {
+		case compare == 0:
+			return node
+		case compare < 0:
+			node = node.Left
+		case compare > 0:
+			node = node.Right
+		}
+	}
+	return nil
+}
+
+// rotate moves the node above its parent, keeping the order of the keys.
+func (tree *Tree) rotate(node *Node) {
+	parent := node.Parent
+	if node == parent.Left {
+		parent.Left = node.Right
+		if node.Right != nil {
+			node.Right.Parent = parent
+		}
+		node.Right = parent
+	} else {
+		parent.Right = node.Left
+		if node.Left != nil {
+			node.Left.Parent = parent
+		}
+		node.Left = parent
+	}
+	node.Parent = parent.Parent
+	parent.Parent = node
+	switch {
+	case node.Parent == nil:
+		tree.Root = node
+	case node.Parent.Left == parent:
+		node.Parent.Left = node
+	default:
+		node.Parent.Right = node
+	}
+	parent.resize()
+	node.resize()
+}
+
+// split splits the subtree rooted at the node into the subtrees with keys smaller than the key and with the remaining keys.
+func (tree *Tree) split(node *Node, key interface{}) (left *Node, right *Node) {
+	if node == nil {
+		return nil, nil
+	}
+	if tree.Comparator(node.Key, key) < 0 {
+		node.Right, right = tree.split(node.Right, key)
+		attach(node, node.Right)
+		node.resize()
+		return node, right
+	}
+	left, node.Left = tree.split(node.Left, key)
+	attach(node, node.Left)
+	node.resize()
+	return left, node
+}
+
+// merge joins the subtrees whose keys are all smaller in the left one than in the right one.
+func merge(left *Node, right *Node) *Node {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority > right.priority:
+		left.Right = merge(left.Right, right)
+		attach(left, left.Right)
+		left.resize()
+		return left
+	default:
+		right.Left = merge(left, right.Left)
+		attach(right, right.Left)
+		right.resize()
+		return right
+	}
+}
+
+// attach sets the parent of the child, if any.
+func attach(parent *Node, child *Node) {
+	if child != nil {
+		child.Parent = parent
+	}
+}
+
+// detach makes the node a root, if any.
+func detach(node *Node) {
+	if node != nil {
+		node.Parent = nil
+	}
+}
+
+// resize recomputes the size of the node's subtree from the sizes of its children.
+func (node *Node) resize() {
+	node.size = 1 + size(node.Left) + size(node.Right)
+}
+
+// size returns the number of nodes in the subtree rooted at the node.
+func size(node *Node) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// clone copies the subtree rooted at the node, attaching it to the parent.
+func (node *Node) clone(parent *Node) *Node {
+	if node == nil {
+		return nil
+	}
+	clone := &Node{Key: node.Key, Value: node.Value, priority: node.priority, size: node.size, Parent: parent}
+	clone.Left = node.Left.clone(clone)
+	clone.Right = node.Right.clone(clone)
+	return clone
+}
+
+func (node *Node) minimumNode() *Node {
+	if node == nil {
+		return nil
+	}
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node
+}
+
+func (node *Node) maximumNode() *Node {
+	if node == nil {
+		return nil
+	}
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package treap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks the structural invariants of the tree and returns an error describing the first violation found, if any.
+// It checks that keys are in strictly ascending order, that parent pointers are consistent, that priorities of nodes
+// are not smaller than the priorities of their children and that sizes of subtrees match the numbers of their nodes.
+func (tree *Tree) Validate() error {
+	if tree.Root != nil && tree.Root.Parent != nil {
+		return errors.New("treap: root has a parent")
+	}
+	var previous *Node
+	_, err := tree.validate(tree.Root, nil, &previous)
+	return err
+}
+
+// validate checks the subtree rooted at the node attached to the parent and returns the number of its nodes.
+// Nodes are visited in-order, previous is the last visited node.
+func (tree *Tree) validate(node *Node, parent *Node, previous **Node) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	if node.Parent != parent {
+		return 0, fmt.Errorf("treap: node %v has a wrong parent", node.Key)
+	}
+	if parent != nil && parent.priority < node.priority {
+		return 0, fmt.Errorf("treap: node %v has a higher priority than its parent %v", node.Key, parent.Key)
+	}
+	left, err := tree.validate(node.Left, node, previous)
+	if err != nil {
+		return 0, err
+	}
+	if *previous != nil && tree.Comparator((*previous).Key, node.Key) >= 0 {
+		return 0, fmt.Errorf("treap: keys %v and %v are out of order", (*previous).Key, node.Key)
+	}
+	*previous = node
+	right, err := tree.validate(node.Right, node, previous)
+	if err != nil {
+		return 0, err
+	}
+	if node.size != left+right+1 {
+		return 0, fmt.Errorf("treap: node %v has size %d, but its subtree has %d nodes", node.Key, node.size, left+right+1)
+	}
+	return node.size, nil
+}
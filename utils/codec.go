@@ -0,0 +1,128 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"math"
+)
+
+// Codec encodes elements of containers into bytes and decodes them back in binary serialization (see IntCodec for example).
+//
+// Without a codec, containers encode their elements with gob, which preserves types of elements,
+// but requires custom types to be registered with gob.Register.
+type Codec interface {
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+// EncodeValues writes the values into the gob stream, each value encoded with the codec.
+// If the codec is nil, the values are written with gob as they are.
+func EncodeValues(encoder *gob.Encoder, values []interface{}, codec Codec) error {
+	if codec == nil {
+		return encoder.Encode(values)
+	}
+	data := make([][]byte, len(values))
+	for i, value := range values {
+		var err error
+		if data[i], err = codec.Encode(value); err != nil {
+			return err
+		}
+	}
+	return encoder.Encode(data)
+}
+
+// DecodeValues reads values written by EncodeValues from the gob stream, each value decoded with the codec.
+// The codec must be the same as the one the values were encoded with.
+func DecodeValues(decoder *gob.Decoder, codec Codec) ([]interface{}, error) {
+	if codec == nil {
+		values := []interface{}{}
+		err := decoder.Decode(&values)
+		return values, err
+	}
+	data := [][]byte{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(data))
+	for i := range data {
+		var err error
+		if values[i], err = codec.Decode(data[i]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+var errInvalidData = errors.New("codec: invalid data")
+
+// StringCodec encodes strings as their bytes
+var StringCodec Codec = stringCodec{}
+
+type stringCodec struct{}
+
+func (stringCodec) Encode(value interface{}) ([]byte, error) {
+	return []byte(value.(string)), nil
+}
+
+func (stringCodec) Decode(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+// IntCodec encodes ints as varints
+var IntCodec Codec = intCodec{}
+
+type intCodec struct{}
+
+func (intCodec) Encode(value interface{}) ([]byte, error) {
+	data := make([]byte, binary.MaxVarintLen64)
+	return data[:binary.PutVarint(data, int64(value.(int)))], nil
+}
+
+func (intCodec) Decode(data []byte) (interface{}, error) {
+	value, n := binary.Varint(data)
+	if n <= 0 || n != len(data) {
+		return nil, errInvalidData
+	}
+	return int(value), nil
+}
+
+// Int64Codec encodes int64s as varints
+var Int64Codec Codec = int64Codec{}
+
+type int64Codec struct{}
+
+func (int64Codec) Encode(value interface{}) ([]byte, error) {
+	data := make([]byte, binary.MaxVarintLen64)
+	return data[:binary.PutVarint(data, value.(int64))], nil
+}
+
+func (int64Codec) Decode(data []byte) (interface{}, error) {
+	value, n := binary.Varint(data)
+	if n <= 0 || n != len(data) {
+		return nil, errInvalidData
+	}
+	return value, nil
+}
+
+// Float64Codec encodes float64s as their 8 byte IEEE 754 representation
+var Float64Codec Codec = float64Codec{}
+
+type float64Codec struct{}
+
+func (float64Codec) Encode(value interface{}) ([]byte, error) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, math.Float64bits(value.(float64)))
+	return data, nil
+}
+
+func (float64Codec) Decode(data []byte) (interface{}, error) {
+	if len(data) != 8 {
+		return nil, errInvalidData
+	}
+	return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestCodecs(t *testing.T) {
+	tests := [][]interface{}{
+		{StringCodec, "", "abc"},
+		{IntCodec, 0, -1, 1 << 40},
+		{Int64Codec, int64(0), int64(-1 << 40)},
+		{Float64Codec, 0.0, -1.5},
+	}
+	for _, test := range tests {
+		codec := test[0].(Codec)
+		for _, value := range test[1:] {
+			data, err := codec.Encode(value)
+			if err != nil {
+				t.Errorf("Got error %v", err)
+			}
+			decoded, err := codec.Decode(data)
+			if actualValue, expectedValue := decoded, value; actualValue != expectedValue || err != nil {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+	if _, err := IntCodec.Decode([]byte{}); err == nil {
+		t.Errorf("Got no error for invalid data")
+	}
+	if _, err := Float64Codec.Decode([]byte{1}); err == nil {
+		t.Errorf("Got no error for invalid data")
+	}
+}
+
+func TestEncodeValues(t *testing.T) {
+	for _, codec := range []Codec{nil, IntCodec} {
+		buffer := new(bytes.Buffer)
+		err := EncodeValues(gob.NewEncoder(buffer), []interface{}{1, 2, 3}, codec)
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		values, err := DecodeValues(gob.NewDecoder(buffer), codec)
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := len(values), 3; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for i, value := range values {
+			if actualValue, expectedValue := value, i+1; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+}
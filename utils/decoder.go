@@ -0,0 +1,139 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Decoder decodes a single JSON value into an element of the type expected by a container (see IntDecoder for example).
+//
+// Containers use decoders to restore the types of their elements from JSON, e.g. keys of a tree with the IntComparator
+// have to be decoded into int, whereas the default JSON decoding produces float64.
+type Decoder func(data []byte) (interface{}, error)
+
+// Decode decodes the JSON value with the decoder.
+// If the decoder is nil, the value is decoded with Go's default JSON decoding (i.e. numbers become float64, objects become map[string]interface{}, etc.)
+func Decode(data []byte, decoder Decoder) (interface{}, error) {
+	if decoder != nil {
+		return decoder(data)
+	}
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// CompareKeys compares the keys with the comparator, returning an error instead of panicking if the comparator can not
+// compare them, e.g. a key decoded as float64 by the default JSON decoding for a comparator of ints, so that containers
+// can reject such keys while decoding them. Containers compare each decoded key with the one preceding it and the first
+// key with itself.
+func CompareKeys(comparator Comparator, a interface{}, b interface{}) (result int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not compare key %v of type %T, decode keys into the type of the comparator (see Decoder): %v", b, b, r)
+		}
+	}()
+	return comparator(a, b), nil
+}
+
+// StringDecoder decodes a JSON value into a string
+func StringDecoder(data []byte) (interface{}, error) {
+	var value string
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// IntDecoder decodes a JSON value into an int
+func IntDecoder(data []byte) (interface{}, error) {
+	var value int
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// Int8Decoder decodes a JSON value into an int8
+func Int8Decoder(data []byte) (interface{}, error) {
+	var value int8
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// Int16Decoder decodes a JSON value into an int16
+func Int16Decoder(data []byte) (interface{}, error) {
+	var value int16
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// Int32Decoder decodes a JSON value into an int32
+func Int32Decoder(data []byte) (interface{}, error) {
+	var value int32
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// Int64Decoder decodes a JSON value into an int64
+func Int64Decoder(data []byte) (interface{}, error) {
+	var value int64
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// UIntDecoder decodes a JSON value into an uint
+func UIntDecoder(data []byte) (interface{}, error) {
+	var value uint
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// UInt8Decoder decodes a JSON value into an uint8
+func UInt8Decoder(data []byte) (interface{}, error) {
+	var value uint8
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// UInt16Decoder decodes a JSON value into an uint16
+func UInt16Decoder(data []byte) (interface{}, error) {
+	var value uint16
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// UInt32Decoder decodes a JSON value into an uint32
+func UInt32Decoder(data []byte) (interface{}, error) {
+	var value uint32
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// UInt64Decoder decodes a JSON value into an uint64
+func UInt64Decoder(data []byte) (interface{}, error) {
+	var value uint64
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// Float32Decoder decodes a JSON value into a float32
+func Float32Decoder(data []byte) (interface{}, error) {
+	var value float32
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// Float64Decoder decodes a JSON value into a float64
+func Float64Decoder(data []byte) (interface{}, error) {
+	var value float64
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// TimeDecoder decodes a JSON value (RFC 3339 string) into a time.Time
+func TimeDecoder(data []byte) (interface{}, error) {
+	var value time.Time
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
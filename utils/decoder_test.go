@@ -0,0 +1,42 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import "testing"
+
+func TestDecode(t *testing.T) {
+	value, err := Decode([]byte(`1`), nil)
+	if actualValue, expectedValue := value, 1.0; actualValue != expectedValue || err != nil {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	value, err = Decode([]byte(`1`), IntDecoder)
+	if actualValue, expectedValue := value, 1; actualValue != expectedValue || err != nil {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	value, err = Decode([]byte(`"a"`), StringDecoder)
+	if actualValue, expectedValue := value, "a"; actualValue != expectedValue || err != nil {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	value, err = Decode([]byte(`"a"`), IntDecoder)
+	if err == nil {
+		t.Errorf("Got no error for mistyped value %v", value)
+	}
+}
+
+func TestCompareKeys(t *testing.T) {
+	result, err := CompareKeys(IntComparator, 2, 1)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := result, 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, err := CompareKeys(IntComparator, 1.0, 1.0); err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+	if _, err := CompareKeys(IntComparator, 2, "3"); err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"hash/fnv"
+	"strings"
+)
+
+// Hasher will make type assertion (see StringHasher for example),
+// which will panic if value is not of the asserted type.
+//
+// Should return the hash code of the value.
+// Values that are equal with respect to the accompanying Equaler must have equal hash codes.
+type Hasher func(value interface{}) uint64
+
+// Equaler will make type assertion (see StringEqualer for example),
+// which will panic if a or b are not of the asserted type.
+//
+// Should return true if a and b are equal, otherwise false.
+type Equaler func(a, b interface{}) bool
+
+// EqualerFromComparator returns an equaler that considers a and b equal if the comparator returns zero.
+func EqualerFromComparator(comparator Comparator) Equaler {
+	return func(a, b interface{}) bool {
+		return comparator(a, b) == 0
+	}
+}
+
+// StringHasher provides a FNV-1a hash of a string
+func StringHasher(value interface{}) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(value.(string)))
+	return h.Sum64()
+}
+
+// StringEqualer provides a basic equality on strings
+func StringEqualer(a, b interface{}) bool {
+	return a.(string) == b.(string)
+}
+
+// CaseInsensitiveStringHasher provides a FNV-1a hash of a lower-cased string
+func CaseInsensitiveStringHasher(value interface{}) uint64 {
+	return StringHasher(strings.ToLower(value.(string)))
+}
+
+// CaseInsensitiveStringEqualer provides a case-insensitive equality on strings
+func CaseInsensitiveStringEqualer(a, b interface{}) bool {
+	return strings.ToLower(a.(string)) == strings.ToLower(b.(string))
+}
+
+// BytesHasher provides a FNV-1a hash of a byte slice
+func BytesHasher(value interface{}) uint64 {
+	h := fnv.New64a()
+	h.Write(value.([]byte))
+	return h.Sum64()
+}
+
+// BytesEqualer provides an equality on byte slices by their contents
+func BytesEqualer(a, b interface{}) bool {
+	return string(a.([]byte)) == string(b.([]byte))
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import "testing"
+
+func TestStringHasher(t *testing.T) {
+	if StringHasher("abc") != StringHasher("abc") {
+		t.Errorf("Equal strings should have equal hashes")
+	}
+	if StringHasher("abc") == StringHasher("abd") {
+		t.Errorf("Different strings should have different hashes")
+	}
+	if !StringEqualer("abc", "abc") || StringEqualer("abc", "ABC") {
+		t.Errorf("Unexpected string equality")
+	}
+}
+
+func TestCaseInsensitiveStringHasher(t *testing.T) {
+	if CaseInsensitiveStringHasher("aBc") != CaseInsensitiveStringHasher("AbC") {
+		t.Errorf("Equal strings should have equal hashes")
+	}
+	if !CaseInsensitiveStringEqualer("aBc", "AbC") || CaseInsensitiveStringEqualer("abc", "abd") {
+		t.Errorf("Unexpected string equality")
+	}
+}
+
+func TestBytesHasher(t *testing.T) {
+	if BytesHasher([]byte{1, 2, 3}) != BytesHasher([]byte{1, 2, 3}) {
+		t.Errorf("Equal byte slices should have equal hashes")
+	}
+	if BytesHasher([]byte{1, 2, 3}) == BytesHasher([]byte{1, 2}) {
+		t.Errorf("Different byte slices should have different hashes")
+	}
+	if !BytesEqualer([]byte{1, 2}, []byte{1, 2}) || BytesEqualer([]byte{1, 2}, []byte{2, 1}) {
+		t.Errorf("Unexpected byte slice equality")
+	}
+}
+
+func TestEqualerFromComparator(t *testing.T) {
+	equaler := EqualerFromComparator(IntComparator)
+	if !equaler(1, 1) || equaler(1, 2) {
+		t.Errorf("Unexpected int equality")
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bufio"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// chunkSize is the maximum number of elements encoded at once in binary streams
+const chunkSize = 1024
+
+// WriteJSONValues writes the values returned by next into the writer as a JSON array,
+// marshalling one value at a time, until next returns false.
+func WriteJSONValues(w io.Writer, next func() (value interface{}, ok bool)) error {
+	writer := bufio.NewWriter(w)
+	writer.WriteByte('[')
+	for i := 0; ; i++ {
+		value, ok := next()
+		if !ok {
+			break
+		}
+		if i > 0 {
+			writer.WriteByte(',')
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		writer.Write(data)
+	}
+	writer.WriteByte(']')
+	return writer.Flush()
+}
+
+// WriteJSONPairs writes the key-value pairs returned by next into the writer as a JSON array of [key, value] pairs,
+// marshalling one pair at a time, until next returns false.
+func WriteJSONPairs(w io.Writer, next func() (key interface{}, value interface{}, ok bool)) error {
+	return WriteJSONValues(w, func() (interface{}, bool) {
+		key, value, ok := next()
+		return [2]interface{}{key, value}, ok
+	})
+}
+
+// WriteJSONObject writes the key-value pairs returned by next into the writer as a JSON object,
+// marshalling one pair at a time, until next returns false.
+func WriteJSONObject(w io.Writer, next func() (key string, value interface{}, ok bool)) error {
+	writer := bufio.NewWriter(w)
+	writer.WriteByte('{')
+	for i := 0; ; i++ {
+		key, value, ok := next()
+		if !ok {
+			break
+		}
+		if i > 0 {
+			writer.WriteByte(',')
+		}
+		data, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		writer.Write(data)
+		writer.WriteByte(':')
+		if data, err = json.Marshal(value); err != nil {
+			return err
+		}
+		writer.Write(data)
+	}
+	writer.WriteByte('}')
+	return writer.Flush()
+}
+
+// ReadJSONValues reads a JSON array from the reader, decoding one element at a time with the decoder (see Decode)
+// and passing it to add, so that a container is populated while the input is read instead of after collecting the values.
+// Reading stops at the first error of the input, the decoder or add.
+func ReadJSONValues(r io.Reader, decoder Decoder, add func(value interface{}) error) error {
+	return readJSON(r, '[', ']', func(stream *json.Decoder) error {
+		var data json.RawMessage
+		if err := stream.Decode(&data); err != nil {
+			return err
+		}
+		value, err := Decode(data, decoder)
+		if err != nil {
+			return err
+		}
+		return add(value)
+	})
+}
+
+// ReadJSONPairs reads a JSON array of [key, value] pairs from the reader, decoding one pair at a time with the decoders (see Decode)
+// and passing it to put (see ReadJSONValues).
+//
+// A JSON object is read as well, since containers of earlier versions wrote their pairs as an object with the keys
+// as strings (see ToString). A key of an object is decoded from its JSON string or, if the decoder fails on it,
+// from its text, e.g. "1" into 1 by the IntDecoder.
+func ReadJSONPairs(r io.Reader, keyDecoder Decoder, valueDecoder Decoder, put func(key interface{}, value interface{}) error) error {
+	reader := bufio.NewReader(r)
+	if isJSONObject(reader) {
+		return readJSONObjectPairs(reader, keyDecoder, valueDecoder, put)
+	}
+	i := 0
+	return readJSON(reader, '[', ']', func(stream *json.Decoder) error {
+		var pair []json.RawMessage
+		if err := stream.Decode(&pair); err != nil {
+			return err
+		}
+		if len(pair) != 2 {
+			return fmt.Errorf("element %d is not a [key, value] pair", i)
+		}
+		i++
+		key, err := Decode(pair[0], keyDecoder)
+		if err != nil {
+			return err
+		}
+		value, err := Decode(pair[1], valueDecoder)
+		if err != nil {
+			return err
+		}
+		return put(key, value)
+	})
+}
+
+// readJSONObjectPairs reads the pairs of a JSON object written by containers of earlier versions (see ReadJSONPairs).
+func readJSONObjectPairs(r io.Reader, keyDecoder Decoder, valueDecoder Decoder, put func(key interface{}, value interface{}) error) error {
+	return ReadJSONObject(r, valueDecoder, func(member string, value interface{}) error {
+		if keyDecoder == nil {
+			return put(member, value)
+		}
+		data, _ := json.Marshal(member)
+		key, err := keyDecoder(data)
+		if err != nil {
+			if key, err = keyDecoder([]byte(member)); err != nil {
+				return err
+			}
+		}
+		return put(key, value)
+	})
+}
+
+// isJSONObject returns true if the first character of the reader other than white space opens a JSON object.
+func isJSONObject(reader *bufio.Reader) bool {
+	for {
+		data, err := reader.Peek(1)
+		if err != nil {
+			return false
+		}
+		switch data[0] {
+		case ' ', '\t', '\n', '\r':
+			reader.ReadByte()
+		default:
+			return data[0] == '{'
+		}
+	}
+}
+
+// ReadJSONObject reads a JSON object from the reader, decoding one value at a time with the decoder (see Decode)
+// and passing it with its key to put (see ReadJSONValues).
+func ReadJSONObject(r io.Reader, valueDecoder Decoder, put func(key string, value interface{}) error) error {
+	return readJSON(r, '{', '}', func(stream *json.Decoder) error {
+		token, err := stream.Token()
+		if err != nil {
+			return err
+		}
+		var data json.RawMessage
+		if err := stream.Decode(&data); err != nil {
+			return err
+		}
+		value, err := Decode(data, valueDecoder)
+		if err != nil {
+			return err
+		}
+		return put(token.(string), value)
+	})
+}
+
+// readJSON reads a JSON array or object enclosed by the given delimiters, calling element for each of its elements.
+func readJSON(r io.Reader, open json.Delim, close json.Delim, element func(stream *json.Decoder) error) error {
+	stream := json.NewDecoder(r)
+	if token, err := stream.Token(); err != nil {
+		return err
+	} else if token != open {
+		return fmt.Errorf("expected %v, got %v", open, token)
+	}
+	for stream.More() {
+		if err := element(stream); err != nil {
+			return err
+		}
+	}
+	_, err := stream.Token()
+	return err
+}
+
+// WriteBinaryValues writes n values returned by next into the writer as a gob stream,
+// encoding each value with the codec (see EncodeValues). Values are encoded in chunks of bounded size.
+func WriteBinaryValues(w io.Writer, n int, next func() interface{}, codec Codec) error {
+	return writeBinary(w, n, func(chunk [][]interface{}) {
+		chunk[0] = append(chunk[0], next())
+	}, codec)
+}
+
+// WriteBinaryPairs writes n key-value pairs returned by next into the writer as a gob stream,
+// encoding keys and values with the codecs (see EncodeValues). Pairs are encoded in chunks of bounded size.
+func WriteBinaryPairs(w io.Writer, n int, next func() (key interface{}, value interface{}), keyCodec Codec, valueCodec Codec) error {
+	return writeBinary(w, n, func(chunk [][]interface{}) {
+		key, value := next()
+		chunk[0], chunk[1] = append(chunk[0], key), append(chunk[1], value)
+	}, keyCodec, valueCodec)
+}
+
+// ReadBinaryValues reads values written by WriteBinaryValues from the reader, decoding them with the codec
+// and passing them to add one at a time (see ReadJSONValues). Only one chunk of values is held in memory at a time.
+// The reader may be read past the end of the stream.
+func ReadBinaryValues(r io.Reader, codec Codec, add func(value interface{}) error) error {
+	return readBinary(r, func(chunk [][]interface{}, i int) error {
+		return add(chunk[0][i])
+	}, codec)
+}
+
+// ReadBinaryPairs reads key-value pairs written by WriteBinaryPairs from the reader, decoding them with the codecs
+// and passing them to put one at a time (see ReadBinaryValues).
+// The reader may be read past the end of the stream.
+func ReadBinaryPairs(r io.Reader, keyCodec Codec, valueCodec Codec, put func(key interface{}, value interface{}) error) error {
+	return readBinary(r, func(chunk [][]interface{}, i int) error {
+		return put(chunk[0][i], chunk[1][i])
+	}, keyCodec, valueCodec)
+}
+
+// writeBinary writes the number of elements followed by chunks of elements' columns (e.g. keys and values) encoded with codecs.
+func writeBinary(w io.Writer, n int, next func(chunk [][]interface{}), codecs ...Codec) error {
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(n); err != nil {
+		return err
+	}
+	chunk := make([][]interface{}, len(codecs))
+	for n > 0 {
+		size := n
+		if size > chunkSize {
+			size = chunkSize
+		}
+		for i := range chunk {
+			chunk[i] = chunk[i][:0]
+		}
+		for j := 0; j < size; j++ {
+			next(chunk)
+		}
+		for i, codec := range codecs {
+			if err := EncodeValues(encoder, chunk[i], codec); err != nil {
+				return err
+			}
+		}
+		n -= size
+	}
+	return nil
+}
+
+// readBinary reads elements' columns written by writeBinary, decoding them with codecs one chunk at a time
+// and calling element for the index of each element of the chunk.
+func readBinary(r io.Reader, element func(chunk [][]interface{}, i int) error, codecs ...Codec) error {
+	decoder := gob.NewDecoder(r)
+	var n int
+	if err := decoder.Decode(&n); err != nil {
+		return err
+	}
+	if n < 0 {
+		return errors.New("invalid number of elements")
+	}
+	chunk := make([][]interface{}, len(codecs))
+	for n > 0 {
+		size := -1
+		for i, codec := range codecs {
+			values, err := DecodeValues(decoder, codec)
+			if err != nil {
+				return err
+			}
+			if size >= 0 && len(values) != size || len(values) == 0 || len(values) > n {
+				return errors.New("invalid chunk of elements")
+			}
+			size = len(values)
+			chunk[i] = values
+		}
+		for i := 0; i < size; i++ {
+			if err := element(chunk, i); err != nil {
+				return err
+			}
+		}
+		n -= size
+	}
+	return nil
+}
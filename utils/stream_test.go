@@ -0,0 +1,210 @@
+// Copyright (c) 2015, Emir Pasic. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestJSONValuesStream(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	i := 0
+	err := WriteJSONValues(buffer, func() (interface{}, bool) {
+		i++
+		return i, i <= 3
+	})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := buffer.String(), "[1,2,3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	values := []interface{}{}
+	err = ReadJSONValues(buffer, IntDecoder, appendValue(&values))
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := len(values), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := values[2], 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, input := range []string{``, `{}`, `[1,2`, `[1,"a"]`} {
+		if err := ReadJSONValues(strings.NewReader(input), IntDecoder, appendValue(&values)); err == nil {
+			t.Errorf("Got no error for %v", input)
+		}
+	}
+	errStop := errors.New("stop")
+	values = values[:0]
+	err = ReadJSONValues(strings.NewReader(`[1,2,3]`), nil, func(value interface{}) error {
+		values = append(values, value)
+		return errStop
+	})
+	if actualValue, expectedValue := err, errStop; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := len(values), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestJSONPairsStream(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	i := 0
+	err := WriteJSONPairs(buffer, func() (interface{}, interface{}, bool) {
+		i++
+		return i, "a", i <= 2
+	})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := buffer.String(), `[[1,"a"],[2,"a"]]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	keys, values := []interface{}{}, []interface{}{}
+	err = ReadJSONPairs(buffer, IntDecoder, StringDecoder, appendPair(&keys, &values))
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := keys[1], 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := values[1], "a"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, input := range []string{`[1]`, `[[1]]`, `[[1,2,3]]`, `[["a",1]]`} {
+		if err := ReadJSONPairs(strings.NewReader(input), IntDecoder, nil, appendPair(&keys, &values)); err == nil {
+			t.Errorf("Got no error for %v", input)
+		}
+	}
+
+	// object of earlier versions
+	keys, values = keys[:0], values[:0]
+	err = ReadJSONPairs(strings.NewReader(` {"1":"a","2":"b"}`), IntDecoder, StringDecoder, appendPair(&keys, &values))
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys, values), "[1 2] [a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	keys, values = keys[:0], values[:0]
+	err = ReadJSONPairs(strings.NewReader(`{"1":"a"}`), StringDecoder, nil, appendPair(&keys, &values))
+	if actualValue, expectedValue := keys[0], "1"; actualValue != expectedValue || err != nil {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := ReadJSONPairs(strings.NewReader(`{"a":1}`), IntDecoder, nil, appendPair(&keys, &values)); err == nil {
+		t.Errorf("Got no error for mistyped key")
+	}
+}
+
+func TestJSONObjectStream(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	i := 0
+	err := WriteJSONObject(buffer, func() (string, interface{}, bool) {
+		i++
+		return ToString(i), i, i <= 2
+	})
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := buffer.String(), `{"1":1,"2":2}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	keys, values := []string{}, []interface{}{}
+	put := func(key string, value interface{}) error {
+		keys, values = append(keys, key), append(values, value)
+		return nil
+	}
+	err = ReadJSONObject(buffer, IntDecoder, put)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := keys[1], "2"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := values[1], 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, input := range []string{`[]`, `{"a"}`, `{"a":"b"}`} {
+		if err := ReadJSONObject(strings.NewReader(input), IntDecoder, put); err == nil {
+			t.Errorf("Got no error for %v", input)
+		}
+	}
+}
+
+func TestBinaryStream(t *testing.T) {
+	for _, codec := range []Codec{nil, IntCodec} {
+		for _, n := range []int{0, 1, chunkSize, 2*chunkSize + 1} {
+			buffer := new(bytes.Buffer)
+			i := 0
+			err := WriteBinaryPairs(buffer, n, func() (interface{}, interface{}) {
+				i++
+				return i, -i
+			}, codec, codec)
+			if err != nil {
+				t.Errorf("Got error %v", err)
+			}
+			keys, values := []interface{}{}, []interface{}{}
+			err = ReadBinaryPairs(buffer, codec, codec, appendPair(&keys, &values))
+			if err != nil {
+				t.Errorf("Got error %v", err)
+			}
+			if actualValue, expectedValue := len(keys), n; actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			for i := range keys {
+				if keys[i] != i+1 || values[i] != -i-1 {
+					t.Errorf("Got %v,%v expected %v,%v", keys[i], values[i], i+1, -i-1)
+				}
+			}
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	i := 0
+	err := WriteBinaryValues(buffer, 3, func() interface{} {
+		i++
+		return ToString(i)
+	}, StringCodec)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	data := buffer.Bytes()
+	values := []interface{}{}
+	err = ReadBinaryValues(bytes.NewReader(data), StringCodec, appendValue(&values))
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := values[2], "3"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := ReadBinaryValues(bytes.NewReader(data[:len(data)-1]), StringCodec, appendValue(&values)); err == nil {
+		t.Errorf("Got no error for truncated input")
+	}
+	if err := ReadBinaryValues(bytes.NewReader(data), nil, appendValue(&values)); err == nil {
+		t.Errorf("Got no error for mismatched codec")
+	}
+}
+
+// appendValue returns a function that appends the values passed to it to the slice.
+func appendValue(values *[]interface{}) func(value interface{}) error {
+	return func(value interface{}) error {
+		*values = append(*values, value)
+		return nil
+	}
+}
+
+// appendPair returns a function that appends the pairs passed to it to the slices.
+func appendPair(keys *[]interface{}, values *[]interface{}) func(key interface{}, value interface{}) error {
+	return func(key interface{}, value interface{}) error {
+		*keys, *values = append(*keys, key), append(*values, value)
+		return nil
+	}
+}